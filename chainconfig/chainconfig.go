@@ -5,6 +5,8 @@ import (
 	"errors"
 	"math/big"
 
+	"github.com/goplugin/plugin-common/pkg/types/query/primitives"
+
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 )
 
@@ -26,6 +28,9 @@ type ChainConfig struct {
 
 	// OptimisticConfirmations is the number of confirmations of a chain event before
 	// it is considered optimistically confirmed (i.e not necessarily finalized).
+	// Contract readers can only read unconfirmed or finalized data: a value of 1 reads
+	// the chain's events unconfirmed, any other value reads them finalized.
+	// See ConfidenceLevel.
 	OptimisticConfirmations uint32 `json:"optimisticConfirmations"`
 }
 
@@ -44,6 +49,17 @@ func (cc ChainConfig) Validate() error {
 	return nil
 }
 
+// ConfidenceLevel returns the confidence level at which events emitted on this chain are read, derived from
+// OptimisticConfirmations. Contract readers only distinguish finalized data from unconfirmed data, i.e. data that is
+// included in a block. A single optimistic confirmation therefore maps to unconfirmed reads, while any higher value
+// cannot be expressed by the readers and falls back to finalized reads.
+func (cc ChainConfig) ConfidenceLevel() primitives.ConfidenceLevel {
+	if cc.OptimisticConfirmations == 1 {
+		return primitives.Unconfirmed
+	}
+	return primitives.Finalized
+}
+
 // EncodeChainConfig encodes a ChainConfig into bytes using JSON.
 func EncodeChainConfig(cc ChainConfig) ([]byte, error) {
 	return json.Marshal(cc)
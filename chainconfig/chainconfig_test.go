@@ -4,6 +4,8 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/goplugin/plugin-common/pkg/types/query/primitives"
+
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 )
 
@@ -59,3 +61,23 @@ func TestChainConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestChainConfig_ConfidenceLevel(t *testing.T) {
+	tests := []struct {
+		name                    string
+		optimisticConfirmations uint32
+		want                    primitives.ConfidenceLevel
+	}{
+		{"single confirmation reads unconfirmed", 1, primitives.Unconfirmed},
+		{"multiple confirmations read finalized", 2, primitives.Finalized},
+		{"unset reads finalized", 0, primitives.Finalized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := ChainConfig{OptimisticConfirmations: tt.optimisticConfirmations}
+			if got := cc.ConfidenceLevel(); got != tt.want {
+				t.Errorf("ChainConfig.ConfidenceLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
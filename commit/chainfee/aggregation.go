@@ -0,0 +1,176 @@
+package chainfee
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/goplugin/plugin-common/pkg/types"
+
+	"github.com/goplugin/plugin-ccip/internal/libs/mathslib"
+	"github.com/goplugin/plugin-ccip/internal/plugincommon/consensus"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+	"github.com/goplugin/plugin-ccip/pluginconfig"
+)
+
+// aggregateFeeComponents aggregates the fee components observed for each chain with the aggregation method
+// configured in the chain's FeeInfo, the median by default.
+// Chains with fewer than 2f+1 observations, or whose observations could not be aggregated, are skipped.
+func (p *processor) aggregateFeeComponents(
+	feeComponents map[cciptypes.ChainSelector][]types.ChainFeeComponents,
+	fChains map[cciptypes.ChainSelector]int,
+) map[cciptypes.ChainSelector]types.ChainFeeComponents {
+	twoFChainPlus1 := consensus.MakeMultiThreshold(fChains, consensus.TwoFPlus1)
+	aggregated := make(map[cciptypes.ChainSelector]types.ChainFeeComponents)
+
+	for chain, vals := range feeComponents {
+		minObservations := 0
+		if thresh, ok := twoFChainPlus1.Get(chain); ok {
+			if len(vals) < int(thresh) {
+				p.lggr.Warnf("could not reach consensus on FeeComponents for key %v", chain)
+				continue
+			}
+			minObservations = int(thresh)
+		}
+
+		executionFees := make([]*big.Int, len(vals))
+		dataAvailabilityFees := make([]*big.Int, len(vals))
+		for i, feeComp := range vals {
+			executionFees[i] = feeComp.ExecutionFee
+			dataAvailabilityFees[i] = feeComp.DataAvailabilityFee
+		}
+
+		var aggCfg pluginconfig.FeeAggregationConfig
+		if feeInfo, ok := p.cfg.FeeInfo[chain]; ok && feeInfo.FeeAggregation != nil {
+			aggCfg = *feeInfo.FeeAggregation
+		}
+
+		executionFee, err := aggregateFees(executionFees, aggCfg, fChains[chain], minObservations)
+		if err != nil {
+			p.lggr.Warnw("could not aggregate execution fees", "chain", chain, "err", err)
+			continue
+		}
+		dataAvailabilityFee, err := aggregateFees(dataAvailabilityFees, aggCfg, fChains[chain], minObservations)
+		if err != nil {
+			p.lggr.Warnw("could not aggregate data availability fees", "chain", chain, "err", err)
+			continue
+		}
+
+		aggregated[chain] = types.ChainFeeComponents{
+			ExecutionFee:        executionFee,
+			DataAvailabilityFee: dataAvailabilityFee,
+		}
+	}
+
+	return aggregated
+}
+
+// aggregateFees aggregates the fees observed by the oracles with the configured method.
+// f is the f of the chain and minObservations the number of observations required for consensus.
+func aggregateFees(
+	fees []*big.Int,
+	cfg pluginconfig.FeeAggregationConfig,
+	f int,
+	minObservations int,
+) (*big.Int, error) {
+	if len(fees) == 0 {
+		return nil, fmt.Errorf("no fees to aggregate")
+	}
+
+	switch cfg.Method {
+	case "", pluginconfig.FeeAggregationMedian:
+		return consensus.MedianBig(fees), nil
+	case pluginconfig.FeeAggregationTrimmedMean:
+		trimCount := cfg.TrimCount
+		if trimCount == 0 {
+			trimCount = f
+		}
+		return consensus.TrimmedMean(fees, trimCount), nil
+	case pluginconfig.FeeAggregationMedianMaxSpread:
+		return medianMaxSpread(fees, cfg.MaxSpreadPPB, minObservations)
+	default:
+		return nil, fmt.Errorf("unknown fee aggregation method %q", cfg.Method)
+	}
+}
+
+// medianMaxSpread drops the values deviating from the median by more than maxSpreadPPB and returns the median
+// of the rest. It fails if fewer than minObservations values are left.
+func medianMaxSpread(vals []*big.Int, maxSpreadPPB int64, minObservations int) (*big.Int, error) {
+	median := consensus.MedianBig(vals)
+
+	kept := make([]*big.Int, 0, len(vals))
+	for _, val := range vals {
+		if !mathslib.Deviates(val, median, maxSpreadPPB) {
+			kept = append(kept, val)
+		}
+	}
+
+	if len(kept) < minObservations || len(kept) == 0 {
+		return nil, fmt.Errorf("only %d of %d observations within %d ppb of the median %s, need %d",
+			len(kept), len(vals), maxSpreadPPB, median, minObservations)
+	}
+
+	return consensus.MedianBig(kept), nil
+}
+
+// smoothChainFees smooths the chain fees of the chains with a smoothing weight with an exponential moving average
+// over the smoothed chain fees of the previous outcome:
+// smoothed = (weight * current + (100 - weight) * previous) / 100.
+// The chain fees are updated in place, the smoothed chain fees to carry over to the next outcome are returned.
+// The previous smoothed chain fees of the chains without chain fees in this round are carried over unchanged.
+func (p *processor) smoothChainFees(
+	chainFees map[cciptypes.ChainSelector]ComponentsUSDPrices,
+	prevSmoothed map[cciptypes.ChainSelector]ComponentsUSDPrices,
+) map[cciptypes.ChainSelector]ComponentsUSDPrices {
+	var smoothed map[cciptypes.ChainSelector]ComponentsUSDPrices
+	setSmoothed := func(chain cciptypes.ChainSelector, chainFee ComponentsUSDPrices) {
+		if smoothed == nil {
+			smoothed = make(map[cciptypes.ChainSelector]ComponentsUSDPrices)
+		}
+		smoothed[chain] = chainFee
+	}
+
+	for chain, chainFee := range chainFees {
+		weight, ok := p.smoothingWeight(chain)
+		if !ok {
+			continue
+		}
+
+		if prev, ok := prevSmoothed[chain]; ok && prev.ExecutionFeePriceUSD != nil && prev.DataAvFeePriceUSD != nil {
+			chainFee = ComponentsUSDPrices{
+				ExecutionFeePriceUSD: ema(chainFee.ExecutionFeePriceUSD, prev.ExecutionFeePriceUSD, weight),
+				DataAvFeePriceUSD:    ema(chainFee.DataAvFeePriceUSD, prev.DataAvFeePriceUSD, weight),
+			}
+			chainFees[chain] = chainFee
+		}
+
+		setSmoothed(chain, chainFee)
+	}
+
+	for chain, prev := range prevSmoothed {
+		if _, ok := chainFees[chain]; ok {
+			continue
+		}
+		if _, ok := p.smoothingWeight(chain); ok {
+			setSmoothed(chain, prev)
+		}
+	}
+
+	return smoothed
+}
+
+// smoothingWeight returns the smoothing weight percent of the chain and whether its chain fees are smoothed.
+func (p *processor) smoothingWeight(chain cciptypes.ChainSelector) (int64, bool) {
+	feeInfo, ok := p.cfg.FeeInfo[chain]
+	if !ok || feeInfo.FeeAggregation == nil {
+		return 0, false
+	}
+	weight := int64(feeInfo.FeeAggregation.SmoothingWeightPercent)
+	return weight, weight != 0 && weight != 100
+}
+
+// ema returns (weightPercent * current + (100 - weightPercent) * previous) / 100.
+func ema(current, previous *big.Int, weightPercent int64) *big.Int {
+	weighted := new(big.Int).Mul(current, big.NewInt(weightPercent))
+	weighted.Add(weighted, new(big.Int).Mul(previous, big.NewInt(100-weightPercent)))
+	return weighted.Div(weighted, big.NewInt(100))
+}
@@ -0,0 +1,192 @@
+package chainfee
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/types"
+
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+	"github.com/goplugin/plugin-ccip/pluginconfig"
+)
+
+func bigInts(vals ...int64) []*big.Int {
+	res := make([]*big.Int, len(vals))
+	for i, val := range vals {
+		res[i] = big.NewInt(val)
+	}
+	return res
+}
+
+func Test_aggregateFees(t *testing.T) {
+	testCases := []struct {
+		name            string
+		fees            []*big.Int
+		cfg             pluginconfig.FeeAggregationConfig
+		f               int
+		minObservations int
+		exp             int64
+		expErr          bool
+	}{
+		{
+			name: "median by default",
+			fees: bigInts(10, 1000, 12, 11, 13),
+			exp:  12,
+		},
+		{
+			name: "trimmed mean drops f lowest and highest fees by default",
+			fees: bigInts(1, 10, 1000, 12, 14),
+			cfg:  pluginconfig.FeeAggregationConfig{Method: pluginconfig.FeeAggregationTrimmedMean},
+			f:    1,
+			exp:  12, // (10 + 12 + 14) / 3
+		},
+		{
+			name: "trimmed mean with trim count",
+			fees: bigInts(1, 10, 1000, 12, 14, 2000, 0),
+			cfg:  pluginconfig.FeeAggregationConfig{Method: pluginconfig.FeeAggregationTrimmedMean, TrimCount: 2},
+			f:    1,
+			exp:  12, // (10 + 12 + 14) / 3
+		},
+		{
+			name: "trimmed mean falls back to the median without enough fees",
+			fees: bigInts(10, 20),
+			cfg:  pluginconfig.FeeAggregationConfig{Method: pluginconfig.FeeAggregationTrimmedMean},
+			f:    1,
+			exp:  20,
+		},
+		{
+			name: "median max spread rejects outliers",
+			fees: bigInts(100, 105, 95, 1000, 102),
+			cfg: pluginconfig.FeeAggregationConfig{
+				Method:       pluginconfig.FeeAggregationMedianMaxSpread,
+				MaxSpreadPPB: 1e8, // 10%
+			},
+			minObservations: 3,
+			exp:             102, // median of 95, 100, 102, 105
+		},
+		{
+			name: "median max spread fails with too many outliers",
+			fees: bigInts(100, 200, 400, 800, 1600),
+			cfg: pluginconfig.FeeAggregationConfig{
+				Method:       pluginconfig.FeeAggregationMedianMaxSpread,
+				MaxSpreadPPB: 1e8, // 10%
+			},
+			minObservations: 3,
+			expErr:          true,
+		},
+		{
+			name:   "unknown method",
+			fees:   bigInts(100),
+			cfg:    pluginconfig.FeeAggregationConfig{Method: "mode"},
+			expErr: true,
+		},
+		{
+			name:   "no fees",
+			expErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			fee, err := aggregateFees(tc.fees, tc.cfg, tc.f, tc.minObservations)
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, fee.Int64())
+		})
+	}
+}
+
+func TestProcessor_aggregateFeeComponents(t *testing.T) {
+	feeComps := func(vals ...int64) []types.ChainFeeComponents {
+		res := make([]types.ChainFeeComponents, len(vals))
+		for i, val := range vals {
+			res[i] = types.ChainFeeComponents{ExecutionFee: big.NewInt(val), DataAvailabilityFee: big.NewInt(0)}
+		}
+		return res
+	}
+
+	p := &processor{
+		lggr: logger.Test(t),
+		cfg: pluginconfig.CommitOffchainConfig{
+			FeeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				2: {FeeAggregation: &pluginconfig.FeeAggregationConfig{Method: pluginconfig.FeeAggregationTrimmedMean}},
+				3: {FeeAggregation: &pluginconfig.FeeAggregationConfig{
+					Method:       pluginconfig.FeeAggregationMedianMaxSpread,
+					MaxSpreadPPB: 1e8,
+				}},
+			},
+		},
+	}
+
+	aggregated := p.aggregateFeeComponents(
+		map[cciptypes.ChainSelector][]types.ChainFeeComponents{
+			1: feeComps(10, 20, 30, 40, 1000), // median
+			2: feeComps(10, 20, 30, 40, 1000), // trimmed mean
+			3: feeComps(10, 20, 30, 40, 1000), // too spread
+			4: feeComps(10, 20),               // not enough observations
+		},
+		map[cciptypes.ChainSelector]int{1: 1, 2: 1, 3: 1, 4: 1},
+	)
+
+	require.Len(t, aggregated, 2)
+	assert.Equal(t, int64(30), aggregated[1].ExecutionFee.Int64())
+	assert.Equal(t, int64(30), aggregated[2].ExecutionFee.Int64())
+	assert.Equal(t, int64(0), aggregated[2].DataAvailabilityFee.Int64())
+}
+
+func TestProcessor_smoothChainFees(t *testing.T) {
+	usdPrices := func(execFee, dataAvFee int64) ComponentsUSDPrices {
+		return ComponentsUSDPrices{
+			ExecutionFeePriceUSD: big.NewInt(execFee),
+			DataAvFeePriceUSD:    big.NewInt(dataAvFee),
+		}
+	}
+
+	p := &processor{
+		lggr: logger.Test(t),
+		cfg: pluginconfig.CommitOffchainConfig{
+			FeeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				1: {FeeAggregation: &pluginconfig.FeeAggregationConfig{SmoothingWeightPercent: 25}},
+				2: {FeeAggregation: &pluginconfig.FeeAggregationConfig{SmoothingWeightPercent: 25}},
+				3: {},
+				4: {FeeAggregation: &pluginconfig.FeeAggregationConfig{SmoothingWeightPercent: 25}},
+			},
+		},
+	}
+
+	chainFees := map[cciptypes.ChainSelector]ComponentsUSDPrices{
+		1: usdPrices(200, 40),
+		2: usdPrices(200, 40),
+		3: usdPrices(200, 40),
+	}
+	prevSmoothed := map[cciptypes.ChainSelector]ComponentsUSDPrices{
+		1: usdPrices(100, 20),
+		// chain 4 has no chain fees in this round, chain 5 is no longer smoothed
+		4: usdPrices(300, 60),
+		5: usdPrices(300, 60),
+	}
+
+	smoothed := p.smoothChainFees(chainFees, prevSmoothed)
+
+	// chain 1 is smoothed: 0.25 * 200 + 0.75 * 100 = 125, 0.25 * 40 + 0.75 * 20 = 25
+	assert.Equal(t, usdPrices(125, 25), chainFees[1])
+	// chain 2 has no previous smoothed fees, the current fees are used
+	assert.Equal(t, usdPrices(200, 40), chainFees[2])
+	// chain 3 has no smoothing
+	assert.Equal(t, usdPrices(200, 40), chainFees[3])
+
+	assert.Equal(t, map[cciptypes.ChainSelector]ComponentsUSDPrices{
+		1: usdPrices(125, 25),
+		2: usdPrices(200, 40),
+		4: usdPrices(300, 60),
+	}, smoothed)
+}
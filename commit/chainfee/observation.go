@@ -2,10 +2,14 @@ package chainfee
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"time"
 
 	"golang.org/x/exp/maps"
 
+	"github.com/goplugin/plugin-ccip/commit/metrics"
+	"github.com/goplugin/plugin-ccip/internal/plugincommon"
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 )
 
@@ -27,6 +31,18 @@ func (p *processor) Observation(
 
 	fChain := p.ObserveFChain()
 
+	// The readers skip chains which could not be read, a failing chain does not affect the others.
+	failures := missingNativeTokenPrices(availableChains, nativeTokenPrices)
+	if len(failures) > 0 {
+		p.lggr.Warnw("some native token prices could not be observed, continuing with the others",
+			"failures", failures.Err())
+		if p.metrics != nil {
+			for _, f := range failures {
+				p.metrics.TrackError(metrics.PhaseObservation, f.Object)
+			}
+		}
+	}
+
 	p.lggr.Infow("observed fee components",
 		"feeComponents", feeComponents,
 		"nativeTokenPrices", nativeTokenPrices,
@@ -51,3 +67,22 @@ func (p *processor) ObserveFChain() map[cciptypes.ChainSelector]int {
 	}
 	return fChain
 }
+
+// missingNativeTokenPrices returns a failure for each chain with fee components but without a native token price.
+func missingNativeTokenPrices(
+	chains []cciptypes.ChainSelector,
+	nativeTokenPrices map[cciptypes.ChainSelector]cciptypes.BigInt,
+) plugincommon.ObservationFailures {
+	var failures plugincommon.ObservationFailures
+	for _, chain := range chains {
+		if _, ok := nativeTokenPrices[chain]; !ok {
+			failures = append(failures, plugincommon.ObservationFailure{
+				Object:   "nativeTokenPrice",
+				ChainSel: chain,
+				Err:      errors.New("native token price not available"),
+			})
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].ChainSel < failures[j].ChainSel })
+	return failures
+}
@@ -2,7 +2,10 @@ package chainfee
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"sort"
 	"time"
 
@@ -21,15 +24,18 @@ func (p *processor) Outcome(
 	query Query,
 	aos []plugincommon.AttributedObservation[Observation],
 ) (Outcome, error) {
+	// The smoothed chain fees are carried over when nothing is updated, so that the moving averages survive rounds
+	// without consensus.
 	consensusObs, err := p.getConsensusObservation(aos)
 	if err != nil {
-		return Outcome{}, fmt.Errorf("get consensus observation: %w", err)
+		return Outcome{SmoothedChainFees: p.smoothChainFees(nil, prevOutcome.SmoothedChainFees)},
+			fmt.Errorf("get consensus observation: %w", err)
 	}
 	// No need to update yet
 	if len(consensusObs.FeeComponents) == 0 {
 		p.lggr.Debug("no consensus on fee components, nothing to update",
 			"consensusObs", consensusObs)
-		return Outcome{}, nil
+		return Outcome{SmoothedChainFees: p.smoothChainFees(nil, prevOutcome.SmoothedChainFees)}, nil
 	}
 
 	// Stop early if earliest updated timestamp is still fresh
@@ -75,6 +81,8 @@ func (p *processor) Outcome(
 		chainFeeUSDPrices[chain] = chainFeeUsd
 	}
 
+	smoothedChainFees := p.smoothChainFees(chainFeeUSDPrices, prevOutcome.SmoothedChainFees)
+
 	gasPrices := p.getGasPricesToUpdate(
 		chainFeeUSDPrices,
 		consensusObs.ChainFeeUpdates,
@@ -91,7 +99,8 @@ func (p *processor) Outcome(
 	)
 
 	return Outcome{
-		GasPrices: gasPrices,
+		GasPrices:         gasPrices,
+		SmoothedChainFees: smoothedChainFees,
 	}, nil
 }
 
@@ -103,7 +112,7 @@ func (p *processor) getConsensusObservation(
 	// consensus on the fChain map uses the role DON F value
 	// because all nodes can observe the home chain.
 	donThresh := consensus.MakeConstantThreshold[cciptypes.ChainSelector](consensus.TwoFPlus1(p.fRoleDON))
-	fChains := consensus.GetConsensusMap(p.lggr, "fChain", aggObs.FChain, donThresh)
+	fChains := consensus.GetConsensusMap(p.lggr, "fChain", aggObs.FChain, donThresh, p.metrics)
 
 	fDestChain, exists := fChains[p.destChain]
 	if !exists {
@@ -126,37 +135,14 @@ func (p *processor) getConsensusObservation(
 		ChainFeeUpdateAggregator,
 	)
 
-	twoFChainPlus1 := consensus.MakeMultiThreshold(fChains, consensus.TwoFPlus1)
-
-	feeComponents := consensus.GetConsensusMapAggregator(
-		p.lggr,
-		"FeeComponents",
-		aggObs.FeeComponents,
-		twoFChainPlus1,
-		// Aggregator function
-		func(vals []types.ChainFeeComponents) types.ChainFeeComponents {
-			executionFees := make([]cciptypes.BigInt, len(vals))
-			dataAvailabilityFees := make([]cciptypes.BigInt, len(vals))
-			for i, feeComp := range vals {
-				executionFees[i] = cciptypes.NewBigInt(feeComp.ExecutionFee)
-				dataAvailabilityFees[i] = cciptypes.NewBigInt(feeComp.DataAvailabilityFee)
-			}
-			return types.ChainFeeComponents{
-				ExecutionFee:        consensus.Median(executionFees, consensus.BigIntComparator).Int,
-				DataAvailabilityFee: consensus.Median(dataAvailabilityFees, consensus.BigIntComparator).Int,
-			}
-		},
-	)
+	feeComponents := p.aggregateFeeComponents(aggObs.FeeComponents, fChains)
 
 	nativeTokenPrices := consensus.GetConsensusMapAggregator(
 		p.lggr,
 		"NativeTokenPrices",
 		aggObs.NativeTokenPrices,
-		twoFChainPlus1,
-		// Aggregator function
-		func(vals []cciptypes.BigInt) cciptypes.BigInt {
-			return consensus.Median(vals, consensus.BigIntComparator)
-		},
+		consensus.MakeMultiThreshold(fChains, consensus.TwoFPlus1),
+		consensus.MedianBig[cciptypes.BigInt],
 	)
 
 	consensusObs := Observation{
@@ -211,57 +197,174 @@ func aggregateObservations(aos []plugincommon.AttributedObservation[Observation]
 // getGasPricesToUpdate checks which chain fees need to be updated based on the observed chain fee prices and
 // the fee quoter updates.
 // A chain fee is selected for update if it meets one of 2 conditions:
-// 1. If time passed since the last update is greater than the stale threshold.
+// 1. If time passed since the last update is greater than the chain's heartbeat.
 // 2. If deviation between the fee quoter and latest observed chain fee exceeds the chain's configured threshold.
+// If more chains are selected than MaxGasPriceUpdatesPerReport, the chains missing from the fee quoter and then the
+// most urgent ones are kept.
 func (p *processor) getGasPricesToUpdate(
 	currentChainUSDFees map[cciptypes.ChainSelector]ComponentsUSDPrices,
 	latestUpdates map[cciptypes.ChainSelector]Update,
 	obsTimestamp time.Time,
 ) []cciptypes.GasPriceChain {
-	var gasPrices []cciptypes.GasPriceChain
-	feeInfo := p.cfg.FeeInfo
+	var updates []gasPriceUpdate
 
 	for chain, currentChainFee := range currentChainUSDFees {
-		packedFee := cciptypes.NewBigInt(currentChainFee.ToPackedFee())
+		gasPrice := cciptypes.GasPriceChain{
+			ChainSel: chain,
+			GasPrice: cciptypes.NewBigInt(currentChainFee.ToPackedFee()),
+		}
+
 		lastUpdate, exists := latestUpdates[chain]
-		nextUpdateTime := lastUpdate.Timestamp.Add(p.cfg.RemoteGasPriceBatchWriteFrequency.Duration())
-		// If the chain is not in the fee quoter updates or is stale, then we should update it
-		if !exists || obsTimestamp.After(nextUpdateTime) {
-			gasPrices = append(gasPrices, cciptypes.GasPriceChain{
-				ChainSel: chain,
-				GasPrice: packedFee,
-			})
+		// If the chain is not in the fee quoter updates, then we should update it
+		if !exists {
+			updates = append(updates, gasPriceUpdate{price: gasPrice, urgency: math.Inf(1)})
 			continue
 		}
 
-		if feeInfo == nil {
-			continue
+		heartbeat := p.cfg.RemoteGasPriceBatchWriteFrequency.Duration()
+		if ci, ok := p.cfg.FeeInfo[chain]; ok && ci.Heartbeat.Duration() > 0 {
+			heartbeat = ci.Heartbeat.Duration()
 		}
-		ci, ok := feeInfo[chain]
-		if !ok {
-			p.lggr.Warnf("could not find fee info for chain %d", chain)
+		sinceLastUpdate := obsTimestamp.Sub(lastUpdate.Timestamp)
+		isStale := obsTimestamp.After(lastUpdate.Timestamp.Add(heartbeat))
+
+		execDeviationPPB, dataAvDeviationPPB, err := p.getDeviationThresholds(chain)
+		if err != nil {
+			p.lggr.Warnw("could not get deviation thresholds for chain", "chain", chain, "err", err)
+			// If the chain fee is stale, then we should update it, ranked by how overdue it is
+			if isStale {
+				updates = append(updates, gasPriceUpdate{
+					price:   gasPrice,
+					urgency: updateUrgency(sinceLastUpdate, heartbeat, nil),
+				})
+			}
 			continue
 		}
 
 		executionFeeDeviates := mathslib.Deviates(
 			currentChainFee.ExecutionFeePriceUSD,
 			lastUpdate.ChainFee.ExecutionFeePriceUSD,
-			ci.ExecDeviationPPB.Int64(),
+			execDeviationPPB,
 		)
 
 		dataAvFeeDeviates := mathslib.Deviates(
 			currentChainFee.DataAvFeePriceUSD,
 			lastUpdate.ChainFee.DataAvFeePriceUSD,
-			ci.DataAvailabilityDeviationPPB.Int64(),
+			dataAvDeviationPPB,
 		)
 
-		if executionFeeDeviates || dataAvFeeDeviates {
-			gasPrices = append(gasPrices, cciptypes.GasPriceChain{
-				ChainSel: chain,
-				GasPrice: packedFee,
+		if isStale || executionFeeDeviates || dataAvFeeDeviates {
+			updates = append(updates, gasPriceUpdate{
+				price: gasPrice,
+				urgency: updateUrgency(sinceLastUpdate, heartbeat, []feeDeviation{
+					{current: currentChainFee.ExecutionFeePriceUSD, last: lastUpdate.ChainFee.ExecutionFeePriceUSD,
+						thresholdPPB: execDeviationPPB},
+					{current: currentChainFee.DataAvFeePriceUSD, last: lastUpdate.ChainFee.DataAvFeePriceUSD,
+						thresholdPPB: dataAvDeviationPPB},
+				}),
 			})
 		}
 	}
 
+	if maxUpdates := p.cfg.MaxGasPriceUpdatesPerReport; maxUpdates > 0 && len(updates) > maxUpdates {
+		// keep the most urgent updates, ties are broken by chain selector to be deterministic across nodes
+		sort.Slice(updates, func(i, j int) bool {
+			if updates[i].urgency != updates[j].urgency {
+				return updates[i].urgency > updates[j].urgency
+			}
+			return updates[i].price.ChainSel < updates[j].price.ChainSel
+		})
+		p.lggr.Infow("capping gas price updates",
+			"maxUpdates", maxUpdates, "numUpdates", len(updates), "skipped", updates[maxUpdates:])
+		updates = updates[:maxUpdates]
+	}
+
+	var gasPrices []cciptypes.GasPriceChain
+	for _, update := range updates {
+		gasPrices = append(gasPrices, update.price)
+	}
 	return gasPrices
 }
+
+// getDeviationThresholds returns the execution and data availability fee deviation thresholds of the chain in ppb.
+// The thresholds are taken from the chain config on the home chain and can be overridden by the chain's FeeInfo.
+// If no data availability fee threshold is set, the execution fee threshold is used.
+func (p *processor) getDeviationThresholds(chain cciptypes.ChainSelector) (int64, int64, error) {
+	var execDeviationPPB, dataAvDeviationPPB cciptypes.BigInt
+
+	chainConfig, err := p.homeChain.GetChainConfig(chain)
+	if err == nil {
+		execDeviationPPB = chainConfig.Config.GasPriceDeviationPPB
+		dataAvDeviationPPB = chainConfig.Config.DAGasPriceDeviationPPB
+	}
+
+	if feeInfo, ok := p.cfg.FeeInfo[chain]; ok {
+		if !feeInfo.ExecDeviationPPB.IsEmpty() {
+			execDeviationPPB = feeInfo.ExecDeviationPPB
+		}
+		if !feeInfo.DataAvailabilityDeviationPPB.IsEmpty() {
+			dataAvDeviationPPB = feeInfo.DataAvailabilityDeviationPPB
+		}
+	}
+
+	if execDeviationPPB.IsEmpty() {
+		if err != nil {
+			return 0, 0, fmt.Errorf("no fee info and chain config unavailable: %w", err)
+		}
+		return 0, 0, errors.New("no gas price deviation threshold in the chain config or fee info")
+	}
+
+	if dataAvDeviationPPB.IsEmpty() {
+		dataAvDeviationPPB = execDeviationPPB
+	}
+
+	return execDeviationPPB.Int64(), dataAvDeviationPPB.Int64(), nil
+}
+
+// gasPriceUpdate is a gas price selected for update with the urgency of the update.
+type gasPriceUpdate struct {
+	price   cciptypes.GasPriceChain
+	urgency float64
+}
+
+// feeDeviation is a fee component of a chain with its last update and deviation threshold.
+type feeDeviation struct {
+	current, last *big.Int
+	thresholdPPB  int64
+}
+
+// updateUrgency returns how urgent a gas price update is, as the greatest of how overdue the update is relative
+// to the heartbeat and how far each fee deviates relative to its deviation threshold.
+// e.g. a chain whose execution fee deviates twice its threshold is as urgent as a chain last updated two heartbeats
+// ago. A fee deviating from or to zero, or deviating without a threshold, makes the update infinitely urgent.
+func updateUrgency(sinceLastUpdate, heartbeat time.Duration, fees []feeDeviation) float64 {
+	urgency := 0.0
+	if heartbeat > 0 {
+		urgency = float64(sinceLastUpdate) / float64(heartbeat)
+	}
+
+	for _, fee := range fees {
+		if fee.current == nil || fee.last == nil {
+			continue
+		}
+		deviation, ok := mathslib.DeviationPPB(fee.current, fee.last)
+		if !ok {
+			if fee.current.Cmp(fee.last) != 0 {
+				return math.Inf(1)
+			}
+			continue
+		}
+		if fee.thresholdPPB <= 0 {
+			if deviation.Sign() > 0 {
+				return math.Inf(1)
+			}
+			continue
+		}
+		deviationUrgency, _ := new(big.Float).Quo(
+			new(big.Float).SetInt(deviation),
+			new(big.Float).SetInt64(fee.thresholdPPB),
+		).Float64()
+		urgency = math.Max(urgency, deviationUrgency)
+	}
+	return urgency
+}
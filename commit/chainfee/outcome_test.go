@@ -1,11 +1,13 @@
 package chainfee
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/goplugin/plugin-libocr/commontypes"
@@ -15,7 +17,10 @@ import (
 	"github.com/goplugin/plugin-common/pkg/types"
 	"github.com/goplugin/plugin-common/pkg/utils/tests"
 
+	"github.com/goplugin/plugin-ccip/chainconfig"
 	"github.com/goplugin/plugin-ccip/internal/plugincommon"
+	"github.com/goplugin/plugin-ccip/internal/reader"
+	readermock "github.com/goplugin/plugin-ccip/mocks/internal_/reader"
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 	"github.com/goplugin/plugin-ccip/pluginconfig"
 )
@@ -39,13 +44,19 @@ var fChains = map[cciptypes.ChainSelector]int{
 	2: 2,
 }
 
+// the USD prices of feeComponentsMap with nativeTokenPricesMap
+var chainFeesUSD = map[cciptypes.ChainSelector]ComponentsUSDPrices{
+	1: {ExecutionFeePriceUSD: big.NewInt(100), DataAvFeePriceUSD: big.NewInt(200)},
+	2: {ExecutionFeePriceUSD: big.NewInt(300), DataAvFeePriceUSD: big.NewInt(500)},
+}
+
 var obsNeedUpdate = Observation{
 	FeeComponents:     feeComponentsMap,
 	NativeTokenPrices: nativeTokenPricesMap,
 	FChain:            fChains,
 	ChainFeeUpdates: map[cciptypes.ChainSelector]Update{
-		1: {Timestamp: ts},
-		2: {Timestamp: ts.Add(-chainFeePriceBatchWriteFrequency.Duration() * 2)}, // Needs updating
+		1: {Timestamp: ts, ChainFee: chainFeesUSD[1]},
+		2: {Timestamp: ts.Add(-chainFeePriceBatchWriteFrequency.Duration() * 2), ChainFee: chainFeesUSD[2]}, // Needs updating
 	},
 	TimestampNow: ts,
 }
@@ -55,8 +66,23 @@ var obsNoUpdate = Observation{
 	NativeTokenPrices: nativeTokenPricesMap,
 	FChain:            fChains,
 	ChainFeeUpdates: map[cciptypes.ChainSelector]Update{
-		1: {Timestamp: ts},
-		2: {Timestamp: ts},
+		1: {Timestamp: ts, ChainFee: chainFeesUSD[1]},
+		2: {Timestamp: ts, ChainFee: chainFeesUSD[2]},
+	},
+	TimestampNow: ts,
+}
+
+var obsDeviates = Observation{
+	FeeComponents:     feeComponentsMap,
+	NativeTokenPrices: nativeTokenPricesMap,
+	FChain:            fChains,
+	ChainFeeUpdates: map[cciptypes.ChainSelector]Update{
+		// execution fee deviates by 100% from the last update, above the chain config threshold of 10%
+		1: {Timestamp: ts, ChainFee: ComponentsUSDPrices{
+			ExecutionFeePriceUSD: big.NewInt(50),
+			DataAvFeePriceUSD:    chainFeesUSD[1].DataAvFeePriceUSD,
+		}},
+		2: {Timestamp: ts, ChainFee: chainFeesUSD[2]},
 	},
 	TimestampNow: ts,
 }
@@ -114,6 +140,7 @@ func TestProcessor_Outcome(t *testing.T) {
 		name                   string
 		chainFeeWriteFrequency commonconfig.Duration
 		feeInfo                map[cciptypes.ChainSelector]pluginconfig.FeeInfo
+		prevOutcome            Outcome
 		aos                    []plugincommon.AttributedObservation[Observation]
 		expectedError          bool
 		expectedOutcome        func() Outcome
@@ -158,28 +185,295 @@ func TestProcessor_Outcome(t *testing.T) {
 			},
 			chainFeeWriteFrequency: chainFeePriceBatchWriteFrequency,
 		},
-		//TODO: Add test to check that deviated prices updates
+		{
+			name: "smoothed chain fees are carried over when there is no consensus",
+			aos:  []plugincommon.AttributedObservation[Observation]{},
+			feeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				7: {FeeAggregation: &pluginconfig.FeeAggregationConfig{SmoothingWeightPercent: 25}},
+			},
+			prevOutcome: Outcome{SmoothedChainFees: map[cciptypes.ChainSelector]ComponentsUSDPrices{
+				7: chainFeesUSD[1],
+			}},
+			expectedError: true,
+			expectedOutcome: func() Outcome {
+				return Outcome{SmoothedChainFees: map[cciptypes.ChainSelector]ComponentsUSDPrices{7: chainFeesUSD[1]}}
+			},
+		},
+		{
+			name: "smoothed chain fees are carried over when no need to update",
+			aos:  SameObs(5, obsNoUpdate),
+			feeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				7: {FeeAggregation: &pluginconfig.FeeAggregationConfig{SmoothingWeightPercent: 25}},
+			},
+			prevOutcome: Outcome{SmoothedChainFees: map[cciptypes.ChainSelector]ComponentsUSDPrices{
+				7: chainFeesUSD[1],
+			}},
+			expectedOutcome: func() Outcome {
+				return Outcome{SmoothedChainFees: map[cciptypes.ChainSelector]ComponentsUSDPrices{7: chainFeesUSD[1]}}
+			},
+			chainFeeWriteFrequency: chainFeePriceBatchWriteFrequency,
+		},
+		{
+			name:          "Outcome gas prices when the chain fee deviates above the chain config threshold",
+			aos:           SameObs(5, obsDeviates),
+			expectedError: false,
+			expectedOutcome: func() Outcome {
+				return Outcome{
+					GasPrices: []cciptypes.GasPriceChain{
+						{
+							ChainSel: 1,
+							GasPrice: cciptypes.NewBigInt(chainFeesUSD[1].ToPackedFee()),
+						},
+					},
+				}
+			},
+			chainFeeWriteFrequency: chainFeePriceBatchWriteFrequency,
+		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := tests.Context(t)
+			homeChain := readermock.NewMockHomeChain(t)
+			homeChain.EXPECT().GetChainConfig(mock.Anything).Return(reader.ChainConfig{
+				Config: chainconfig.ChainConfig{GasPriceDeviationPPB: cciptypes.NewBigInt(big.NewInt(1e8))},
+			}, nil).Maybe()
 			p := &processor{
 				lggr:      logger.Test(t),
 				destChain: 1,
 				fRoleDON:  1,
+				homeChain: homeChain,
 				cfg: pluginconfig.CommitOffchainConfig{
 					RemoteGasPriceBatchWriteFrequency: tt.chainFeeWriteFrequency,
+					FeeInfo:                           tt.feeInfo,
 				},
 			}
 
-			outcome, err := p.Outcome(ctx, Outcome{}, Query{}, tt.aos)
+			outcome, err := p.Outcome(ctx, tt.prevOutcome, Query{}, tt.aos)
 			if tt.expectedError {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
-				assert.Equal(t, tt.expectedOutcome(), outcome)
 			}
+			assert.Equal(t, tt.expectedOutcome(), outcome)
+		})
+	}
+}
+
+func TestProcessor_getGasPricesToUpdate(t *testing.T) {
+	fees := func(execFee, dataAvFee int64) ComponentsUSDPrices {
+		return ComponentsUSDPrices{
+			ExecutionFeePriceUSD: big.NewInt(execFee),
+			DataAvFeePriceUSD:    big.NewInt(dataAvFee),
+		}
+	}
+	feeInfo := func(heartbeat time.Duration) pluginconfig.FeeInfo {
+		return pluginconfig.FeeInfo{
+			ExecDeviationPPB:             cciptypes.NewBigInt(big.NewInt(1e8)), // 10%
+			DataAvailabilityDeviationPPB: cciptypes.NewBigInt(big.NewInt(1e8)), // 10%
+			Heartbeat:                    *commonconfig.MustNewDuration(heartbeat),
+		}
+	}
+
+	cases := []struct {
+		name          string
+		feeInfo       map[cciptypes.ChainSelector]pluginconfig.FeeInfo
+		maxUpdates    int
+		currentFees   map[cciptypes.ChainSelector]ComponentsUSDPrices
+		latestUpdates map[cciptypes.ChainSelector]Update
+		expChains     []cciptypes.ChainSelector
+	}{
+		{
+			name: "per chain heartbeat overrides the batch write frequency",
+			feeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				1: feeInfo(time.Hour),
+				2: feeInfo(10 * time.Second),
+			},
+			currentFees: map[cciptypes.ChainSelector]ComponentsUSDPrices{
+				1: fees(100, 100),
+				2: fees(100, 100),
+			},
+			latestUpdates: map[cciptypes.ChainSelector]Update{
+				1: {Timestamp: ts.Add(-2 * time.Minute), ChainFee: fees(100, 100)},
+				2: {Timestamp: ts.Add(-30 * time.Second), ChainFee: fees(100, 100)},
+			},
+			expChains: []cciptypes.ChainSelector{2},
+		},
+		{
+			name: "largest deviations are kept when capped",
+			feeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				1: feeInfo(0),
+				2: feeInfo(0),
+				3: feeInfo(0),
+			},
+			maxUpdates: 2,
+			currentFees: map[cciptypes.ChainSelector]ComponentsUSDPrices{
+				1: fees(120, 100), // 20%
+				2: fees(100, 300), // 200%
+				3: fees(150, 100), // 50%
+			},
+			latestUpdates: map[cciptypes.ChainSelector]Update{
+				1: {Timestamp: ts, ChainFee: fees(100, 100)},
+				2: {Timestamp: ts, ChainFee: fees(100, 100)},
+				3: {Timestamp: ts, ChainFee: fees(100, 100)},
+			},
+			expChains: []cciptypes.ChainSelector{2, 3},
+		},
+		{
+			name: "overdue chains are ranked by how overdue they are when capped",
+			feeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				1: feeInfo(10 * time.Second),
+				2: feeInfo(10 * time.Second),
+				3: feeInfo(10 * time.Second),
+			},
+			maxUpdates: 2,
+			currentFees: map[cciptypes.ChainSelector]ComponentsUSDPrices{
+				1: fees(105, 100), // 2 heartbeats overdue, half the threshold
+				2: fees(100, 100), // 10 heartbeats overdue
+				3: fees(150, 100), // 5 times the threshold
+			},
+			latestUpdates: map[cciptypes.ChainSelector]Update{
+				1: {Timestamp: ts.Add(-20 * time.Second), ChainFee: fees(100, 100)},
+				2: {Timestamp: ts.Add(-100 * time.Second), ChainFee: fees(100, 100)},
+				3: {Timestamp: ts, ChainFee: fees(100, 100)},
+			},
+			expChains: []cciptypes.ChainSelector{2, 3},
+		},
+		{
+			name: "chains missing from the fee quoter go first when capped",
+			feeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				1: feeInfo(0),
+				2: feeInfo(0),
+			},
+			maxUpdates: 1,
+			currentFees: map[cciptypes.ChainSelector]ComponentsUSDPrices{
+				1: fees(1000, 100),
+				2: fees(100, 100),
+			},
+			latestUpdates: map[cciptypes.ChainSelector]Update{
+				1: {Timestamp: ts, ChainFee: fees(100, 100)},
+			},
+			expChains: []cciptypes.ChainSelector{2},
+		},
+		{
+			name: "no cap when the updates are within the limit",
+			feeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				1: feeInfo(0),
+				2: feeInfo(0),
+			},
+			maxUpdates: 2,
+			currentFees: map[cciptypes.ChainSelector]ComponentsUSDPrices{
+				1: fees(200, 100),
+				2: fees(100, 100),
+			},
+			latestUpdates: map[cciptypes.ChainSelector]Update{
+				1: {Timestamp: ts, ChainFee: fees(100, 100)},
+				2: {Timestamp: ts.Add(-time.Hour), ChainFee: fees(100, 100)},
+			},
+			expChains: []cciptypes.ChainSelector{1, 2},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			homeChain := readermock.NewMockHomeChain(t)
+			homeChain.EXPECT().GetChainConfig(mock.Anything).
+				Return(reader.ChainConfig{}, errors.New("chain config not found")).Maybe()
+			p := &processor{
+				lggr:      logger.Test(t),
+				homeChain: homeChain,
+				cfg: pluginconfig.CommitOffchainConfig{
+					RemoteGasPriceBatchWriteFrequency: chainFeePriceBatchWriteFrequency,
+					FeeInfo:                           tc.feeInfo,
+					MaxGasPriceUpdatesPerReport:       tc.maxUpdates,
+				},
+			}
+
+			gasPrices := p.getGasPricesToUpdate(tc.currentFees, tc.latestUpdates, ts)
+
+			chains := make([]cciptypes.ChainSelector, 0, len(gasPrices))
+			for _, gasPrice := range gasPrices {
+				chains = append(chains, gasPrice.ChainSel)
+			}
+			assert.ElementsMatch(t, tc.expChains, chains)
+		})
+	}
+}
+
+func TestProcessor_getDeviationThresholds(t *testing.T) {
+	ppb := func(v int64) cciptypes.BigInt { return cciptypes.NewBigInt(big.NewInt(v)) }
+
+	cases := []struct {
+		name           string
+		chainConfig    *chainconfig.ChainConfig
+		feeInfo        map[cciptypes.ChainSelector]pluginconfig.FeeInfo
+		expExecPPB     int64
+		expDataAvPPB   int64
+		expErrContains string
+	}{
+		{
+			name:         "thresholds from the chain config",
+			chainConfig:  &chainconfig.ChainConfig{GasPriceDeviationPPB: ppb(1e8), DAGasPriceDeviationPPB: ppb(2e8)},
+			expExecPPB:   1e8,
+			expDataAvPPB: 2e8,
+		},
+		{
+			name:         "data availability threshold defaults to the execution threshold",
+			chainConfig:  &chainconfig.ChainConfig{GasPriceDeviationPPB: ppb(1e8)},
+			expExecPPB:   1e8,
+			expDataAvPPB: 1e8,
+		},
+		{
+			name:        "fee info overrides the chain config",
+			chainConfig: &chainconfig.ChainConfig{GasPriceDeviationPPB: ppb(1e8), DAGasPriceDeviationPPB: ppb(2e8)},
+			feeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				1: {DataAvailabilityDeviationPPB: ppb(3e8)},
+			},
+			expExecPPB:   1e8,
+			expDataAvPPB: 3e8,
+		},
+		{
+			name: "fee info when the chain config is unavailable",
+			feeInfo: map[cciptypes.ChainSelector]pluginconfig.FeeInfo{
+				1: {ExecDeviationPPB: ppb(4e8), DataAvailabilityDeviationPPB: ppb(5e8)},
+			},
+			expExecPPB:   4e8,
+			expDataAvPPB: 5e8,
+		},
+		{
+			name:           "no thresholds",
+			expErrContains: "chain config unavailable",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			homeChain := readermock.NewMockHomeChain(t)
+			if tc.chainConfig != nil {
+				homeChain.EXPECT().GetChainConfig(cciptypes.ChainSelector(1)).
+					Return(reader.ChainConfig{Config: *tc.chainConfig}, nil)
+			} else {
+				homeChain.EXPECT().GetChainConfig(cciptypes.ChainSelector(1)).
+					Return(reader.ChainConfig{}, errors.New("chain config not found"))
+			}
+			p := &processor{
+				lggr:      logger.Test(t),
+				homeChain: homeChain,
+				cfg:       pluginconfig.CommitOffchainConfig{FeeInfo: tc.feeInfo},
+			}
+
+			execPPB, dataAvPPB, err := p.getDeviationThresholds(1)
+			if tc.expErrContains != "" {
+				require.ErrorContains(t, err, tc.expErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expExecPPB, execPPB)
+			assert.Equal(t, tc.expDataAvPPB, dataAvPPB)
 		})
 	}
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/goplugin/plugin-common/pkg/logger"
 
+	"github.com/goplugin/plugin-ccip/commit/metrics"
 	"github.com/goplugin/plugin-ccip/internal/plugincommon"
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 )
@@ -21,6 +22,7 @@ type processor struct {
 	cfg          pluginconfig.CommitOffchainConfig
 	chainSupport plugincommon.ChainSupport
 	fRoleDON     int
+	metrics      metrics.Reporter
 }
 
 func NewProcessor(
@@ -31,6 +33,7 @@ func NewProcessor(
 	offChainConfig pluginconfig.CommitOffchainConfig,
 	chainSupport plugincommon.ChainSupport,
 	fRoleDON int,
+	metricsReporter metrics.Reporter,
 ) plugincommon.PluginProcessor[Query, Observation, Outcome] {
 	return &processor{
 		lggr:         lggr,
@@ -40,6 +43,7 @@ func NewProcessor(
 		fRoleDON:     fRoleDON,
 		chainSupport: chainSupport,
 		cfg:          offChainConfig,
+		metrics:      metricsReporter,
 	}
 }
 
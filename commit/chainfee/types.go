@@ -17,6 +17,9 @@ type Query struct {
 type Outcome struct {
 	// Each Gas Price is the combination of Execution and DataAvailability Fees using bitwise operations
 	GasPrices []cciptypes.GasPriceChain `json:"gasPrices"`
+
+	// SmoothedChainFees are the chain fees smoothed over the previous outcomes, for the chains with fee smoothing.
+	SmoothedChainFees map[cciptypes.ChainSelector]ComponentsUSDPrices `json:"smoothedChainFees,omitempty"`
 }
 
 type Observation struct {
@@ -101,17 +104,10 @@ func ChainFeeUpdateAggregator(updates []Update) Update {
 		dataAvFeeUSDs[i] = updates[i].ChainFee.DataAvFeePriceUSD
 		timestamps[i] = updates[i].Timestamp
 	}
-	medianExecFeeUSD := consensus.Median(execFeeUSDs, func(a, b *big.Int) bool {
-		return a.Cmp(b) == -1
-	})
-	medianDataAvFeeUSD := consensus.Median(dataAvFeeUSDs, func(a, b *big.Int) bool {
-		return a.Cmp(b) == -1
-	})
-
 	return Update{
 		ChainFee: ComponentsUSDPrices{
-			ExecutionFeePriceUSD: medianExecFeeUSD,
-			DataAvFeePriceUSD:    medianDataAvFeeUSD,
+			ExecutionFeePriceUSD: consensus.MedianBig(execFeeUSDs),
+			DataAvFeePriceUSD:    consensus.MedianBig(dataAvFeeUSDs),
 		},
 		Timestamp: consensus.TimestampsMedian(timestamps),
 	}
@@ -5,7 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"time"
+	"sync"
 
 	"google.golang.org/grpc"
 
@@ -18,6 +18,7 @@ import (
 	"github.com/goplugin/plugin-common/pkg/types/core"
 
 	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
+	"github.com/goplugin/plugin-ccip/commit/metrics"
 	"github.com/goplugin/plugin-ccip/internal/plugintypes"
 	"github.com/goplugin/plugin-ccip/internal/reader"
 	"github.com/goplugin/plugin-ccip/pkg/consts"
@@ -67,6 +68,11 @@ type PluginFactory struct {
 	chainWriters      map[cciptypes.ChainSelector]types.ChainWriter
 	rmnPeerClient     rmn.PeerClient
 	rmnCrypto         cciptypes.RMNCrypto
+	metricsReporter   metrics.Reporter
+
+	// mu guards plugin, the most recently created plugin instance whose health is reported by the factory.
+	mu     sync.RWMutex
+	plugin *Plugin
 }
 
 func NewPluginFactory(
@@ -81,6 +87,7 @@ func NewPluginFactory(
 	chainWriters map[cciptypes.ChainSelector]types.ChainWriter,
 	rmnPeerClient rmn.PeerClient,
 	rmnCrypto cciptypes.RMNCrypto,
+	metricsReporter metrics.Reporter,
 ) *PluginFactory {
 	return &PluginFactory{
 		lggr:              lggr,
@@ -94,6 +101,7 @@ func NewPluginFactory(
 		chainWriters:      chainWriters,
 		rmnPeerClient:     rmnPeerClient,
 		rmnCrypto:         rmnCrypto,
+		metricsReporter:   metricsReporter,
 	}
 }
 
@@ -135,7 +143,7 @@ func (p *PluginFactory) NewReportingPlugin(ctx context.Context, config ocr3types
 			rmnCr,
 			rmnHomeBoundContract,
 			p.lggr,
-			5*time.Second,
+			offchainConfig.RMNPollingInterval.Duration(),
 		)
 
 		if err := rmnHomeReader.Start(ctx); err != nil {
@@ -152,24 +160,57 @@ func (p *PluginFactory) NewReportingPlugin(ctx context.Context, config ocr3types
 	ccipReader := readerpkg.NewCCIPChainReader(
 		ctx,
 		p.lggr,
+		p.homeChainReader,
 		readers,
 		p.chainWriters,
 		p.ocrConfig.Config.ChainSelector,
 		p.ocrConfig.Config.OfframpAddress,
 	)
 
-	// The node supports the chain that the token prices are on.
-	_, ok := readers[offchainConfig.PriceFeedChainSelector]
-	if ok {
-		// Bind all token aggregate contracts
-		var bcs []types.BoundContract
-		for _, info := range offchainConfig.TokenInfo {
-			bcs = append(bcs, types.BoundContract{
-				Address: info.AggregatorAddress,
+	var rmnRemoteReader readerpkg.RMNRemote
+	if offchainConfig.RMNEnabled {
+		rmnRemoteReader = readerpkg.NewRMNRemotePoller(
+			ccipReader,
+			p.ocrConfig.Config.ChainSelector,
+			p.lggr,
+			offchainConfig.RMNPollingInterval.Duration(),
+		)
+
+		if err := rmnRemoteReader.Start(ctx); err != nil {
+			return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to start RMNRemote reader: %w", err)
+		}
+	}
+
+	// Bind all token aggregate contracts on the feed chains supported by the node.
+	bcsPerChain := make(map[cciptypes.ChainSelector][]types.BoundContract)
+	addBoundContract := func(chain cciptypes.ChainSelector, bc types.BoundContract) {
+		if _, ok := readers[chain]; ok {
+			bcsPerChain[chain] = append(bcsPerChain[chain], bc)
+		}
+	}
+	for _, info := range offchainConfig.TokenInfo {
+		for _, source := range info.AllPriceSources(offchainConfig.PriceFeedChainSelector) {
+			addBoundContract(source.ChainSelector, types.BoundContract{
+				Address: source.AggregatorAddress,
 				Name:    consts.ContractNamePriceAggregator,
 			})
 		}
-		if err1 := readers[offchainConfig.PriceFeedChainSelector].Bind(ctx, bcs); err1 != nil {
+
+		if info.DerivedPrice != nil {
+			rate := info.DerivedPrice.Rate
+			chain := rate.ChainSelector
+			if chain == 0 {
+				chain = offchainConfig.PriceFeedChainSelector
+			}
+			name := consts.ContractNamePriceAggregator
+			if rate.IsContractRead() {
+				name = rate.ContractName
+			}
+			addBoundContract(chain, types.BoundContract{Address: rate.Address, Name: name})
+		}
+	}
+	for chain, bcs := range bcsPerChain {
+		if err1 := readers[chain].Bind(ctx, bcs); err1 != nil {
 			return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to bind token price contracts: %w", err1)
 		}
 	}
@@ -182,52 +223,72 @@ func (p *PluginFactory) NewReportingPlugin(ctx context.Context, config ocr3types
 		offchainConfig.PriceFeedChainSelector,
 	)
 
-	return NewPlugin(
-			p.donID,
-			config.OracleID,
-			oracleIDToP2PID,
-			offchainConfig,
-			p.ocrConfig.Config.ChainSelector,
-			ccipReader,
-			onChainTokenPricesReader,
-			p.commitCodec,
-			p.msgHasher,
-			p.lggr,
-			p.homeChainReader,
-			rmnHomeReader,
-			p.rmnCrypto,
-			p.rmnPeerClient,
-			config,
-		), ocr3types.ReportingPluginInfo{
-			Name: "CCIPRoleCommit",
-			Limits: ocr3types.ReportingPluginLimits{
-				MaxQueryLength:       maxQueryLength,
-				MaxObservationLength: 20_000, // 20kB
-				MaxOutcomeLength:     10_000, // 10kB
-				MaxReportLength:      10_000, // 10kB
-				MaxReportCount:       10,
-			},
-		}, nil
+	plugin := NewPlugin(
+		p.donID,
+		config.OracleID,
+		oracleIDToP2PID,
+		offchainConfig,
+		p.ocrConfig.Config.ChainSelector,
+		ccipReader,
+		onChainTokenPricesReader,
+		p.commitCodec,
+		p.msgHasher,
+		p.lggr,
+		p.homeChainReader,
+		rmnHomeReader,
+		rmnRemoteReader,
+		p.rmnCrypto,
+		p.rmnPeerClient,
+		config,
+		p.metricsReporter,
+	)
+
+	if err := plugin.Start(ctx); err != nil {
+		return nil, ocr3types.ReportingPluginInfo{}, errors.Join(
+			fmt.Errorf("failed to start plugin: %w", err), plugin.Close())
+	}
+
+	p.mu.Lock()
+	p.plugin = plugin
+	p.mu.Unlock()
+
+	return plugin, ocr3types.ReportingPluginInfo{
+		Name: "CCIPRoleCommit",
+		Limits: ocr3types.ReportingPluginLimits{
+			MaxQueryLength:       maxQueryLength,
+			MaxObservationLength: 20_000, // 20kB
+			MaxOutcomeLength:     10_000, // 10kB
+			MaxReportLength:      10_000, // 10kB
+			MaxReportCount:       10,
+		},
+	}, nil
 }
 
-func (p PluginFactory) Name() string {
+func (p *PluginFactory) Name() string {
 	panic("implement me")
 }
 
-func (p PluginFactory) Start(ctx context.Context) error {
+func (p *PluginFactory) Start(ctx context.Context) error {
 	panic("implement me")
 }
 
-func (p PluginFactory) Close() error {
+func (p *PluginFactory) Close() error {
 	panic("implement me")
 }
 
-func (p PluginFactory) Ready() error {
+func (p *PluginFactory) Ready() error {
 	panic("implement me")
 }
 
-func (p PluginFactory) HealthReport() map[string]error {
-	panic("implement me")
+// HealthReport reports the health of the most recently created plugin instance.
+func (p *PluginFactory) HealthReport() map[string]error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.plugin == nil {
+		return map[string]error{}
+	}
+	return p.plugin.HealthReport()
 }
 
 // Interface compatibility checks.
@@ -4,14 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"sync"
 	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
-	"golang.org/x/sync/errgroup"
 
-	chainsel "github.com/goplugin/chain-selectors"
 	"github.com/goplugin/plugin-libocr/commontypes"
 	"github.com/goplugin/plugin-libocr/offchainreporting2plus/ocr3types"
 	"github.com/goplugin/plugin-libocr/offchainreporting2plus/types"
@@ -23,6 +22,7 @@ import (
 
 	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
 	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+	"github.com/goplugin/plugin-ccip/commit/metrics"
 	"github.com/goplugin/plugin-ccip/internal/plugincommon"
 	"github.com/goplugin/plugin-ccip/internal/plugintypes"
 	"github.com/goplugin/plugin-ccip/internal/reader"
@@ -61,8 +61,11 @@ func (w *Processor) Observation(
 
 // initializeRMNController initializes the RMN controller iff:
 // 1. RMN is enabled.
-// 2. RMN controller is not already initialized with the same cfg digest.
-// 3. RMN remote config is available from previous outcome.
+// 2. RMN controller is not already initialized with the same RMNHome cfg digests.
+//
+// The controller is connected to the RMNHome config used by RMNRemote and to the active and the candidate RMNHome
+// configs, so that an RMNHome config rotation does not require re-initializing the connection when RMNRemote
+// switches to the new config.
 func (w *Processor) initializeRMNController(ctx context.Context, prevOutcome Outcome) error {
 	if !w.offchainCfg.RMNEnabled {
 		return nil
@@ -73,22 +76,36 @@ func (w *Processor) initializeRMNController(ctx context.Context, prevOutcome Out
 		return nil
 	}
 
-	if prevOutcome.RMNRemoteCfg.ConfigDigest == w.rmnControllerCfgDigest {
-		w.lggr.Debugw("RMN controller already initialized with the same config digest",
-			"configDigest", w.rmnControllerCfgDigest)
+	cfgDigests := w.rmnHomeConfigDigests(prevOutcome.RMNRemoteCfg.ConfigDigest)
+	if slices.Equal(cfgDigests, w.rmnControllerCfgDigests) {
+		w.lggr.Debugw("RMN controller already initialized with the same config digests",
+			"configDigests", w.rmnControllerCfgDigests)
 		return nil
 	}
 
-	w.lggr.Infow("Initializing RMN controller", "rmnRemoteCfg", prevOutcome.RMNRemoteCfg)
+	w.lggr.Infow("Initializing RMN controller",
+		"rmnRemoteCfg", prevOutcome.RMNRemoteCfg, "rmnHomeConfigDigests", cfgDigests)
 
-	rmnNodesInfo, err := w.rmnHomeReader.GetRMNNodesInfo(prevOutcome.RMNRemoteCfg.ConfigDigest)
-	if err != nil {
-		return fmt.Errorf("failed to get RMN nodes info: %w", err)
-	}
-
-	peerIDs := make([]string, 0, len(rmnNodesInfo))
-	for _, node := range rmnNodesInfo {
-		peerIDs = append(peerIDs, node.PeerID.String())
+	peerIDs := make([]string, 0)
+	seenPeerIDs := mapset.NewSet[string]()
+	connectedCfgDigests := make([]cciptypes.Bytes32, 0, len(cfgDigests))
+	for i, cfgDigest := range cfgDigests {
+		rmnNodesInfo, err := w.rmnHomeReader.GetRMNNodesInfo(cfgDigest)
+		if err != nil {
+			if i == 0 {
+				return fmt.Errorf("failed to get RMN nodes info of config %s: %w", cfgDigest, err)
+			}
+			// The nodes of the config used by RMNRemote are required, the other configs are only connected to
+			// in advance of a config rotation and are retried in the next rounds.
+			w.lggr.Warnw("failed to get RMN nodes info, skipping config", "configDigest", cfgDigest, "err", err)
+			continue
+		}
+		connectedCfgDigests = append(connectedCfgDigests, cfgDigest)
+		for _, node := range rmnNodesInfo {
+			if seenPeerIDs.Add(node.PeerID.String()) {
+				peerIDs = append(peerIDs, node.PeerID.String())
+			}
+		}
 	}
 	for _, p2pID := range w.oracleIDToP2pID {
 		peerIDs = append(peerIDs, p2pID.String())
@@ -97,17 +114,31 @@ func (w *Processor) initializeRMNController(ctx context.Context, prevOutcome Out
 	if err := w.rmnController.InitConnection(
 		ctx,
 		cciptypes.Bytes32(w.reportingCfg.ConfigDigest),
-		prevOutcome.RMNRemoteCfg.ConfigDigest,
+		connectedCfgDigests,
 		peerIDs,
 	); err != nil {
 		return fmt.Errorf("failed to init connection to RMN: %w", err)
 	}
 
-	w.rmnControllerCfgDigest = prevOutcome.RMNRemoteCfg.ConfigDigest
+	w.rmnControllerCfgDigests = connectedCfgDigests
 
 	return nil
 }
 
+// rmnHomeConfigDigests returns the RMNHome config digest used by RMNRemote followed by the active and the
+// candidate RMNHome config digests, if they are set and different.
+func (w *Processor) rmnHomeConfigDigests(rmnRemoteCfgDigest cciptypes.Bytes32) []cciptypes.Bytes32 {
+	cfgDigests := []cciptypes.Bytes32{rmnRemoteCfgDigest}
+	activeCfgDigest, candidateCfgDigest := w.rmnHomeReader.GetAllConfigDigests()
+	for _, cfgDigest := range []cciptypes.Bytes32{activeCfgDigest, candidateCfgDigest} {
+		if cfgDigest.IsEmpty() || slices.Contains(cfgDigests, cfgDigest) {
+			continue
+		}
+		cfgDigests = append(cfgDigests, cfgDigest)
+	}
+	return cfgDigests
+}
+
 // verifyQuery verifies the query based to the following rules.
 // 1. If RMN is enabled, RMN signatures are required in the BuildingReport state but not expected in other states.
 // 2. If RMN signatures are provided, they are verified against the current RMN node config.
@@ -126,11 +157,6 @@ func (w *Processor) verifyQuery(ctx context.Context, prevOutcome Outcome, q Quer
 		return err
 	}
 
-	ch, exists := chainsel.ChainBySelector(uint64(w.destChain))
-	if !exists {
-		return fmt.Errorf("failed to get chain by selector %d", w.destChain)
-	}
-
 	offRampAddress, err := w.ccipReader.GetContractAddress(consts.ContractNameOffRamp, w.destChain)
 	if err != nil {
 		return fmt.Errorf("failed to get offramp contract address: %w", err)
@@ -156,14 +182,9 @@ func (w *Processor) verifyQuery(ctx context.Context, prevOutcome Outcome, q Quer
 		return fmt.Errorf("failed to convert lane updates from protobuf: %w", err)
 	}
 
-	rmnReport := cciptypes.RMNReport{
-		ReportVersionDigest:         rmnRemoteCfg.RmnReportVersion,
-		DestChainID:                 cciptypes.NewBigIntFromInt64(int64(ch.EvmChainID)),
-		DestChainSelector:           cciptypes.ChainSelector(ch.Selector),
-		RmnRemoteContractAddress:    rmnRemoteCfg.ContractAddress,
-		OfframpAddress:              offRampAddress,
-		RmnHomeContractConfigDigest: rmnRemoteCfg.ConfigDigest,
-		LaneUpdates:                 laneUpdates,
+	rmnReport, err := rmn.NewRMNReport(w.destChain, offRampAddress, rmnRemoteCfg, laneUpdates)
+	if err != nil {
+		return fmt.Errorf("failed to build RMN report: %w", err)
 	}
 
 	if err := w.rmnCrypto.VerifyReportSignatures(ctx, sigs, rmnReport, signerAddresses); err != nil {
@@ -208,7 +229,9 @@ func (w *Processor) getObservation(ctx context.Context, q Query, previousOutcome
 	switch nextState {
 	case SelectingRangesForReport:
 		offRampNextSeqNums := w.observer.ObserveOffRampNextSeqNums(ctx)
-		onRampLatestSeqNums := w.observer.ObserveLatestOnRampSeqNums(ctx, w.destChain)
+		onRampLatestSeqNums, failures := w.observer.ObserveLatestOnRampSeqNums(ctx, w.destChain)
+		w.handleObservationFailures(failures)
+		onRampLatestSeqNums = w.filterPausedLanes(onRampLatestSeqNums)
 		rmnRemoteCfg := w.observer.ObserveRMNRemoteCfg(ctx, w.destChain)
 
 		return Observation{
@@ -223,8 +246,10 @@ func (w *Processor) getObservation(ctx context.Context, q Query, previousOutcome
 			// So there's nothing to observe, i.e. we don't want to build the report yet.
 			return Observation{}, nextState
 		}
+		merkleRoots, failures := w.observer.ObserveMerkleRoots(ctx, previousOutcome.RangesSelectedForReport)
+		w.handleObservationFailures(failures)
 		return Observation{
-			MerkleRoots: w.observer.ObserveMerkleRoots(ctx, previousOutcome.RangesSelectedForReport),
+			MerkleRoots: merkleRoots,
 			FChain:      w.observer.ObserveFChain(),
 		}, nextState
 	case WaitingForReportTransmission:
@@ -238,15 +263,55 @@ func (w *Processor) getObservation(ctx context.Context, q Query, previousOutcome
 	}
 }
 
+// filterPausedLanes drops the source chains for which a reorg of committed messages was detected, so that no new
+// ranges are selected for them.
+func (w *Processor) filterPausedLanes(seqNums []plugintypes.SeqNumChain) []plugintypes.SeqNumChain {
+	if w.reorgVerifier == nil {
+		return seqNums
+	}
+	filtered := make([]plugintypes.SeqNumChain, 0, len(seqNums))
+	for _, seqNum := range seqNums {
+		if w.reorgVerifier.IsLanePaused(seqNum.ChainSel) {
+			w.lggr.Warnw("lane is paused due to a detected source chain reorg, not observing its messages",
+				"sourceChain", seqNum.ChainSel)
+			continue
+		}
+		filtered = append(filtered, seqNum)
+	}
+	return filtered
+}
+
+// handleObservationFailures logs and reports the items which could not be observed. The successfully observed items
+// are still part of the observation.
+func (w *Processor) handleObservationFailures(failures plugincommon.ObservationFailures) {
+	if len(failures) == 0 {
+		return
+	}
+	w.lggr.Warnw("some items could not be observed, continuing with a partial observation",
+		"failures", failures.Err())
+	if w.metrics == nil {
+		return
+	}
+	for _, f := range failures {
+		w.metrics.TrackError(metrics.PhaseObservation, f.Object)
+	}
+}
+
 type Observer interface {
 	// ObserveOffRampNextSeqNums observes the next OffRamp sequence numbers for each source chain
 	ObserveOffRampNextSeqNums(ctx context.Context) []plugintypes.SeqNumChain
 
 	// ObserveLatestOnRampSeqNums observes the latest OnRamp sequence numbers for each configured source chain.
-	ObserveLatestOnRampSeqNums(ctx context.Context, destChain cciptypes.ChainSelector) []plugintypes.SeqNumChain
+	// Source chains which could not be observed are returned as failures.
+	ObserveLatestOnRampSeqNums(
+		ctx context.Context, destChain cciptypes.ChainSelector,
+	) ([]plugintypes.SeqNumChain, plugincommon.ObservationFailures)
 
-	// ObserveMerkleRoots computes the merkle roots for the given sequence number ranges
-	ObserveMerkleRoots(ctx context.Context, ranges []plugintypes.ChainRange) []cciptypes.MerkleRootChain
+	// ObserveMerkleRoots computes the merkle roots for the given sequence number ranges.
+	// Ranges for which no merkle root could be computed are returned as failures.
+	ObserveMerkleRoots(
+		ctx context.Context, ranges []plugintypes.ChainRange,
+	) ([]cciptypes.MerkleRootChain, plugincommon.ObservationFailures)
 
 	// ObserveRMNRemoteCfg observes the RMN remote config for the given destination chain
 	ObserveRMNRemoteCfg(ctx context.Context, dstChain cciptypes.ChainSelector) rmntypes.RemoteConfig
@@ -260,7 +325,10 @@ type ObserverImpl struct {
 	nodeID       commontypes.OracleID
 	chainSupport plugincommon.ChainSupport
 	ccipReader   readerpkg.CCIPReader
-	msgHasher    cciptypes.MessageHasher
+	// rmnRemote caches the RMNRemote config of the destination chain, required if RMN is enabled.
+	rmnRemote readerpkg.RMNRemote
+	msgHasher cciptypes.MessageHasher
+	metrics   metrics.Reporter
 }
 
 // ObserveOffRampNextSeqNums observes the next sequence numbers for each source chain from the OffRamp
@@ -303,68 +371,131 @@ func (o ObserverImpl) ObserveOffRampNextSeqNums(ctx context.Context) []plugintyp
 }
 
 // ObserveLatestOnRampSeqNums observes the latest onRamp sequence numbers for each configured source chain.
+// A failure to read one source chain does not affect the others, it is returned as an observation failure.
 func (o ObserverImpl) ObserveLatestOnRampSeqNums(
-	ctx context.Context, destChain cciptypes.ChainSelector) []plugintypes.SeqNumChain {
+	ctx context.Context, destChain cciptypes.ChainSelector) ([]plugintypes.SeqNumChain, plugincommon.ObservationFailures) {
 
 	allSourceChains, err := o.chainSupport.KnownSourceChainsSlice()
 	if err != nil {
 		o.lggr.Warnw("call to KnownSourceChainsSlice failed", "err", err)
-		return nil
+		return nil, nil
 	}
 
 	supportedChains, err := o.chainSupport.SupportedChains(o.nodeID)
 	if err != nil {
 		o.lggr.Warnw("call to KnownSourceChainsSlice failed", "err", err)
-		return nil
+		return nil, nil
 	}
 
 	sourceChains := mapset.NewSet(allSourceChains...).Intersect(supportedChains).ToSlice()
 	sort.Slice(sourceChains, func(i, j int) bool { return sourceChains[i] < sourceChains[j] })
 
-	latestOnRampSeqNums := make([]plugintypes.SeqNumChain, len(sourceChains))
-	eg := &errgroup.Group{}
+	var failures plugincommon.ObservationFailures
+	sourceChains, err = o.filterCursedSourceChains(ctx, destChain, sourceChains)
+	if err != nil {
+		failures = append(failures, plugincommon.ObservationFailure{
+			Object:   "curseInfo",
+			ChainSel: destChain,
+			Err:      err,
+		})
+	}
+
+	seqNums := make([]*plugintypes.SeqNumChain, len(sourceChains))
+	mu := &sync.Mutex{}
+	wg := sync.WaitGroup{}
 
 	for i, sourceChain := range sourceChains {
 		i, sourceChain := i, sourceChain
-		eg.Go(func() error {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 			nextOnRampSeqNum, err := o.ccipReader.GetExpectedNextSequenceNumber(ctx, sourceChain, destChain)
-			if err != nil {
-				return fmt.Errorf("failed to get expected next sequence number for source chain %d: %w", sourceChain, err)
-			}
-			if nextOnRampSeqNum == 0 {
-				return fmt.Errorf("expected next sequence number for source chain %d is 0", sourceChain)
+			if err == nil && nextOnRampSeqNum == 0 {
+				err = errors.New("expected next sequence number is 0")
 			}
 
-			latestOnRampSeqNums[i] = plugintypes.SeqNumChain{
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, plugincommon.ObservationFailure{
+					Object:   "onRampSeqNum",
+					ChainSel: sourceChain,
+					Err:      err,
+				})
+				return
+			}
+			seqNums[i] = &plugintypes.SeqNumChain{
 				ChainSel: sourceChain,
 				SeqNum:   nextOnRampSeqNum - 1, // Latest is the next one minus one.
 			}
-			return nil
-		})
+		}()
 	}
+	wg.Wait()
 
-	if err := eg.Wait(); err != nil {
-		o.lggr.Warnw("call to GetExpectedNextSequenceNumber failed", "err", err)
-		return nil
+	latestOnRampSeqNums := make([]plugintypes.SeqNumChain, 0, len(sourceChains))
+	for _, seqNum := range seqNums {
+		if seqNum != nil {
+			latestOnRampSeqNums = append(latestOnRampSeqNums, *seqNum)
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].ChainSel < failures[j].ChainSel })
+
+	return latestOnRampSeqNums, failures
+}
+
+// filterCursedSourceChains removes the source chains whose lane to the destination chain is cursed on RMNRemote, so
+// that no ranges are selected for them. If the curse state can not be read the source chains are returned as is along
+// with the error. Nodes which do not support the destination chain can not read the curse state and rely on the
+// observations of the nodes that do.
+func (o ObserverImpl) filterCursedSourceChains(
+	ctx context.Context, destChain cciptypes.ChainSelector, sourceChains []cciptypes.ChainSelector,
+) ([]cciptypes.ChainSelector, error) {
+	curseInfo, err := o.ccipReader.GetRmnCurseInfo(ctx, destChain, sourceChains)
+	if errors.Is(err, readerpkg.ErrContractReaderNotFound) {
+		return sourceChains, nil
+	}
+	if err != nil {
+		o.lggr.Warnw("call to GetRmnCurseInfo failed", "err", err)
+		return sourceChains, fmt.Errorf("get curse info: %w", err)
 	}
 
-	return latestOnRampSeqNums
+	nonCursed := make([]cciptypes.ChainSelector, 0, len(sourceChains))
+	for _, sourceChain := range sourceChains {
+		if curseInfo.IsLaneCursed(sourceChain) {
+			o.lggr.Warnw("lane is cursed, not observing its sequence numbers",
+				"sourceChain", sourceChain, "destChain", destChain,
+				"globalCurse", curseInfo.GlobalCurse, "cursedDestination", curseInfo.CursedDestination)
+			continue
+		}
+		nonCursed = append(nonCursed, sourceChain)
+	}
+	return nonCursed, nil
 }
 
 // ObserveMerkleRoots computes the merkle roots for the given sequence number ranges
 func (o ObserverImpl) ObserveMerkleRoots(
 	ctx context.Context,
 	ranges []plugintypes.ChainRange,
-) []cciptypes.MerkleRootChain {
+) ([]cciptypes.MerkleRootChain, plugincommon.ObservationFailures) {
 
 	supportedChains, err := o.chainSupport.SupportedChains(o.nodeID)
 	if err != nil {
 		o.lggr.Warnw("call to supportedChains failed", "err", err)
-		return nil
+		return nil, nil
 	}
 
 	var roots []cciptypes.MerkleRootChain
+	var failures plugincommon.ObservationFailures
 	rootsMu := &sync.Mutex{}
+	addFailure := func(chainSel cciptypes.ChainSelector, err error) {
+		rootsMu.Lock()
+		defer rootsMu.Unlock()
+		failures = append(failures, plugincommon.ObservationFailure{
+			Object:   "merkleRoot",
+			ChainSel: chainSel,
+			Err:      err,
+		})
+	}
 	wg := sync.WaitGroup{}
 	for _, chainRange := range ranges {
 		if supportedChains.Contains(chainRange.ChainSel) {
@@ -374,12 +505,14 @@ func (o ObserverImpl) ObserveMerkleRoots(
 				msgs, err := o.ccipReader.MsgsBetweenSeqNums(ctx, chainRange.ChainSel, chainRange.SeqNumRange)
 				if err != nil {
 					o.lggr.Warnw("call to MsgsBetweenSeqNums failed", "err", err)
+					addFailure(chainRange.ChainSel, fmt.Errorf("read messages: %w", err))
 					return
 				}
 
 				root, err := o.computeMerkleRoot(ctx, msgs)
 				if err != nil {
 					o.lggr.Warnw("call to computeMerkleRoot failed", "err", err)
+					addFailure(chainRange.ChainSel, fmt.Errorf("compute merkle root: %w", err))
 					return
 				}
 
@@ -390,6 +523,7 @@ func (o ObserverImpl) ObserveMerkleRoots(
 						"err", err,
 						"chainSelector", chainRange.ChainSel,
 					)
+					addFailure(chainRange.ChainSel, fmt.Errorf("get onramp address: %w", err))
 					return
 				}
 
@@ -407,8 +541,9 @@ func (o ObserverImpl) ObserveMerkleRoots(
 		}
 	}
 	wg.Wait()
+	sort.Slice(failures, func(i, j int) bool { return failures[i].ChainSel < failures[j].ChainSel })
 
-	return roots
+	return roots, failures
 }
 
 // computeMerkleRoot computes the merkle root of a list of messages
@@ -450,7 +585,11 @@ func (o ObserverImpl) computeMerkleRoot(ctx context.Context, msgs []cciptypes.Me
 func (o ObserverImpl) ObserveRMNRemoteCfg(
 	ctx context.Context,
 	dstChain cciptypes.ChainSelector) rmntypes.RemoteConfig {
-	rmnRemoteCfg, err := o.ccipReader.GetRMNRemoteConfig(ctx, dstChain)
+	if o.rmnRemote == nil {
+		o.lggr.Errorw("RMNRemote reader not set, cannot observe the RMNRemote config", "destChain", dstChain)
+		return rmntypes.RemoteConfig{}
+	}
+	rmnRemoteCfg, err := o.rmnRemote.GetRemoteConfig()
 	if err != nil {
 		if errors.Is(err, readerpkg.ErrContractReaderNotFound) {
 			// destination chain not supported
@@ -466,7 +605,9 @@ func (o ObserverImpl) ObserveRMNRemoteCfg(
 func (o ObserverImpl) ObserveFChain() map[cciptypes.ChainSelector]int {
 	fChain, err := o.homeChain.GetFChain()
 	if err != nil {
-		// TODO: metrics
+		if o.metrics != nil {
+			o.metrics.TrackError(metrics.PhaseObservation, "fChain")
+		}
 		o.lggr.Warnw("call to GetFChain failed", "err", err)
 		return map[cciptypes.ChainSelector]int{}
 	}
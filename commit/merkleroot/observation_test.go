@@ -30,6 +30,7 @@ import (
 	common_mock "github.com/goplugin/plugin-ccip/mocks/internal_/plugincommon"
 	reader_mock "github.com/goplugin/plugin-ccip/mocks/pkg/reader"
 	readerpkg_mock "github.com/goplugin/plugin-ccip/mocks/pkg/reader"
+	readerpkg "github.com/goplugin/plugin-ccip/pkg/reader"
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 	"github.com/goplugin/plugin-ccip/pluginconfig"
 )
@@ -38,19 +39,22 @@ func TestObservation(t *testing.T) {
 	mockObserver := merkleroot.NewMockObserver(t)
 	mockCCIPReader := readerpkg_mock.NewMockCCIPReader(t)
 	chainSupport := common_mock.NewMockChainSupport(t)
+	rmnHomeReader := readerpkg_mock.NewMockRMNHome(t)
+	rmnHomeReader.EXPECT().GetAllConfigDigests().Return(cciptypes.Bytes32{}, cciptypes.Bytes32{}).Maybe()
 
 	destChain := cciptypes.ChainSelector(909606746561742123)
 
 	offchainAddress := []byte(rand.RandomAddress())
 
 	p := &Processor{
-		lggr:         logger.Test(t),
-		observer:     mockObserver,
-		rmnCrypto:    signatureVerifierAlwaysTrue{},
-		ccipReader:   mockCCIPReader,
-		destChain:    destChain,
-		offchainCfg:  pluginconfig.CommitOffchainConfig{RMNEnabled: true},
-		chainSupport: chainSupport,
+		lggr:          logger.Test(t),
+		observer:      mockObserver,
+		rmnCrypto:     signatureVerifierAlwaysTrue{},
+		ccipReader:    mockCCIPReader,
+		destChain:     destChain,
+		offchainCfg:   pluginconfig.CommitOffchainConfig{RMNEnabled: true},
+		chainSupport:  chainSupport,
+		rmnHomeReader: rmnHomeReader,
 	}
 
 	ctx := context.Background()
@@ -73,7 +77,7 @@ func TestObservation(t *testing.T) {
 				mockObserver.On("ObserveOffRampNextSeqNums", mock.Anything).Return(
 					[]plugintypes.SeqNumChain{{ChainSel: 1, SeqNum: 10}}).Once()
 				mockObserver.On("ObserveLatestOnRampSeqNums", mock.Anything, destChain).Return(
-					[]plugintypes.SeqNumChain{{ChainSel: 1, SeqNum: 15}})
+					[]plugintypes.SeqNumChain{{ChainSel: 1, SeqNum: 15}}, nil)
 				mockObserver.On("ObserveRMNRemoteCfg", mock.Anything, destChain).Return(rmntypes.RemoteConfig{})
 				mockObserver.On("ObserveFChain").Return(map[cciptypes.ChainSelector]int{1: 3})
 			},
@@ -102,7 +106,7 @@ func TestObservation(t *testing.T) {
 						ChainSel:     1,
 						SeqNumsRange: [2]cciptypes.SeqNum{5, 10},
 						MerkleRoot:   [32]byte{1},
-					}})
+					}}, nil)
 				mockObserver.On("ObserveFChain").Return(map[cciptypes.ChainSelector]int{1: 3})
 				mockCCIPReader.On("GetContractAddress", mock.Anything, mock.Anything).Return(offchainAddress, nil)
 			},
@@ -152,7 +156,8 @@ func TestObservation(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMocks()
 
-			p.rmnControllerCfgDigest = tc.prevOutcome.RMNRemoteCfg.ConfigDigest // skip rmn controller setup
+			// skip rmn controller setup
+			p.rmnControllerCfgDigests = []cciptypes.Bytes32{tc.prevOutcome.RMNRemoteCfg.ConfigDigest}
 			obs, err := p.Observation(ctx, tc.prevOutcome, tc.query)
 
 			if tc.expectedErr != "" {
@@ -270,6 +275,7 @@ func Test_ObserveMerkleRoots(t *testing.T) {
 		msgsBetweenSeqNums       map[cciptypes.ChainSelector][]cciptypes.Message
 		msgsBetweenSeqNumsErrors map[cciptypes.ChainSelector]error
 		expMerkleRoots           map[cciptypes.ChainSelector]string
+		expFailedChains          []cciptypes.ChainSelector
 	}{
 		{
 			name: "Success single chain",
@@ -411,7 +417,7 @@ func Test_ObserveMerkleRoots(t *testing.T) {
 					SeqNumRange: cciptypes.SeqNumRange{50, 60},
 				},
 			},
-			supportedChains:      mapset.NewSet[cciptypes.ChainSelector](8),
+			supportedChains:      mapset.NewSet[cciptypes.ChainSelector](8, 12),
 			supportedChainsFails: false,
 			msgsBetweenSeqNums: map[cciptypes.ChainSelector][]cciptypes.Message{
 				8: {{
@@ -431,6 +437,7 @@ func Test_ObserveMerkleRoots(t *testing.T) {
 			expMerkleRoots: map[cciptypes.ChainSelector]string{
 				8: "5b81aaf37240df67f3ab0e845f30e29f35fdf9169e2517c436c1c0c11224c97b",
 			},
+			expFailedChains: []cciptypes.ChainSelector{12},
 		},
 	}
 
@@ -475,14 +482,144 @@ func Test_ObserveMerkleRoots(t *testing.T) {
 				chainSupport: chainSupport,
 			}
 
-			roots := o.ObserveMerkleRoots(ctx, tc.ranges)
+			roots, failures := o.ObserveMerkleRoots(ctx, tc.ranges)
 			if tc.expMerkleRoots == nil {
 				assert.Nil(t, roots)
 			} else {
+				assert.Len(t, roots, len(tc.expMerkleRoots))
 				for _, root := range roots {
 					assert.Equal(t, tc.expMerkleRoots[root.ChainSel], hex.EncodeToString(root.MerkleRoot[:]))
 				}
 			}
+
+			failedChains := make([]cciptypes.ChainSelector, 0, len(failures))
+			for _, f := range failures {
+				failedChains = append(failedChains, f.ChainSel)
+			}
+			assert.ElementsMatch(t, tc.expFailedChains, failedChains)
+		})
+	}
+}
+
+func Test_ObserveLatestOnRampSeqNums(t *testing.T) {
+	const (
+		destChain = cciptypes.ChainSelector(1)
+		nodeID    = commontypes.OracleID(1)
+	)
+	ctx := context.Background()
+
+	reader := reader_mock.NewMockCCIPReader(t)
+	reader.On("GetExpectedNextSequenceNumber", ctx, cciptypes.ChainSelector(2), destChain).
+		Return(cciptypes.SeqNum(10), nil)
+	reader.On("GetExpectedNextSequenceNumber", ctx, cciptypes.ChainSelector(3), destChain).
+		Return(cciptypes.SeqNum(0), fmt.Errorf("rpc unavailable"))
+	reader.On("GetExpectedNextSequenceNumber", ctx, cciptypes.ChainSelector(4), destChain).
+		Return(cciptypes.SeqNum(0), nil)
+	reader.On("GetExpectedNextSequenceNumber", ctx, cciptypes.ChainSelector(5), destChain).
+		Return(cciptypes.SeqNum(20), nil)
+
+	reader.On("GetRmnCurseInfo", ctx, destChain, []cciptypes.ChainSelector{2, 3, 4, 5, 6}).
+		Return(readerpkg.CurseInfo{
+			CursedSourceChains: map[cciptypes.ChainSelector]bool{2: false, 3: false, 4: false, 5: false, 6: true},
+		}, nil)
+
+	chainSupport := common_mock.NewMockChainSupport(t)
+	chainSupport.On("KnownSourceChainsSlice").Return([]cciptypes.ChainSelector{6, 5, 4, 3, 2}, nil)
+	chainSupport.On("SupportedChains", nodeID).Return(mapset.NewSet[cciptypes.ChainSelector](2, 3, 4, 5, 6), nil)
+
+	o := ObserverImpl{
+		nodeID:       nodeID,
+		lggr:         logger.Test(t),
+		ccipReader:   reader,
+		chainSupport: chainSupport,
+	}
+
+	seqNums, failures := o.ObserveLatestOnRampSeqNums(ctx, destChain)
+	assert.Equal(t, []plugintypes.SeqNumChain{
+		{ChainSel: 2, SeqNum: 9},
+		{ChainSel: 5, SeqNum: 19},
+	}, seqNums)
+	require.Len(t, failures, 2)
+	assert.Equal(t, cciptypes.ChainSelector(3), failures[0].ChainSel)
+	assert.Equal(t, "onRampSeqNum", failures[0].Object)
+	assert.Equal(t, cciptypes.ChainSelector(4), failures[1].ChainSel)
+}
+
+func Test_ObserveLatestOnRampSeqNums_CursedDestination(t *testing.T) {
+	const (
+		destChain = cciptypes.ChainSelector(1)
+		nodeID    = commontypes.OracleID(1)
+	)
+	ctx := context.Background()
+
+	reader := reader_mock.NewMockCCIPReader(t)
+	reader.On("GetRmnCurseInfo", ctx, destChain, []cciptypes.ChainSelector{2, 3}).
+		Return(readerpkg.CurseInfo{
+			CursedSourceChains: map[cciptypes.ChainSelector]bool{2: false, 3: false},
+			CursedDestination:  true,
+		}, nil)
+
+	chainSupport := common_mock.NewMockChainSupport(t)
+	chainSupport.On("KnownSourceChainsSlice").Return([]cciptypes.ChainSelector{2, 3}, nil)
+	chainSupport.On("SupportedChains", nodeID).Return(mapset.NewSet[cciptypes.ChainSelector](1, 2, 3), nil)
+
+	o := ObserverImpl{
+		nodeID:       nodeID,
+		lggr:         logger.Test(t),
+		ccipReader:   reader,
+		chainSupport: chainSupport,
+	}
+
+	seqNums, failures := o.ObserveLatestOnRampSeqNums(ctx, destChain)
+	assert.Empty(t, seqNums)
+	assert.Empty(t, failures)
+}
+
+func Test_ObserveRMNRemoteCfg(t *testing.T) {
+	const destChain = cciptypes.ChainSelector(1)
+	ctx := context.Background()
+	cfg := rmntypes.RemoteConfig{ConfigDigest: cciptypes.Bytes32{1}, MinSigners: 2}
+
+	testCases := []struct {
+		name         string
+		pollerCfg    rmntypes.RemoteConfig
+		pollerErr    error
+		usePoller    bool
+		expRemoteCfg rmntypes.RemoteConfig
+	}{
+		{
+			name:         "config read from the poller",
+			usePoller:    true,
+			pollerCfg:    cfg,
+			expRemoteCfg: cfg,
+		},
+		{
+			name:         "stale poller config is not observed",
+			usePoller:    true,
+			pollerErr:    readerpkg.ErrRMNRemoteConfigStale,
+			expRemoteCfg: rmntypes.RemoteConfig{},
+		},
+		{
+			name:         "no config without a poller",
+			expRemoteCfg: rmntypes.RemoteConfig{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ccipReader := reader_mock.NewMockCCIPReader(t)
+			o := ObserverImpl{
+				lggr:       logger.Test(t),
+				ccipReader: ccipReader,
+			}
+			if tc.usePoller {
+				rmnRemote := readerpkg_mock.NewMockRMNRemote(t)
+				rmnRemote.EXPECT().GetRemoteConfig().Return(tc.pollerCfg, tc.pollerErr)
+				o.rmnRemote = rmnRemote
+			}
+
+			assert.Equal(t, tc.expRemoteCfg, o.ObserveRMNRemoteCfg(ctx, destChain))
 		})
 	}
 }
@@ -605,11 +742,7 @@ func Test_Processor_initializeRMNController(t *testing.T) {
 	assert.NoError(t, err, "rmn is not enabled")
 
 	p.offchainCfg.RMNEnabled = true
-	p.rmnControllerCfgDigest = cciptypes.Bytes32{1}
-	err = p.initializeRMNController(ctx, Outcome{})
-	assert.NoError(t, err, "rmn enabled but controller already initialized")
-
-	p.rmnControllerCfgDigest = cciptypes.Bytes32{1}
+	p.rmnControllerCfgDigests = []cciptypes.Bytes32{{1}}
 	err = p.initializeRMNController(ctx, Outcome{})
 	assert.NoError(t, err, "previous outcome does not contain remote config digest")
 
@@ -617,24 +750,79 @@ func Test_Processor_initializeRMNController(t *testing.T) {
 	rmnController := rmn_mock.NewMockController(t)
 	p.rmnHomeReader = rmnHomeReader
 	p.rmnController = rmnController
+	p.rmnControllerCfgDigests = nil
 
 	cfg := testhelpers.CreateRMNRemoteCfg()
+	candidateCfgDigest := cciptypes.Bytes32{0xca}
 	rmnNodes := []rmntypes.HomeNodeInfo{
 		{ID: 1, PeerID: types.PeerID{1, 2, 3}},
 		{ID: 10, PeerID: types.PeerID{1, 2, 31}},
 	}
-	rmnHomeReader.EXPECT().GetRMNNodesInfo(cfg.ConfigDigest).Return(rmnNodes, nil)
+	candidateRMNNodes := []rmntypes.HomeNodeInfo{
+		{ID: 1, PeerID: types.PeerID{1, 2, 3}},
+		{ID: 20, PeerID: types.PeerID{1, 2, 32}},
+	}
+	rmnHomeReader.EXPECT().GetAllConfigDigests().Return(cfg.ConfigDigest, candidateCfgDigest).Times(2)
+	rmnHomeReader.EXPECT().GetRMNNodesInfo(cfg.ConfigDigest).Return(rmnNodes, nil).Once()
+	rmnHomeReader.EXPECT().GetRMNNodesInfo(candidateCfgDigest).Return(candidateRMNNodes, nil).Once()
+
+	rmnController.EXPECT().InitConnection(
+		ctx,
+		cciptypes.Bytes32(p.reportingCfg.ConfigDigest),
+		[]cciptypes.Bytes32{cfg.ConfigDigest, candidateCfgDigest},
+		[]string{rmnNodes[0].PeerID.String(), rmnNodes[1].PeerID.String(), candidateRMNNodes[1].PeerID.String()},
+	).Return(nil).Once()
+
+	err = p.initializeRMNController(ctx, Outcome{RMNRemoteCfg: cfg})
+	assert.NoError(t, err, "rmn controller initialized with the active and the candidate configs")
+	assert.Equal(t, []cciptypes.Bytes32{cfg.ConfigDigest, candidateCfgDigest}, p.rmnControllerCfgDigests)
+
+	err = p.initializeRMNController(ctx, Outcome{RMNRemoteCfg: cfg})
+	assert.NoError(t, err, "rmn controller already initialized with the same configs")
 
+	// The candidate is promoted, RMNRemote still uses the previous config.
+	rmnHomeReader.EXPECT().GetAllConfigDigests().Return(candidateCfgDigest, cciptypes.Bytes32{}).Once()
+	err = p.initializeRMNController(ctx, Outcome{RMNRemoteCfg: cfg})
+	assert.NoError(t, err, "connections of the previous active config are kept until RMNRemote switches")
+	assert.Equal(t, []cciptypes.Bytes32{cfg.ConfigDigest, candidateCfgDigest}, p.rmnControllerCfgDigests)
+
+	// RMNRemote switches to the promoted config.
+	newCfg := cfg
+	newCfg.ConfigDigest = candidateCfgDigest
+	rmnHomeReader.EXPECT().GetAllConfigDigests().Return(candidateCfgDigest, cciptypes.Bytes32{}).Once()
+	rmnHomeReader.EXPECT().GetRMNNodesInfo(candidateCfgDigest).Return(candidateRMNNodes, nil).Once()
 	rmnController.EXPECT().InitConnection(
 		ctx,
 		cciptypes.Bytes32(p.reportingCfg.ConfigDigest),
-		cfg.ConfigDigest,
-		[]string{rmnNodes[0].PeerID.String(), rmnNodes[1].PeerID.String()},
-	).Return(nil)
+		[]cciptypes.Bytes32{candidateCfgDigest},
+		[]string{candidateRMNNodes[0].PeerID.String(), candidateRMNNodes[1].PeerID.String()},
+	).Return(nil).Once()
+
+	err = p.initializeRMNController(ctx, Outcome{RMNRemoteCfg: newCfg})
+	assert.NoError(t, err, "rmn controller re-initialized with the new config")
+	assert.Equal(t, []cciptypes.Bytes32{candidateCfgDigest}, p.rmnControllerCfgDigests)
+
+	// The nodes of a new candidate config cannot be read, the candidate is skipped.
+	newCandidateCfgDigest := cciptypes.Bytes32{0xcb}
+	rmnHomeReader.EXPECT().GetAllConfigDigests().Return(candidateCfgDigest, newCandidateCfgDigest).Once()
+	rmnHomeReader.EXPECT().GetRMNNodesInfo(candidateCfgDigest).Return(candidateRMNNodes, nil).Once()
+	rmnHomeReader.EXPECT().GetRMNNodesInfo(newCandidateCfgDigest).Return(nil, fmt.Errorf("some error")).Once()
+	rmnController.EXPECT().InitConnection(
+		ctx,
+		cciptypes.Bytes32(p.reportingCfg.ConfigDigest),
+		[]cciptypes.Bytes32{candidateCfgDigest},
+		[]string{candidateRMNNodes[0].PeerID.String(), candidateRMNNodes[1].PeerID.String()},
+	).Return(nil).Once()
+
+	err = p.initializeRMNController(ctx, Outcome{RMNRemoteCfg: newCfg})
+	assert.NoError(t, err, "candidate config with unreadable nodes is skipped")
+	assert.Equal(t, []cciptypes.Bytes32{candidateCfgDigest}, p.rmnControllerCfgDigests)
 
+	// The nodes of the config used by RMNRemote cannot be read.
+	rmnHomeReader.EXPECT().GetAllConfigDigests().Return(candidateCfgDigest, cciptypes.Bytes32{}).Once()
+	rmnHomeReader.EXPECT().GetRMNNodesInfo(cfg.ConfigDigest).Return(nil, fmt.Errorf("some error")).Once()
 	err = p.initializeRMNController(ctx, Outcome{RMNRemoteCfg: cfg})
-	assert.NoError(t, err, "rmn controller initialized")
-	assert.Equal(t, cfg.ConfigDigest, p.rmnControllerCfgDigest)
+	assert.Error(t, err, "nodes of the RMNRemote config are required")
 }
 
 func mustNewMessageID(msgIDHex string) cciptypes.Bytes32 {
@@ -32,6 +32,9 @@ func (w *Processor) Outcome(
 ) (Outcome, error) {
 	tStart := time.Now()
 	outcome, nextState := w.getOutcome(prevOutcome, query, aos)
+	if w.metrics != nil {
+		w.metrics.TrackMerkleRootStateTransition(nextState.String(), outcome.NextState().String())
+	}
 	w.lggr.Infow("Sending Outcome",
 		"outcome", outcome, "nextState", nextState, "outcomeDuration", time.Since(tStart))
 	return outcome, nil
@@ -44,7 +47,7 @@ func (w *Processor) getOutcome(
 ) (Outcome, State) {
 	nextState := previousOutcome.NextState()
 
-	consensusObservation, err := getConsensusObservation(w.lggr, w.reportingCfg.F, w.destChain, aos)
+	consensusObservation, err := getConsensusObservation(w.lggr, w.reportingCfg.F, w.destChain, aos, w.metrics)
 	if err != nil {
 		w.lggr.Warnw("Get consensus observation failed, empty outcome", "err", err)
 		return Outcome{}, nextState
@@ -263,13 +266,14 @@ func getConsensusObservation(
 	fRoleDON int,
 	destChain cciptypes.ChainSelector,
 	aos []plugincommon.AttributedObservation[Observation],
+	tracker consensus.FailureTracker,
 ) (ConsensusObservation, error) {
 	aggObs := aggregateObservations(aos)
 
 	// consensus on the fChain map uses the role DON F value
 	// because all nodes can observe the home chain.
 	donThresh := consensus.MakeConstantThreshold[cciptypes.ChainSelector](consensus.TwoFPlus1(fRoleDON))
-	fChains := consensus.GetConsensusMap(lggr, "fChain", aggObs.FChain, donThresh)
+	fChains := consensus.GetConsensusMapMode(lggr, "fChain", aggObs.FChain, donThresh, tracker)
 
 	_, exists := fChains[destChain]
 	if !exists {
@@ -285,14 +289,20 @@ func getConsensusObservation(
 	// Get consensus using strict 2fChain+1 threshold.
 	twoFChainPlus1 := consensus.MakeMultiThreshold(fChains, consensus.TwoFPlus1)
 	consensusObs := ConsensusObservation{
-		MerkleRoots:      consensus.GetConsensusMap(lggr, "Merkle Root", aggObs.MerkleRoots, twoFChainPlus1),
-		OnRampMaxSeqNums: consensus.GetConsensusMap(lggr, "OnRamp Max Seq Nums", aggObs.OnRampMaxSeqNums, twoFChainPlus1),
-		OffRampNextSeqNums: consensus.GetConsensusMap(
+		MerkleRoots: consensus.GetConsensusMap(lggr, "Merkle Root", aggObs.MerkleRoots, twoFChainPlus1, tracker),
+		OnRampMaxSeqNums: consensus.GetConsensusMapMode(
+			lggr,
+			"OnRamp Max Seq Nums",
+			aggObs.OnRampMaxSeqNums,
+			twoFChainPlus1,
+			tracker),
+		OffRampNextSeqNums: consensus.GetConsensusMapMode(
 			lggr,
 			"OffRamp Next Seq Nums",
 			aggObs.OffRampNextSeqNums,
-			twoFChainPlus1),
-		RMNRemoteConfig: consensus.GetConsensusMap(lggr, "RMNRemote cfg", rmnRemoteConfigs, twoFChainPlus1),
+			twoFChainPlus1,
+			tracker),
+		RMNRemoteConfig: consensus.GetConsensusMap(lggr, "RMNRemote cfg", rmnRemoteConfigs, twoFChainPlus1, tracker),
 		FChain:          fChains,
 	}
 
@@ -1,6 +1,7 @@
 package merkleroot
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -9,8 +10,10 @@ import (
 	libocrtypes "github.com/goplugin/plugin-libocr/ragep2p/types"
 
 	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/services"
 
 	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
+	"github.com/goplugin/plugin-ccip/commit/metrics"
 	"github.com/goplugin/plugin-ccip/internal/plugincommon"
 	"github.com/goplugin/plugin-ccip/internal/reader"
 	readerpkg "github.com/goplugin/plugin-ccip/pkg/reader"
@@ -23,19 +26,23 @@ import (
 // It's setup to use RMN to query which messages to include in the merkle root and ensures
 // the newly built merkle roots are the same as RMN roots.
 type Processor struct {
-	oracleID               commontypes.OracleID
-	oracleIDToP2pID        map[commontypes.OracleID]libocrtypes.PeerID
-	offchainCfg            pluginconfig.CommitOffchainConfig
-	destChain              cciptypes.ChainSelector
-	lggr                   logger.Logger
-	observer               Observer
-	ccipReader             readerpkg.CCIPReader
-	reportingCfg           ocr3types.ReportingPluginConfig
-	chainSupport           plugincommon.ChainSupport
-	rmnController          rmn.Controller
-	rmnControllerCfgDigest cciptypes.Bytes32
-	rmnCrypto              cciptypes.RMNCrypto
-	rmnHomeReader          readerpkg.RMNHome
+	oracleID                commontypes.OracleID
+	oracleIDToP2pID         map[commontypes.OracleID]libocrtypes.PeerID
+	offchainCfg             pluginconfig.CommitOffchainConfig
+	destChain               cciptypes.ChainSelector
+	lggr                    logger.Logger
+	observer                Observer
+	ccipReader              readerpkg.CCIPReader
+	reportingCfg            ocr3types.ReportingPluginConfig
+	chainSupport            plugincommon.ChainSupport
+	rmnController           rmn.Controller
+	rmnControllerCfgDigests []cciptypes.Bytes32
+	rmnCrypto               cciptypes.RMNCrypto
+	rmnHomeReader           readerpkg.RMNHome
+	rmnRemoteReader         readerpkg.RMNRemote
+	metrics                 metrics.Reporter
+	// reorgVerifier verifies committed roots against the source chains, nil if disabled.
+	reorgVerifier *reorgVerifier
 }
 
 // NewProcessor creates a new Processor
@@ -53,15 +60,33 @@ func NewProcessor(
 	rmnController rmn.Controller,
 	rmnCrypto cciptypes.RMNCrypto,
 	rmnHomeReader readerpkg.RMNHome,
+	rmnRemoteReader readerpkg.RMNRemote,
+	metricsReporter metrics.Reporter,
 ) *Processor {
 	observer := ObserverImpl{
-		lggr,
-		homeChain,
-		oracleID,
-		chainSupport,
-		ccipReader,
-		msgHasher,
+		lggr:         lggr,
+		homeChain:    homeChain,
+		nodeID:       oracleID,
+		chainSupport: chainSupport,
+		ccipReader:   ccipReader,
+		rmnRemote:    rmnRemoteReader,
+		msgHasher:    msgHasher,
+		metrics:      metricsReporter,
 	}
+	var verifier *reorgVerifier
+	if offchainCfg.ReorgCheckInterval.Duration() > 0 {
+		verifier = newReorgVerifier(
+			lggr,
+			destChain,
+			ccipReader,
+			observer,
+			metricsReporter,
+			offchainCfg.ReorgCheckInterval.Duration(),
+			offchainCfg.ReorgCheckLookback.Duration(),
+			offchainCfg.PauseLaneOnReorg,
+		)
+	}
+
 	return &Processor{
 		oracleID:        oracleID,
 		oracleIDToP2pID: oracleIDToP2pID,
@@ -75,18 +100,54 @@ func NewProcessor(
 		rmnController:   rmnController,
 		rmnCrypto:       rmnCrypto,
 		rmnHomeReader:   rmnHomeReader,
+		rmnRemoteReader: rmnRemoteReader,
+		metrics:         metricsReporter,
+		reorgVerifier:   verifier,
 	}
 }
 
 var _ plugincommon.PluginProcessor[Query, Observation, Outcome] = &Processor{}
 
-func (p *Processor) Close() error {
-	if !p.offchainCfg.RMNEnabled {
+// Start starts the background services of the processor, i.e. the reorg verifier if enabled. They are stopped by
+// Close.
+func (p *Processor) Start(ctx context.Context) error {
+	if p.reorgVerifier == nil {
 		return nil
 	}
+	if err := p.reorgVerifier.Start(ctx); err != nil {
+		return fmt.Errorf("start reorg verifier: %w", err)
+	}
+	return nil
+}
+
+// HealthReport reports the source chains on which the reorg verifier detected a reorg and the RMN nodes which keep
+// failing.
+func (p *Processor) HealthReport() map[string]error {
+	report := make(map[string]error)
+	if p.reorgVerifier != nil {
+		services.CopyHealth(report, p.reorgVerifier.HealthReport())
+	}
+	if p.offchainCfg.RMNEnabled && p.rmnController != nil {
+		services.CopyHealth(report, p.rmnController.HealthReport())
+	}
+	return report
+}
 
+func (p *Processor) Close() error {
 	errs := make([]error, 0)
 
+	// close reorg verifier
+	if p.reorgVerifier != nil {
+		if err := p.reorgVerifier.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close reorg verifier: %w", err))
+			p.lggr.Errorw("Failed to close reorg verifier", "err", err)
+		}
+	}
+
+	if !p.offchainCfg.RMNEnabled {
+		return errors.Join(errs...)
+	}
+
 	// close rmn controller
 	if p.rmnController != nil {
 		if err := p.rmnController.Close(); err != nil {
@@ -103,5 +164,13 @@ func (p *Processor) Close() error {
 		}
 	}
 
+	// close rmn remote reader
+	if p.rmnRemoteReader != nil {
+		if err := p.rmnRemoteReader.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close RMNRemote reader: %w", err))
+			p.lggr.Errorw("Failed to close RMNRemote reader", "err", err)
+		}
+	}
+
 	return errors.Join(errs...)
 }
@@ -0,0 +1,27 @@
+package merkleroot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	rmnmocks "github.com/goplugin/plugin-ccip/mocks/commit/merkleroot/rmn"
+	"github.com/goplugin/plugin-ccip/pluginconfig"
+)
+
+func TestProcessor_HealthReport(t *testing.T) {
+	rmnErr := errors.New("rmn node 1 failed 5 consecutive requests")
+
+	rmnController := rmnmocks.NewMockController(t)
+	rmnController.EXPECT().HealthReport().Return(map[string]error{"RMNController": rmnErr})
+	p := &Processor{
+		offchainCfg:   pluginconfig.CommitOffchainConfig{RMNEnabled: true},
+		rmnController: rmnController,
+	}
+	require.Equal(t, map[string]error{"RMNController": rmnErr}, p.HealthReport())
+
+	// The controller is not consulted if RMN is disabled.
+	p = &Processor{rmnController: rmnmocks.NewMockController(t)}
+	require.Empty(t, p.HealthReport())
+}
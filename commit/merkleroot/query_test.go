@@ -235,15 +235,20 @@ func TestProcessor_Query(t *testing.T) {
 				}
 			}
 
+			rmnHomeReader := reader.NewMockRMNHome(t)
+			rmnHomeReader.EXPECT().GetAllConfigDigests().Return(ccipocr3.Bytes32{}, ccipocr3.Bytes32{}).Maybe()
+
 			w := Processor{
 				offchainCfg:   tc.cfg,
 				destChain:     tc.destChain,
 				ccipReader:    ccipReader,
 				rmnController: tc.rmnClient(t),
+				rmnHomeReader: rmnHomeReader,
 				lggr:          logger.Test(t),
 			}
 
-			w.rmnControllerCfgDigest = tc.prevOutcome.RMNRemoteCfg.ConfigDigest // skip rmn controller init
+			// skip rmn controller init
+			w.rmnControllerCfgDigests = []ccipocr3.Bytes32{tc.prevOutcome.RMNRemoteCfg.ConfigDigest}
 
 			q, err := w.Query(ctx, tc.prevOutcome)
 			if tc.expErr {
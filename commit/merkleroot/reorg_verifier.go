@@ -0,0 +1,285 @@
+package merkleroot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/services"
+
+	"github.com/goplugin/plugin-ccip/commit/metrics"
+	readerpkg "github.com/goplugin/plugin-ccip/pkg/reader"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// reorgCheckMaxReports is the maximum number of committed reports verified in a single check.
+const reorgCheckMaxReports = 1000
+
+// reorgMismatch describes a committed merkle root which no longer matches the messages on its source chain.
+type reorgMismatch struct {
+	root         cciptypes.MerkleRootChain
+	computedRoot cciptypes.Bytes32
+	detectedAt   time.Time
+}
+
+func (m reorgMismatch) Error() string {
+	return fmt.Sprintf("committed merkle root %s of range %s does not match source chain root %s",
+		m.root.MerkleRoot, m.root.SeqNumsRange, m.computedRoot)
+}
+
+// reorgVerifier periodically recomputes the merkle roots of recently committed ranges from the source chain
+// messages and compares them with the roots committed on the destination chain. A mismatch means the source chain
+// reorged after the root was committed. Detected mismatches are reported by HealthReport and, if configured, pause
+// the lane. They are verified again on every check, even once the root is outside of the lookback window, and are
+// cleared when the source chain messages match the committed root again, e.g. after the reorg was reverted.
+type reorgVerifier struct {
+	wg     sync.WaitGroup
+	stopCh services.StopChan
+	sync   services.StateMachine
+
+	lggr       logger.Logger
+	destChain  cciptypes.ChainSelector
+	ccipReader readerpkg.CCIPReader
+	// observer is used to compute the merkle roots and to determine the supported chains.
+	observer    ObserverImpl
+	metrics     metrics.Reporter
+	interval    time.Duration
+	lookback    time.Duration
+	pauseOnLane bool
+
+	mu sync.RWMutex
+	// mismatches holds the detected mismatches of each source chain, keyed by committed merkle root.
+	mismatches map[cciptypes.ChainSelector]map[cciptypes.Bytes32]reorgMismatch
+}
+
+func newReorgVerifier(
+	lggr logger.Logger,
+	destChain cciptypes.ChainSelector,
+	ccipReader readerpkg.CCIPReader,
+	observer ObserverImpl,
+	metricsReporter metrics.Reporter,
+	interval time.Duration,
+	lookback time.Duration,
+	pauseOnLane bool,
+) *reorgVerifier {
+	return &reorgVerifier{
+		stopCh:      make(chan struct{}),
+		lggr:        logger.Named(lggr, "ReorgVerifier"),
+		destChain:   destChain,
+		ccipReader:  ccipReader,
+		observer:    observer,
+		metrics:     metricsReporter,
+		interval:    interval,
+		lookback:    lookback,
+		pauseOnLane: pauseOnLane,
+		mismatches:  make(map[cciptypes.ChainSelector]map[cciptypes.Bytes32]reorgMismatch),
+	}
+}
+
+func (v *reorgVerifier) Start(_ context.Context) error {
+	return v.sync.StartOnce(v.Name(), func() error {
+		v.wg.Add(1)
+		go v.run()
+		return nil
+	})
+}
+
+func (v *reorgVerifier) run() {
+	defer v.wg.Done()
+	ctx, cancel := v.stopCh.NewCtx()
+	defer cancel()
+
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			v.verify(ctx)
+		}
+	}
+}
+
+// verify checks the previously detected mismatches and the roots committed within the lookback window against the
+// source chain messages.
+func (v *reorgVerifier) verify(ctx context.Context) {
+	// Verify the known mismatches first, so that they are re-verified even outside of the lookback window.
+	verified := make(map[cciptypes.Bytes32]struct{})
+	for _, mismatch := range v.knownMismatches() {
+		verified[mismatch.root.MerkleRoot] = struct{}{}
+		if err := v.verifyRoot(ctx, mismatch.root); err != nil {
+			v.lggr.Warnw("failed to verify mismatching merkle root",
+				"sourceChain", mismatch.root.ChainSel, "seqNumRange", mismatch.root.SeqNumsRange, "err", err)
+		}
+	}
+
+	reports, err := v.ccipReader.CommitReportsGTETimestamp(
+		ctx, v.destChain, time.Now().Add(-v.lookback), reorgCheckMaxReports)
+	if err != nil {
+		v.lggr.Warnw("failed to read committed reports", "err", err)
+		return
+	}
+
+	supportedChains, err := v.observer.chainSupport.SupportedChains(v.observer.nodeID)
+	if err != nil {
+		v.lggr.Warnw("failed to get supported chains", "err", err)
+		return
+	}
+
+	for _, report := range reports {
+		for _, root := range report.Report.MerkleRoots {
+			if !supportedChains.Contains(root.ChainSel) {
+				continue
+			}
+			if _, ok := verified[root.MerkleRoot]; ok {
+				continue
+			}
+			if err := v.verifyRoot(ctx, root); err != nil {
+				v.lggr.Warnw("failed to verify committed merkle root",
+					"sourceChain", root.ChainSel, "seqNumRange", root.SeqNumsRange, "err", err)
+			}
+		}
+	}
+}
+
+// knownMismatches returns the currently detected mismatches of all source chains.
+func (v *reorgVerifier) knownMismatches() []reorgMismatch {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	var mismatches []reorgMismatch
+	for _, chainMismatches := range v.mismatches {
+		for _, mismatch := range chainMismatches {
+			mismatches = append(mismatches, mismatch)
+		}
+	}
+	return mismatches
+}
+
+func (v *reorgVerifier) verifyRoot(ctx context.Context, root cciptypes.MerkleRootChain) error {
+	msgs, err := v.ccipReader.MsgsBetweenSeqNums(ctx, root.ChainSel, root.SeqNumsRange)
+	if err != nil {
+		return fmt.Errorf("read messages: %w", err)
+	}
+
+	// Messages of the range may not be readable yet, e.g. if the root was committed optimistically and the messages
+	// are read at a higher confidence level. The range is verified again in the next check.
+	if len(msgs) != int(root.SeqNumsRange.End()-root.SeqNumsRange.Start())+1 {
+		v.lggr.Debugw("not all messages of the committed range are available yet, skipping",
+			"sourceChain", root.ChainSel, "seqNumRange", root.SeqNumsRange, "numMsgs", len(msgs))
+		return nil
+	}
+
+	computedRoot, err := v.observer.computeMerkleRoot(ctx, msgs)
+	if err != nil {
+		return fmt.Errorf("compute merkle root: %w", err)
+	}
+	if computedRoot == root.MerkleRoot {
+		v.clearMismatch(root)
+		return nil
+	}
+
+	logger.Sugared(v.lggr).Criticalw("source chain reorg detected, committed merkle root does not match",
+		"sourceChain", root.ChainSel,
+		"seqNumRange", root.SeqNumsRange,
+		"committedRoot", root.MerkleRoot,
+		"computedRoot", computedRoot,
+		"pauseLane", v.pauseOnLane,
+	)
+
+	v.mu.Lock()
+	chainMismatches, ok := v.mismatches[root.ChainSel]
+	if !ok {
+		chainMismatches = make(map[cciptypes.Bytes32]reorgMismatch)
+		v.mismatches[root.ChainSel] = chainMismatches
+	}
+	mismatch, known := chainMismatches[root.MerkleRoot]
+	if !known {
+		mismatch.detectedAt = time.Now()
+	}
+	mismatch.root = root
+	mismatch.computedRoot = computedRoot
+	chainMismatches[root.MerkleRoot] = mismatch
+	v.mu.Unlock()
+
+	if !known && v.metrics != nil {
+		v.metrics.TrackReorgDetected(root.ChainSel)
+	}
+	return nil
+}
+
+// clearMismatch forgets a previously detected mismatch of a root which matches the source chain again.
+func (v *reorgVerifier) clearMismatch(root cciptypes.MerkleRootChain) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	mismatch, ok := v.mismatches[root.ChainSel][root.MerkleRoot]
+	if !ok {
+		return
+	}
+	v.lggr.Infow("committed merkle root matches the source chain again, clearing the detected reorg",
+		"sourceChain", root.ChainSel,
+		"seqNumRange", root.SeqNumsRange,
+		"committedRoot", root.MerkleRoot,
+		"detectedAt", mismatch.detectedAt,
+	)
+	delete(v.mismatches[root.ChainSel], root.MerkleRoot)
+	if len(v.mismatches[root.ChainSel]) == 0 {
+		delete(v.mismatches, root.ChainSel)
+	}
+}
+
+// IsLanePaused returns true if committing messages of the source chain should be paused because a reorg was detected.
+func (v *reorgVerifier) IsLanePaused(sourceChain cciptypes.ChainSelector) bool {
+	if !v.pauseOnLane {
+		return false
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return len(v.mismatches[sourceChain]) > 0
+}
+
+func (v *reorgVerifier) Close() error {
+	return v.sync.StopOnce(v.Name(), func() error {
+		defer v.wg.Wait()
+		close(v.stopCh)
+		return nil
+	})
+}
+
+func (v *reorgVerifier) Ready() error {
+	return v.sync.Ready()
+}
+
+func (v *reorgVerifier) HealthReport() map[string]error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	chains := make([]cciptypes.ChainSelector, 0, len(v.mismatches))
+	for chain := range v.mismatches {
+		chains = append(chains, chain)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+
+	errs := make([]error, 0, len(chains))
+	for _, chain := range chains {
+		mismatches := make([]reorgMismatch, 0, len(v.mismatches[chain]))
+		for _, mismatch := range v.mismatches[chain] {
+			mismatches = append(mismatches, mismatch)
+		}
+		sort.Slice(mismatches, func(i, j int) bool {
+			return mismatches[i].root.SeqNumsRange.Start() < mismatches[j].root.SeqNumsRange.Start()
+		})
+		for _, mismatch := range mismatches {
+			errs = append(errs, fmt.Errorf("source chain %d: %w", chain, mismatch))
+		}
+	}
+	return map[string]error{v.Name(): errors.Join(errs...)}
+}
+
+func (v *reorgVerifier) Name() string {
+	return "reorgVerifier"
+}
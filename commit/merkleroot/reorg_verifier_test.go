@@ -0,0 +1,194 @@
+package merkleroot
+
+import (
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/utils/tests"
+
+	"github.com/goplugin/plugin-ccip/commit/metrics"
+	"github.com/goplugin/plugin-ccip/internal/mocks"
+	common_mock "github.com/goplugin/plugin-ccip/mocks/internal_/plugincommon"
+	reader_mock "github.com/goplugin/plugin-ccip/mocks/pkg/reader"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+	"github.com/goplugin/plugin-ccip/plugintypes"
+)
+
+func Test_reorgVerifier_verify(t *testing.T) {
+	const (
+		destChain   = cciptypes.ChainSelector(1)
+		sourceChain = cciptypes.ChainSelector(2)
+		otherChain  = cciptypes.ChainSelector(3)
+	)
+	seqNumRange := cciptypes.NewSeqNumRange(112, 114)
+	msgs := []cciptypes.Message{
+		{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x1a"), SequenceNumber: 112}},
+		{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x23"), SequenceNumber: 113}},
+		{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x87"), SequenceNumber: 114}},
+	}
+	expRoot, err := cciptypes.NewBytes32FromString(
+		"0x94c7e711e6f2acf41dca598ced55b6925e55aaed83520dc5ea6cbc054344564b")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		committedRoot cciptypes.Bytes32
+		sourceMsgs    []cciptypes.Message
+		pauseOnLane   bool
+		expMismatch   bool
+		expPaused     bool
+	}{
+		{
+			name:          "committed root matches source chain",
+			committedRoot: expRoot,
+			sourceMsgs:    msgs,
+			pauseOnLane:   true,
+		},
+		{
+			name:          "committed root does not match source chain",
+			committedRoot: cciptypes.Bytes32{0x1},
+			sourceMsgs:    msgs,
+			expMismatch:   true,
+		},
+		{
+			name:          "mismatch pauses the lane when configured",
+			committedRoot: cciptypes.Bytes32{0x1},
+			sourceMsgs:    msgs,
+			pauseOnLane:   true,
+			expMismatch:   true,
+			expPaused:     true,
+		},
+		{
+			name:          "range is skipped while messages are not available",
+			committedRoot: cciptypes.Bytes32{0x1},
+			sourceMsgs:    msgs[:2],
+			pauseOnLane:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ccipReader := reader_mock.NewMockCCIPReader(t)
+			chainSupport := common_mock.NewMockChainSupport(t)
+
+			ccipReader.EXPECT().CommitReportsGTETimestamp(mock.Anything, destChain, mock.Anything, reorgCheckMaxReports).
+				Return([]plugintypes.CommitPluginReportWithMeta{{
+					Report: cciptypes.CommitPluginReport{MerkleRoots: []cciptypes.MerkleRootChain{
+						{ChainSel: sourceChain, SeqNumsRange: seqNumRange, MerkleRoot: tc.committedRoot},
+						{ChainSel: otherChain, SeqNumsRange: seqNumRange, MerkleRoot: tc.committedRoot},
+					}},
+				}}, nil)
+			chainSupport.EXPECT().SupportedChains(mock.Anything).
+				Return(mapset.NewSet(destChain, sourceChain), nil)
+			ccipReader.EXPECT().MsgsBetweenSeqNums(mock.Anything, sourceChain, seqNumRange).
+				Return(tc.sourceMsgs, nil)
+
+			lggr := logger.Test(t)
+			v := newReorgVerifier(
+				lggr,
+				destChain,
+				ccipReader,
+				ObserverImpl{lggr: lggr, msgHasher: mocks.NewMessageHasher(), chainSupport: chainSupport},
+				metrics.Noop{},
+				time.Minute,
+				time.Hour,
+				tc.pauseOnLane,
+			)
+
+			v.verify(tests.Context(t))
+
+			healthErr := v.HealthReport()[v.Name()]
+			assert.Equal(t, healthErr, (&Processor{reorgVerifier: v}).HealthReport()[v.Name()])
+			if tc.expMismatch {
+				assert.ErrorContains(t, healthErr, "does not match source chain root")
+			} else {
+				assert.NoError(t, healthErr)
+			}
+			assert.Equal(t, tc.expPaused, v.IsLanePaused(sourceChain))
+			assert.False(t, v.IsLanePaused(otherChain))
+		})
+	}
+}
+
+func Test_reorgVerifier_reverifiesMismatches(t *testing.T) {
+	const (
+		destChain   = cciptypes.ChainSelector(1)
+		sourceChain = cciptypes.ChainSelector(2)
+	)
+	seqNumRange := cciptypes.NewSeqNumRange(112, 114)
+	msgs := []cciptypes.Message{
+		{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x1a"), SequenceNumber: 112}},
+		{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x23"), SequenceNumber: 113}},
+		{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x87"), SequenceNumber: 114}},
+	}
+	reorgedMsgs := []cciptypes.Message{
+		msgs[0],
+		msgs[1],
+		{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x99"), SequenceNumber: 114}},
+	}
+	committedRoot, err := cciptypes.NewBytes32FromString(
+		"0x94c7e711e6f2acf41dca598ced55b6925e55aaed83520dc5ea6cbc054344564b")
+	require.NoError(t, err)
+	root := cciptypes.MerkleRootChain{ChainSel: sourceChain, SeqNumsRange: seqNumRange, MerkleRoot: committedRoot}
+
+	ccipReader := reader_mock.NewMockCCIPReader(t)
+	chainSupport := common_mock.NewMockChainSupport(t)
+	chainSupport.EXPECT().SupportedChains(mock.Anything).Return(mapset.NewSet(destChain, sourceChain), nil)
+
+	lggr := logger.Test(t)
+	v := newReorgVerifier(
+		lggr,
+		destChain,
+		ccipReader,
+		ObserverImpl{lggr: lggr, msgHasher: mocks.NewMessageHasher(), chainSupport: chainSupport},
+		metrics.Noop{},
+		time.Minute,
+		time.Hour,
+		true,
+	)
+
+	// The source chain reorged after the root was committed.
+	ccipReader.EXPECT().CommitReportsGTETimestamp(mock.Anything, destChain, mock.Anything, reorgCheckMaxReports).
+		Return([]plugintypes.CommitPluginReportWithMeta{{
+			Report: cciptypes.CommitPluginReport{MerkleRoots: []cciptypes.MerkleRootChain{root}},
+		}}, nil).Once()
+	ccipReader.EXPECT().MsgsBetweenSeqNums(mock.Anything, sourceChain, seqNumRange).Return(reorgedMsgs, nil).Once()
+	v.verify(tests.Context(t))
+	require.True(t, v.IsLanePaused(sourceChain))
+
+	// The root left the lookback window, the mismatch is still verified and kept.
+	ccipReader.EXPECT().CommitReportsGTETimestamp(mock.Anything, destChain, mock.Anything, reorgCheckMaxReports).
+		Return(nil, nil)
+	ccipReader.EXPECT().MsgsBetweenSeqNums(mock.Anything, sourceChain, seqNumRange).Return(reorgedMsgs, nil).Once()
+	v.verify(tests.Context(t))
+	require.True(t, v.IsLanePaused(sourceChain))
+	assert.ErrorContains(t, v.HealthReport()[v.Name()], "does not match source chain root")
+
+	// The reorg was reverted, the mismatch is cleared and the lane resumes.
+	ccipReader.EXPECT().MsgsBetweenSeqNums(mock.Anything, sourceChain, seqNumRange).Return(msgs, nil).Once()
+	v.verify(tests.Context(t))
+	assert.False(t, v.IsLanePaused(sourceChain))
+	assert.NoError(t, v.HealthReport()[v.Name()])
+}
+
+func TestProcessor_StartsReorgVerifier(t *testing.T) {
+	lggr := logger.Test(t)
+	v := newReorgVerifier(lggr, 1, reader_mock.NewMockCCIPReader(t), ObserverImpl{lggr: lggr},
+		metrics.Noop{}, time.Hour, time.Hour, false)
+	p := &Processor{lggr: lggr, reorgVerifier: v}
+
+	require.Error(t, v.Ready())
+	require.NoError(t, p.Start(tests.Context(t)))
+	require.NoError(t, v.Ready())
+	require.Error(t, p.Start(tests.Context(t)), "the reorg verifier is started once")
+	require.NoError(t, p.Close())
+	require.Error(t, v.Ready())
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"sync"
 	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
@@ -26,6 +27,7 @@ import (
 	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
 	readerpkg "github.com/goplugin/plugin-ccip/pkg/reader"
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+	"github.com/goplugin/plugin-ccip/pluginconfig"
 )
 
 var (
@@ -49,18 +51,24 @@ var (
 
 // Controller contains the high-level functionality required by the plugin to interact with the RMN nodes.
 type Controller interface {
-	// InitConnection initializes the connection to the generic peer group endpoint and must be called before
-	// further Controller interaction. If called twice it overwrites the previous connection.
+	// InitConnection initializes the connections to the generic peer group endpoints of the provided RMNHome config
+	// digests, e.g. of the active and the candidate config during an RMNHome config rotation, and must be called
+	// before further Controller interaction. Existing connections of the provided digests are kept, connections of
+	// digests that are not provided anymore are closed.
 	InitConnection(
 		ctx context.Context,
 		commitConfigDigest cciptypes.Bytes32,
-		rmnHomeConfigDigest cciptypes.Bytes32,
-		peerIDs []string, // union of oraclePeerIDs and rmnNodePeerIDs (oracles required for peer discovery)
+		rmnHomeConfigDigests []cciptypes.Bytes32,
+		// union of oraclePeerIDs and rmnNodePeerIDs of all the configs (oracles required for peer discovery)
+		peerIDs []string,
 	) error
 
-	// Close closes the connection to the generic peer group endpoint and all the underlying streams.
+	// Close closes the connections to the generic peer group endpoints and all the underlying streams.
 	Close() error
 
+	// HealthReport reports the RMN nodes that keep failing to respond with valid observations or signatures.
+	HealthReport() map[string]error
+
 	// ComputeReportSignatures computes and returns the signatures for the provided lane updates.
 	// The returned ReportSignatures might contain a subset of the requested lane updates if some of them were not
 	// able to get signed by the RMN nodes.
@@ -109,6 +117,20 @@ type controller struct {
 	// reportsInitialRequestTimerDuration is the duration of the initial report signature request timer.
 	// After this timer expires we send additional report signature requests to the rest of the RMN nodes.
 	reportsInitialRequestTimerDuration time.Duration
+
+	// responseTimes is used to derive the initial request timers from the RMN node response times.
+	// If nil, the static timer durations above are used.
+	responseTimes *responseTimeTracker
+
+	// nodeHealth keeps per RMN node stats, the initial requests are sent to the healthiest nodes.
+	nodeHealth *nodeHealthTracker
+
+	// sigCache keeps the collected report signatures to reuse them when the same lane updates are requested again.
+	sigCache *reportSignaturesCache
+
+	// connections are the commit config digests of the open connections per RMNHome config digest.
+	connections   map[cciptypes.Bytes32]cciptypes.Bytes32
+	connectionsMu sync.Mutex
 }
 
 // NewController creates a new RMN Controller instance.
@@ -120,7 +142,13 @@ func NewController(
 	rmnHomeReader readerpkg.RMNHome,
 	observationsInitialRequestTimerDuration time.Duration,
 	reportsInitialRequestTimerDuration time.Duration,
+	adaptiveTimers *pluginconfig.RMNAdaptiveTimersConfig,
 ) Controller {
+	var responseTimes *responseTimeTracker
+	if adaptiveTimers != nil {
+		responseTimes = newResponseTimeTracker(*adaptiveTimers)
+	}
+
 	return &controller{
 		lggr:                                    lggr,
 		rmnCrypto:                               rmnCrypto,
@@ -130,6 +158,10 @@ func NewController(
 		ed25519Verifier:                         NewED25519Verifier(),
 		observationsInitialRequestTimerDuration: observationsInitialRequestTimerDuration,
 		reportsInitialRequestTimerDuration:      reportsInitialRequestTimerDuration,
+		responseTimes:                           responseTimes,
+		nodeHealth:                              newNodeHealthTracker(lggr),
+		sigCache:                                newReportSignaturesCache(),
+		connections:                             make(map[cciptypes.Bytes32]cciptypes.Bytes32),
 	}
 }
 
@@ -212,12 +244,29 @@ func (c *controller) ComputeReportSignatures(
 		"duration", time.Since(tStart),
 	)
 
+	fixedDestLaneUpdates, err := selectLaneUpdates(rmnSignedObservations, updatesPerChain, minObserversMap)
+	if err != nil {
+		return nil, fmt.Errorf("select lane updates: %w", err)
+	}
+
+	// Signatures are only reused for the same lane updates, including the merkle roots observed by the RMN nodes in
+	// this round, so that a blessing is never reused for roots that changed, e.g. after a source chain reorg.
+	// Signatures for a subset of the requested lane updates are not cached.
+	cacheable := len(fixedDestLaneUpdates) == len(updateRequests)
+	cacheKey := newReportSignaturesCacheKey(destChain, fixedDestLaneUpdates, rmnRemoteCfg.ConfigDigest)
+	if cacheable {
+		if sigs, ok := c.sigCache.get(cacheKey, rmnRemoteCfg, time.Now()); ok {
+			c.lggr.Infow("reusing cached RMN report signatures", "laneUpdates", fixedDestLaneUpdates)
+			return sigs, nil
+		}
+	}
+
 	tStart = time.Now()
 	rmnReportSignatures, err := c.getRmnReportSignatures(
 		ctx,
 		destChain,
 		rmnSignedObservations,
-		updatesPerChain,
+		fixedDestLaneUpdates,
 		rmnRemoteCfg,
 		rmnNodeInfo)
 	if err != nil {
@@ -229,22 +278,57 @@ func (c *controller) ComputeReportSignatures(
 		"duration", time.Since(tStart),
 	)
 
+	if cacheable {
+		c.sigCache.add(cacheKey, rmnRemoteCfg, rmnReportSignatures, time.Now())
+	}
 	return rmnReportSignatures, nil
 }
 
 func (c *controller) InitConnection(
 	ctx context.Context,
 	commitConfigDigest cciptypes.Bytes32,
-	rmnHomeConfigDigest cciptypes.Bytes32,
+	rmnHomeConfigDigests []cciptypes.Bytes32,
 	peerIDs []string,
 ) error {
-	return c.peerClient.InitConnection(ctx, commitConfigDigest, rmnHomeConfigDigest, peerIDs)
+	c.connectionsMu.Lock()
+	defer c.connectionsMu.Unlock()
+
+	cfgDigests := mapset.NewSet(rmnHomeConfigDigests...)
+	for rmnHomeConfigDigest := range c.connections {
+		if cfgDigests.Contains(rmnHomeConfigDigest) {
+			continue
+		}
+		c.lggr.Infow("closing RMN connection", "rmnHomeConfigDigest", rmnHomeConfigDigest)
+		if err := c.peerClient.CloseConnection(rmnHomeConfigDigest); err != nil {
+			return fmt.Errorf("close connection of rmn home config %s: %w", rmnHomeConfigDigest, err)
+		}
+		delete(c.connections, rmnHomeConfigDigest)
+	}
+
+	for _, rmnHomeConfigDigest := range rmnHomeConfigDigests {
+		if connCommitCfgDigest, ok := c.connections[rmnHomeConfigDigest]; ok && connCommitCfgDigest == commitConfigDigest {
+			continue // keep the existing connection, requests in progress are not interrupted
+		}
+		c.lggr.Infow("opening RMN connection", "rmnHomeConfigDigest", rmnHomeConfigDigest)
+		if err := c.peerClient.InitConnection(ctx, commitConfigDigest, rmnHomeConfigDigest, peerIDs); err != nil {
+			return fmt.Errorf("init connection of rmn home config %s: %w", rmnHomeConfigDigest, err)
+		}
+		c.connections[rmnHomeConfigDigest] = commitConfigDigest
+	}
+	return nil
 }
 
 func (c *controller) Close() error {
+	c.connectionsMu.Lock()
+	defer c.connectionsMu.Unlock()
+	c.connections = make(map[cciptypes.Bytes32]cciptypes.Bytes32)
 	return c.peerClient.Close()
 }
 
+func (c *controller) HealthReport() map[string]error {
+	return map[string]error{"RMNController": c.nodeHealth.HealthReport()}
+}
+
 // getRmnSignedObservations guarantees to return at least #minObservers signed observations for each source chain.
 func (c *controller) getRmnSignedObservations(
 	ctx context.Context,
@@ -266,7 +350,9 @@ func (c *controller) getRmnSignedObservations(
 			return nil, fmt.Errorf("no min observers for chain %d", sourceChain)
 		}
 
-		for nodeID := range updateRequest.RmnNodes.Iter() {
+		rankedNodes := rankByHealth(c.nodeHealth, updateRequest.RmnNodes.ToSlice(),
+			func(nodeID rmntypes.NodeID) rmntypes.NodeID { return nodeID })
+		for _, nodeID := range rankedNodes {
 			if requestedNodes[sourceChain].Cardinality() >= minObservers {
 				break // We have enough initial observers for this source chain.
 			}
@@ -279,7 +365,7 @@ func (c *controller) getRmnSignedObservations(
 		}
 	}
 
-	requestIDs := c.sendObservationRequests(destChain, requestsPerNode, rmnNodeInfo)
+	requestIDs := c.sendObservationRequests(destChain, requestsPerNode, configDigest, rmnNodeInfo)
 
 	signedObservations, err := c.listenForRmnObservationResponses(
 		ctx, destChain, requestIDs, updateRequestsPerChain, requestedNodes, configDigest, minObserversMap, rmnNodeInfo)
@@ -301,6 +387,7 @@ func (c *controller) getRmnSignedObservations(
 func (c *controller) sendObservationRequests(
 	destChain *rmnpb.LaneDest,
 	requestsPerNode map[rmntypes.NodeID][]*rmnpb.FixedDestLaneUpdateRequest,
+	rmnHomeConfigDigest cciptypes.Bytes32,
 	rmnNodeInfo map[rmntypes.NodeID]rmntypes.HomeNodeInfo,
 ) (requestIDs mapset.Set[uint64]) {
 	requestIDs = mapset.NewSet[uint64]()
@@ -328,7 +415,7 @@ func (c *controller) sendObservationRequests(
 
 		lggr := logger.With(c.lggr, "node", nodeID, "requestID", req.RequestId)
 		lggr.Infow("sending observation request", "laneUpdateRequests", requests)
-		if err := c.marshalAndSend(req, rmnNode); err != nil {
+		if err := c.marshalAndSend(rmnHomeConfigDigest, req, rmnNode); err != nil {
 			lggr.Errorw("failed to send observation request", "err", err)
 			continue
 		}
@@ -359,10 +446,15 @@ func (c *controller) listenForRmnObservationResponses(
 	finishedRequestIDs := mapset.NewSet[uint64]()
 	rmnObservationResponses := make([]rmnSignedObservationWithMeta, 0)
 
-	initialObservationRequestTimer := time.NewTimer(c.observationsInitialRequestTimerDuration)
+	initialTimerDuration := c.initialRequestTimer(observationRequest, c.observationsInitialRequestTimerDuration)
+	initialObservationRequestTimer := time.NewTimer(initialTimerDuration)
 	timerExpired := false
 
 	defer initialObservationRequestTimer.Stop()
+	defer func() {
+		// Requests pending for longer than the initial timer are accounted as timeouts.
+		c.nodeHealth.requestsFinished(requestIDs, time.Now().Add(-initialTimerDuration))
+	}()
 	for {
 		select {
 		case resp := <-c.peerClient.Recv():
@@ -381,6 +473,7 @@ func (c *controller) listenForRmnObservationResponses(
 				destChain,
 				configDigest,
 			)
+			c.trackResponse(parsedResp.RequestId, err)
 			if err != nil {
 				c.lggr.Warnw("skipping an invalid RMN observation response", "err", err)
 				initialObservationRequestTimer.Reset(0) // immediately schedule the additional requests
@@ -423,7 +516,7 @@ func (c *controller) listenForRmnObservationResponses(
 					requestsPerNode[nodeID] = append(requestsPerNode[nodeID], updateReq.Data)
 				}
 			}
-			newRequestIDs := c.sendObservationRequests(destChain, requestsPerNode, rmnNodeInfo)
+			newRequestIDs := c.sendObservationRequests(destChain, requestsPerNode, configDigest, rmnNodeInfo)
 			requestIDs = requestIDs.Union(newRequestIDs)
 		case <-ctx.Done():
 			return nil, ErrTimeout
@@ -497,8 +590,8 @@ func (c *controller) validateSignedObservationResponse(
 	}
 
 	if !bytes.Equal(signedObs.Observation.RmnHomeContractConfigDigest, rmnHomeConfigDigest[:]) {
-		return fmt.Errorf("unexpected rmn home contract config digest %x",
-			signedObs.Observation.RmnHomeContractConfigDigest)
+		return fmt.Errorf("unexpected rmn home contract config digest %x: %w",
+			signedObs.Observation.RmnHomeContractConfigDigest, errStaleObservation)
 	}
 
 	for _, signedObsLu := range signedObs.Observation.FixedDestLaneUpdates {
@@ -532,19 +625,18 @@ func (c *controller) validateSignedObservationResponse(
 	}
 
 	if err := verifyObservationSignature(rmnNode, c.signObservationPrefix, signedObs, c.ed25519Verifier); err != nil {
-		return fmt.Errorf("failed to verify observation signature: %w", err)
+		return fmt.Errorf("failed to verify observation signature: %w: %w", errInvalidSignature, err)
 	}
 	return nil
 }
 
-func (c *controller) getRmnReportSignatures(
-	ctx context.Context,
-	destChain *rmnpb.LaneDest,
+// selectLaneUpdates selects the merkle root of each requested lane update from the signed observations and returns
+// the lane updates sorted by source chain.
+func selectLaneUpdates(
 	rmnSignedObservations []rmnSignedObservationWithMeta,
 	updatesPerChain map[uint64]updateRequestWithMeta,
-	rmnRemoteCfg rmntypes.RemoteConfig,
-	rmnNodeInfo map[rmntypes.NodeID]rmntypes.HomeNodeInfo,
-) (*ReportSignatures, error) {
+	minObservers map[cciptypes.ChainSelector]int,
+) ([]*rmnpb.FixedDestLaneUpdate, error) {
 	// At this point we might have multiple signedObservations for different nodes but never for the same source chain
 	// from the same node.
 	//
@@ -560,11 +652,6 @@ func (c *controller) getRmnReportSignatures(
 	// At this point it is also possible that the signed observations contain
 	// different roots for the same source chain and interval.
 
-	minObservers, err := c.rmnHomeReader.GetMinObservers(rmnRemoteCfg.ConfigDigest)
-	if err != nil {
-		return nil, fmt.Errorf("get min observers: %w", err)
-	}
-
 	rootsPerChain, err := selectRoots(rmnSignedObservations, minObservers)
 	if err != nil {
 		return nil, fmt.Errorf("get most voted roots from observations: %w", err)
@@ -585,25 +672,26 @@ func (c *controller) getRmnReportSignatures(
 	sort.Slice(fixedDestLaneUpdates, func(i, j int) bool {
 		return fixedDestLaneUpdates[i].LaneSource.SourceChainSelector < fixedDestLaneUpdates[j].LaneSource.SourceChainSelector
 	})
+	return fixedDestLaneUpdates, nil
+}
 
-	destChainInfo, exists := chainsel.ChainBySelector(destChain.DestChainSelector)
-	if !exists {
-		return nil, fmt.Errorf("unknown dest chain selector %d", destChain.DestChainSelector)
-	}
-
+func (c *controller) getRmnReportSignatures(
+	ctx context.Context,
+	destChain *rmnpb.LaneDest,
+	rmnSignedObservations []rmnSignedObservationWithMeta,
+	fixedDestLaneUpdates []*rmnpb.FixedDestLaneUpdate,
+	rmnRemoteCfg rmntypes.RemoteConfig,
+	rmnNodeInfo map[rmntypes.NodeID]rmntypes.HomeNodeInfo,
+) (*ReportSignatures, error) {
 	laneUpdates, err := NewLaneUpdatesFromPB(fixedDestLaneUpdates)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert lane updates from protobuf: %w", err)
 	}
 
-	rmnReport := cciptypes.RMNReport{
-		ReportVersionDigest:         rmnRemoteCfg.RmnReportVersion,
-		DestChainID:                 cciptypes.NewBigIntFromInt64(int64(destChainInfo.EvmChainID)),
-		DestChainSelector:           cciptypes.ChainSelector(destChain.DestChainSelector),
-		RmnRemoteContractAddress:    rmnRemoteCfg.ContractAddress,
-		OfframpAddress:              destChain.OfframpAddress,
-		RmnHomeContractConfigDigest: rmnRemoteCfg.ConfigDigest,
-		LaneUpdates:                 laneUpdates,
+	rmnReport, err := NewRMNReport(
+		cciptypes.ChainSelector(destChain.DestChainSelector), destChain.OfframpAddress, rmnRemoteCfg, laneUpdates)
+	if err != nil {
+		return nil, fmt.Errorf("build RMN report: %w", err)
 	}
 
 	chainInfo, exists := chainsel.ChainBySelector(destChain.DestChainSelector)
@@ -623,6 +711,7 @@ func (c *controller) getRmnReportSignatures(
 	minSigners := int(rmnRemoteCfg.MinSigners)
 	signers := rmnRemoteCfg.Signers
 	requestIDs, signersRequested, err := c.sendReportSignatureRequest(
+		rmnRemoteCfg.ConfigDigest,
 		reportSigReq,
 		signers,
 		minSigners,
@@ -727,9 +816,10 @@ func selectRoots(
 	return selectedRoots, nil
 }
 
-// sendReportSignatureRequest sends the report signature request to #minSigners random RMN nodes.
+// sendReportSignatureRequest sends the report signature request to the #minSigners healthiest RMN nodes.
 // If not enough requests were sent, it returns an error.
 func (c *controller) sendReportSignatureRequest(
+	rmnHomeConfigDigest cciptypes.Bytes32,
 	reportSigReq *rmnpb.ReportSignatureRequest,
 	remoteSigners []rmntypes.RemoteSignerInfo,
 	minSigners int,
@@ -739,8 +829,10 @@ func (c *controller) sendReportSignatureRequest(
 	requestIDs = mapset.NewSet[uint64]()
 	signersRequested = mapset.NewSet[rmntypes.NodeID]()
 
-	// Send the report signature request to at least minSigners
-	for _, node := range randomShuffle(remoteSigners) {
+	// Send the report signature request to at least minSigners, starting with the healthiest nodes.
+	rankedSigners := rankByHealth(c.nodeHealth, remoteSigners,
+		func(signer rmntypes.RemoteSignerInfo) rmntypes.NodeID { return rmntypes.NodeID(signer.NodeIndex) })
+	for _, node := range rankedSigners {
 		if requestIDs.Cardinality() >= minSigners {
 			break
 		}
@@ -760,7 +852,7 @@ func (c *controller) sendReportSignatureRequest(
 			continue
 		}
 
-		err := c.marshalAndSend(req, rmnNode)
+		err := c.marshalAndSend(rmnHomeConfigDigest, req, rmnNode)
 		if err != nil {
 			c.lggr.Warnw("failed to send report signature request", "node", node.NodeIndex, "err", err)
 			continue
@@ -794,13 +886,18 @@ func (c *controller) listenForRmnReportSignatures(
 	minSigners int,
 	rmnNodeInfo map[rmntypes.NodeID]rmntypes.HomeNodeInfo,
 ) ([]*rmnpb.EcdsaSignature, error) {
-	tReportsInitialRequest := time.NewTimer(c.reportsInitialRequestTimerDuration)
+	initialTimerDuration := c.initialRequestTimer(reportSignatureRequest, c.reportsInitialRequestTimerDuration)
+	tReportsInitialRequest := time.NewTimer(initialTimerDuration)
 	timerExpired := false
 
 	reportSigs := make([]reportSigWithSignerAddress, 0)
 	finishedRequests := mapset.NewSet[uint64]()
 	requestIDs = requestIDs.Clone()
 	c.lggr.Infof("waiting for report signatures, requestIDs: %s", requestIDs.String())
+	defer func() {
+		// Requests pending for longer than the initial timer are accounted as timeouts.
+		c.nodeHealth.requestsFinished(requestIDs, time.Now().Add(-initialTimerDuration))
+	}()
 
 	for {
 		select {
@@ -813,6 +910,7 @@ func (c *controller) listenForRmnReportSignatures(
 			finishedRequests.Add(responseTyp.RequestId)
 
 			reportSig, err := c.validateReportSigResponse(ctx, responseTyp, resp.RMNNodeID, signers, rmnReport)
+			c.trackResponse(responseTyp.RequestId, err)
 			if err != nil {
 				c.lggr.Warnw("skipping an invalid RMN report signature response", "err", err)
 				tReportsInitialRequest.Reset(0) // schedule additional requests if any
@@ -857,7 +955,7 @@ func (c *controller) listenForRmnReportSignatures(
 				}
 
 				c.lggr.Infow("sending report signature request", "node", nodeIndex, "requestID", req.RequestId)
-				if err := c.marshalAndSend(req, rmnNode); err != nil {
+				if err := c.marshalAndSend(rmnReport.RmnHomeContractConfigDigest, req, rmnNode); err != nil {
 					c.lggr.Errorw("failed to send report signature request", "node", nodeIndex, "err", err)
 					continue
 				}
@@ -914,7 +1012,7 @@ func (c *controller) validateReportSigResponse(
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify report signature: %w", err)
+		return nil, fmt.Errorf("failed to verify report signature: %w: %w", errInvalidSignature, err)
 	}
 
 	return &reportSigWithSignerAddress{
@@ -965,19 +1063,51 @@ type rmnSignedObservationWithMeta struct {
 	RMNNodeID         rmntypes.NodeID
 }
 
-func (c *controller) marshalAndSend(req *rmnpb.Request, rmnNode rmntypes.HomeNodeInfo) error {
+// marshalAndSend sends the request to the RMN node using the connection of the provided RMNHome config digest.
+func (c *controller) marshalAndSend(
+	rmnHomeConfigDigest cciptypes.Bytes32,
+	req *rmnpb.Request,
+	rmnNode rmntypes.HomeNodeInfo,
+) error {
 	reqBytes, err := proto.Marshal(req)
 	if err != nil {
 		return fmt.Errorf("proto marshal RMN request: %w", err)
 	}
 
-	if err := c.peerClient.Send(rmnNode, reqBytes); err != nil {
+	if err := c.peerClient.Send(rmnHomeConfigDigest, rmnNode, reqBytes); err != nil {
 		return fmt.Errorf("send rmn request: %w", err)
 	}
 
+	now := time.Now()
+	c.nodeHealth.requestSent(req.RequestId, rmnNode.ID, now)
+	if c.responseTimes != nil {
+		c.responseTimes.requestSent(req, now)
+	}
+
 	return nil
 }
 
+// trackResponse records the outcome of an RMN response in the node stats, validationErr is the error of the
+// response validation if any. Response times of valid responses are recorded if adaptive timers are enabled.
+func (c *controller) trackResponse(requestID uint64, validationErr error) {
+	now := time.Now()
+	c.nodeHealth.responseReceived(requestID, validationErr, now)
+	if c.responseTimes != nil && validationErr == nil {
+		c.responseTimes.responseReceived(requestID, now)
+	}
+}
+
+// initialRequestTimer returns the initial request timer duration for the provided request kind.
+// If adaptive timers are enabled, it is derived from the recent RMN node response times.
+func (c *controller) initialRequestTimer(kind requestKind, staticTimer time.Duration) time.Duration {
+	if c.responseTimes == nil {
+		return staticTimer
+	}
+	d := c.responseTimes.timer(kind, staticTimer)
+	c.lggr.Debugw("using adaptive RMN initial request timer", "kind", kind, "duration", d)
+	return d
+}
+
 // parseResponse parses the response from the RMN and returns the response.
 // Validates that the response is expected and not a duplicate.
 func (c *controller) parseResponse(
@@ -262,6 +262,8 @@ func TestClient_ComputeReportSignatures(t *testing.T) {
 			reportsInitialRequestTimerDuration:      time.Minute,
 			ed25519Verifier:                         signatureVerifierAlwaysTrue{},
 			rmnCrypto:                               signatureVerifierAlwaysTrue{},
+			nodeHealth:                              newNodeHealthTracker(lggr),
+			sigCache:                                newReportSignaturesCache(),
 		}
 
 		updateRequests := []*rmnpb.FixedDestLaneUpdateRequest{
@@ -374,6 +376,86 @@ func TestClient_ComputeReportSignatures(t *testing.T) {
 		}
 	})
 
+	t.Run("report signatures are reused for identical lane updates", func(t *testing.T) {
+		ts := newTestSetup(t)
+
+		ts.rmnHomeMock.On("GetRMNNodesInfo", cciptypes.Bytes32{0x1, 0x2, 0x3}).Return(ts.rmnNodes, nil)
+		ts.rmnHomeMock.On("GetMinObservers", cciptypes.Bytes32{0x1, 0x2, 0x3}).Return(
+			map[cciptypes.ChainSelector]int{chainS1: 2, chainS2: 2, chainD1: 2}, nil)
+		go func() {
+			requestIDs, requestedChains := ts.waitForObservationRequestsToBeSent(
+				ts.peerClient, ts.minObservers)
+
+			ts.nodesRespondToTheObservationRequests(
+				ts.peerClient, requestIDs, requestedChains, ts.remoteRMNCfg.ConfigDigest, destChain)
+
+			requestIDs = ts.waitForReportSignatureRequestsToBeSent(
+				t, ts.peerClient, int(ts.remoteRMNCfg.MinSigners),
+				ts.minObservers)
+
+			ts.nodesRespondToTheSignatureRequests(ts.peerClient, requestIDs)
+		}()
+
+		sigs, err := ts.rmnController.ComputeReportSignatures(
+			ts.ctx,
+			destChain,
+			ts.updateRequests,
+			ts.remoteRMNCfg,
+		)
+		require.NoError(t, err)
+
+		// The RMN nodes observe the same roots again, the signatures are served from the cache without requesting
+		// the report signatures.
+		go func() {
+			requestIDs, requestedChains := ts.waitForObservationRequestsToBeSent(
+				ts.peerClient, ts.minObservers)
+
+			ts.nodesRespondToTheObservationRequests(
+				ts.peerClient, requestIDs, requestedChains, ts.remoteRMNCfg.ConfigDigest, destChain)
+		}()
+
+		reversedUpdates := []*rmnpb.FixedDestLaneUpdateRequest{ts.updateRequests[1], ts.updateRequests[0]}
+		cachedSigs, err := ts.rmnController.ComputeReportSignatures(
+			ts.ctx,
+			destChain,
+			reversedUpdates,
+			ts.remoteRMNCfg,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, sigs, cachedSigs)
+		for _, reqs := range ts.peerClient.getReceivedRequests() {
+			for _, req := range reqs {
+				assert.Nil(t, req.GetReportSignatureRequest())
+			}
+		}
+
+		// Lane updates with a different root, e.g. after a source chain reorg, are not served from the cache.
+		reorgedUpdates := []*rmnpb.FixedDestLaneUpdate{
+			sigs.LaneUpdates[0],
+			{
+				LaneSource:     sigs.LaneUpdates[1].LaneSource,
+				ClosedInterval: sigs.LaneUpdates[1].ClosedInterval,
+				Root:           []byte{0xff},
+			},
+		}
+		_, ok := ts.rmnController.sigCache.get(
+			newReportSignaturesCacheKey(destChain, reorgedUpdates, ts.remoteRMNCfg.ConfigDigest),
+			ts.remoteRMNCfg,
+			time.Now(),
+		)
+		assert.False(t, ok)
+
+		// A new RMNRemote config version invalidates the cached signatures.
+		newRemoteCfg := ts.remoteRMNCfg
+		newRemoteCfg.ConfigVersion++
+		_, ok = ts.rmnController.sigCache.get(
+			newReportSignaturesCacheKey(destChain, sigs.LaneUpdates, newRemoteCfg.ConfigDigest),
+			newRemoteCfg,
+			time.Now(),
+		)
+		assert.False(t, ok)
+	})
+
 	t.Run("happy path with retries", func(t *testing.T) {
 		ts := newTestSetup(t)
 
@@ -602,10 +684,44 @@ func (ts *testSetup) nodesRespondToTheSignatureRequests(
 	}
 }
 
+func TestController_InitConnection(t *testing.T) {
+	ctx := tests.Context(t)
+	peerClient := newMockPeerClient(make(chan PeerResponse))
+	c := &controller{
+		lggr:        logger.Test(t),
+		peerClient:  peerClient,
+		connections: make(map[cciptypes.Bytes32]cciptypes.Bytes32),
+	}
+
+	commitCfgDigest := cciptypes.Bytes32{1}
+	activeCfgDigest := cciptypes.Bytes32{2}
+	candidateCfgDigest := cciptypes.Bytes32{3}
+
+	cfgDigests := []cciptypes.Bytes32{activeCfgDigest, candidateCfgDigest}
+	require.NoError(t, c.InitConnection(ctx, commitCfgDigest, cfgDigests, nil))
+	assert.Equal(t, []cciptypes.Bytes32{activeCfgDigest, candidateCfgDigest}, peerClient.openedConnections)
+	assert.Empty(t, peerClient.closedConnections)
+
+	// the candidate is promoted, its connection is kept and the previous active one is closed
+	require.NoError(t, c.InitConnection(ctx, commitCfgDigest, []cciptypes.Bytes32{candidateCfgDigest}, nil))
+	assert.Equal(t, []cciptypes.Bytes32{activeCfgDigest, candidateCfgDigest}, peerClient.openedConnections)
+	assert.Equal(t, []cciptypes.Bytes32{activeCfgDigest}, peerClient.closedConnections)
+
+	// a new commit config digest re-opens the connection
+	require.NoError(t, c.InitConnection(ctx, cciptypes.Bytes32{4}, []cciptypes.Bytes32{candidateCfgDigest}, nil))
+	assert.Equal(t,
+		[]cciptypes.Bytes32{activeCfgDigest, candidateCfgDigest, candidateCfgDigest}, peerClient.openedConnections)
+
+	require.NoError(t, c.Close())
+	assert.Empty(t, c.connections)
+}
+
 type mockPeerClient struct {
-	resChan          chan PeerResponse
-	receivedRequests map[rmntypes.NodeID][]*rmnpb.Request
-	mu               *sync.RWMutex
+	resChan           chan PeerResponse
+	receivedRequests  map[rmntypes.NodeID][]*rmnpb.Request
+	openedConnections []cciptypes.Bytes32
+	closedConnections []cciptypes.Bytes32
+	mu                *sync.RWMutex
 }
 
 func newMockPeerClient(resChan chan PeerResponse) *mockPeerClient {
@@ -631,7 +747,17 @@ func (m *mockPeerClient) resetReceivedRequests() {
 	m.receivedRequests = make(map[rmntypes.NodeID][]*rmnpb.Request)
 }
 
-func (m *mockPeerClient) InitConnection(_ context.Context, _ cciptypes.Bytes32, _ cciptypes.Bytes32, _ []string) error {
+func (m *mockPeerClient) InitConnection(_ context.Context, _, rmnHomeConfigDigest cciptypes.Bytes32, _ []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.openedConnections = append(m.openedConnections, rmnHomeConfigDigest)
+	return nil
+}
+
+func (m *mockPeerClient) CloseConnection(rmnHomeConfigDigest cciptypes.Bytes32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closedConnections = append(m.closedConnections, rmnHomeConfigDigest)
 	return nil
 }
 
@@ -639,7 +765,7 @@ func (m *mockPeerClient) Close() error {
 	return nil
 }
 
-func (m *mockPeerClient) Send(rmnNode rmntypes.HomeNodeInfo, request []byte) error {
+func (m *mockPeerClient) Send(_ cciptypes.Bytes32, rmnNode rmntypes.HomeNodeInfo, request []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
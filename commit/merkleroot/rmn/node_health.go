@@ -0,0 +1,230 @@
+package rmn
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+)
+
+const (
+	// nodeUnhealthyConsecutiveFailures is the number of consecutive failed requests after which
+	// an RMN node is flagged as unhealthy.
+	nodeUnhealthyConsecutiveFailures = 5
+
+	// nodeStatsWeight is the weight of the latest request outcome in the exponentially weighted node stats.
+	nodeStatsWeight = 0.2
+
+	// nodeFailurePenalty is the latency a fully failing node is penalized with when ranking the nodes.
+	nodeFailurePenalty = 10 * time.Second
+)
+
+var (
+	// errInvalidSignature is wrapped by the response validation errors caused by an invalid signature.
+	errInvalidSignature = errors.New("invalid signature")
+
+	// errStaleObservation is wrapped by the response validation errors caused by an RMN node observing
+	// with an outdated RMNHome config.
+	errStaleObservation = errors.New("stale observation")
+)
+
+// nodeStats are the request stats of a single RMN node.
+type nodeStats struct {
+	responses         uint64
+	timeouts          uint64
+	invalidSignatures uint64
+	staleObservations uint64
+	invalidResponses  uint64
+
+	// latency is the exponentially weighted response latency.
+	latency time.Duration
+	// failureRate is the exponentially weighted rate of failed requests.
+	failureRate         float64
+	consecutiveFailures int
+}
+
+// score is used to rank the nodes, lower is better.
+func (s *nodeStats) score() time.Duration {
+	return s.latency + time.Duration(s.failureRate*float64(nodeFailurePenalty))
+}
+
+type pendingNodeRequest struct {
+	nodeID rmntypes.NodeID
+	sentAt time.Time
+}
+
+// nodeHealthTracker keeps per RMN node stats about the requests sent to them.
+// It is used to send the initial requests to the healthiest nodes and to flag nodes that keep failing.
+type nodeHealthTracker struct {
+	lggr logger.Logger
+
+	mu      sync.Mutex
+	pending map[uint64]pendingNodeRequest
+	stats   map[rmntypes.NodeID]*nodeStats
+}
+
+func newNodeHealthTracker(lggr logger.Logger) *nodeHealthTracker {
+	return &nodeHealthTracker{
+		lggr:    lggr,
+		pending: make(map[uint64]pendingNodeRequest),
+		stats:   make(map[rmntypes.NodeID]*nodeStats),
+	}
+}
+
+func (t *nodeHealthTracker) requestSent(requestID uint64, nodeID rmntypes.NodeID, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending[requestID] = pendingNodeRequest{nodeID: nodeID, sentAt: now}
+}
+
+// responseReceived records the outcome of a request, validationErr is the error of the response validation if any.
+func (t *nodeHealthTracker) responseReceived(requestID uint64, validationErr error, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.pending[requestID]
+	if !ok {
+		return
+	}
+	delete(t.pending, requestID)
+
+	s := t.nodeStats(p.nodeID)
+	switch {
+	case validationErr == nil:
+		s.responses++
+		latency := now.Sub(p.sentAt)
+		if s.responses == 1 {
+			s.latency = latency
+		} else {
+			s.latency += time.Duration(nodeStatsWeight * float64(latency-s.latency))
+		}
+		s.failureRate -= nodeStatsWeight * s.failureRate
+		s.consecutiveFailures = 0
+		return
+	case errors.Is(validationErr, errInvalidSignature):
+		s.invalidSignatures++
+	case errors.Is(validationErr, errStaleObservation):
+		s.staleObservations++
+	default:
+		s.invalidResponses++
+	}
+	t.recordFailure(p.nodeID, s, validationErr)
+}
+
+// requestsFinished stops tracking the provided requests. Requests still waiting for a response that were sent
+// before timeoutBefore are recorded as timeouts, the rest are not accounted for.
+func (t *nodeHealthTracker) requestsFinished(requestIDs mapset.Set[uint64], timeoutBefore time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for requestID := range requestIDs.Iter() {
+		p, ok := t.pending[requestID]
+		if !ok {
+			continue
+		}
+		delete(t.pending, requestID)
+		if p.sentAt.After(timeoutBefore) {
+			continue
+		}
+		s := t.nodeStats(p.nodeID)
+		s.timeouts++
+		t.recordFailure(p.nodeID, s, errors.New("request timed out"))
+	}
+}
+
+func (t *nodeHealthTracker) recordFailure(nodeID rmntypes.NodeID, s *nodeStats, reason error) {
+	s.failureRate += nodeStatsWeight * (1 - s.failureRate)
+	s.consecutiveFailures++
+	if s.consecutiveFailures == nodeUnhealthyConsecutiveFailures {
+		t.lggr.Warnw("RMN node flagged as unhealthy",
+			"node", nodeID,
+			"consecutiveFailures", s.consecutiveFailures,
+			"lastFailure", reason,
+			"timeouts", s.timeouts,
+			"invalidSignatures", s.invalidSignatures,
+			"staleObservations", s.staleObservations,
+			"invalidResponses", s.invalidResponses,
+		)
+	}
+}
+
+// nodeStats must be called with the lock held.
+func (t *nodeHealthTracker) nodeStats(nodeID rmntypes.NodeID) *nodeStats {
+	s, ok := t.stats[nodeID]
+	if !ok {
+		s = &nodeStats{}
+		t.stats[nodeID] = s
+	}
+	return s
+}
+
+// rankByHealth returns the items ordered from the healthiest to the least healthy node.
+// Nodes without any stats get a neutral score, the median score of the nodes with stats, so that they are tried
+// after the nodes known to perform well but before the nodes known to perform badly.
+// Nodes with equal scores are ordered randomly.
+func rankByHealth[T any](t *nodeHealthTracker, items []T, nodeID func(T) rmntypes.NodeID) []T {
+	ranked := randomShuffle(items)
+
+	t.mu.Lock()
+	scores := make(map[rmntypes.NodeID]time.Duration, len(ranked))
+	knownScores := make([]time.Duration, 0, len(ranked))
+	for _, item := range ranked {
+		id := nodeID(item)
+		if s, ok := t.stats[id]; ok {
+			scores[id] = s.score()
+			knownScores = append(knownScores, scores[id])
+		}
+	}
+	t.mu.Unlock()
+
+	neutralScore := medianScore(knownScores)
+	for _, item := range ranked {
+		if _, ok := scores[nodeID(item)]; !ok {
+			scores[nodeID(item)] = neutralScore
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[nodeID(ranked[i])] < scores[nodeID(ranked[j])]
+	})
+	return ranked
+}
+
+// medianScore returns the median of the scores, 0 if there are none. The scores are sorted in place.
+func medianScore(scores []time.Duration) time.Duration {
+	if len(scores) == 0 {
+		return 0
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i] < scores[j] })
+	mid := len(scores) / 2
+	if len(scores)%2 == 0 {
+		return (scores[mid-1] + scores[mid]) / 2
+	}
+	return scores[mid]
+}
+
+// HealthReport returns an error for each RMN node that is currently flagged as unhealthy.
+func (t *nodeHealthTracker) HealthReport() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	nodeIDs := make([]rmntypes.NodeID, 0, len(t.stats))
+	for nodeID, s := range t.stats {
+		if s.consecutiveFailures >= nodeUnhealthyConsecutiveFailures {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool { return nodeIDs[i] < nodeIDs[j] })
+
+	errs := make([]error, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		errs = append(errs, fmt.Errorf("rmn node %d failed %d consecutive requests",
+			nodeID, t.stats[nodeID].consecutiveFailures))
+	}
+	return errors.Join(errs...)
+}
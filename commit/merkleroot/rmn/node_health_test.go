@@ -0,0 +1,109 @@
+package rmn
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+)
+
+func Test_nodeHealthTracker_rankByHealth(t *testing.T) {
+	tracker := newNodeHealthTracker(logger.Test(t))
+	now := time.Now()
+
+	// node 1 responds slowly, node 2 responds fast, node 3 times out, node 4 responds with invalid signatures
+	// and node 5 was never requested.
+	requestID := uint64(0)
+	for i := 0; i < 3; i++ {
+		requestID++
+		tracker.requestSent(requestID, 1, now)
+		tracker.responseReceived(requestID, nil, now.Add(time.Second))
+
+		requestID++
+		tracker.requestSent(requestID, 2, now)
+		tracker.responseReceived(requestID, nil, now.Add(100*time.Millisecond))
+
+		requestID++
+		tracker.requestSent(requestID, 3, now)
+		tracker.requestsFinished(mapset.NewSet(requestID), now)
+
+		requestID++
+		tracker.requestSent(requestID, 4, now)
+		tracker.responseReceived(requestID, fmt.Errorf("verify: %w", errInvalidSignature), now.Add(time.Millisecond))
+	}
+
+	for i := 0; i < 10; i++ {
+		ranked := rankByHealth(tracker, []rmntypes.NodeID{1, 2, 3, 4, 5},
+			func(nodeID rmntypes.NodeID) rmntypes.NodeID { return nodeID })
+		// node 5 without stats is ranked after the proven-good nodes but before the failing ones
+		assert.Equal(t, []rmntypes.NodeID{2, 1, 5}, ranked[:3])
+		// failing nodes are ranked last, nodes with the same failure rate are ordered randomly
+		assert.ElementsMatch(t, []rmntypes.NodeID{3, 4}, ranked[3:])
+	}
+
+	assert.Equal(t, uint64(3), tracker.stats[1].responses)
+	assert.Equal(t, uint64(3), tracker.stats[3].timeouts)
+	assert.Equal(t, uint64(3), tracker.stats[4].invalidSignatures)
+	assert.Empty(t, tracker.pending)
+}
+
+func Test_nodeHealthTracker_rankByHealth_NoStats(t *testing.T) {
+	tracker := newNodeHealthTracker(logger.Test(t))
+	ranked := rankByHealth(tracker, []rmntypes.NodeID{1, 2, 3},
+		func(nodeID rmntypes.NodeID) rmntypes.NodeID { return nodeID })
+	assert.ElementsMatch(t, []rmntypes.NodeID{1, 2, 3}, ranked)
+}
+
+func Test_nodeHealthTracker_requestsFinished(t *testing.T) {
+	tracker := newNodeHealthTracker(logger.Test(t))
+	now := time.Now()
+
+	tracker.requestSent(1, 1, now)
+	tracker.requestSent(2, 2, now.Add(time.Second))
+	tracker.requestSent(3, 3, now)
+
+	// only the requests sent before the timeout are accounted as timeouts
+	tracker.requestsFinished(mapset.NewSet[uint64](1, 2), now)
+	assert.Equal(t, uint64(1), tracker.stats[1].timeouts)
+	assert.Nil(t, tracker.stats[2])
+	// requests that are not finished are still pending
+	assert.Len(t, tracker.pending, 1)
+}
+
+func Test_nodeHealthTracker_HealthReport(t *testing.T) {
+	tracker := newNodeHealthTracker(logger.Test(t))
+	now := time.Now()
+
+	requestID := uint64(0)
+	failNode := func(nodeID rmntypes.NodeID, err error) {
+		requestID++
+		tracker.requestSent(requestID, nodeID, now)
+		tracker.responseReceived(requestID, err, now)
+	}
+
+	for i := 0; i < nodeUnhealthyConsecutiveFailures-1; i++ {
+		failNode(1, fmt.Errorf("digest: %w", errStaleObservation))
+		failNode(2, errors.New("unexpected response"))
+	}
+	require.NoError(t, tracker.HealthReport())
+
+	failNode(1, fmt.Errorf("digest: %w", errStaleObservation))
+	err := tracker.HealthReport()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rmn node 1 failed 5 consecutive requests")
+	assert.NotContains(t, err.Error(), "rmn node 2")
+	assert.Equal(t, uint64(nodeUnhealthyConsecutiveFailures), tracker.stats[1].staleObservations)
+	assert.Equal(t, uint64(nodeUnhealthyConsecutiveFailures-1), tracker.stats[2].invalidResponses)
+
+	// a valid response makes the node healthy again
+	failNode(1, nil)
+	require.NoError(t, tracker.HealthReport())
+}
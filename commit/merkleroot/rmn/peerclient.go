@@ -6,6 +6,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -24,9 +25,12 @@ import (
 var ErrNoConn = fmt.Errorf("no connection, please call InitConnection before further interaction")
 
 // PeerClient performs low-level communication with RMN peers.
+// It can be connected to the peer group endpoints of several RMNHome configs at the same time, e.g. to the active
+// and the candidate config during an RMNHome config rotation.
 type PeerClient interface {
-	// InitConnection initializes the connection to the peer group endpoint and must be called before
-	// further PeerClient interaction.
+	// InitConnection initializes the connection to the peer group endpoint of the provided RMNHome config digest and
+	// must be called before further PeerClient interaction for that digest. The connections of other RMNHome config
+	// digests are not affected. If called twice for the same digest it overwrites the previous connection.
 	InitConnection(
 		ctx context.Context,
 		commitConfigDigest cciptypes.Bytes32,
@@ -34,11 +38,15 @@ type PeerClient interface {
 		peerIDs []string, // union of oraclePeerIDs and rmnNodePeerIDs (oracles required for peer discovery)
 	) error
 
+	// CloseConnection closes the connection of the provided RMNHome config digest and all the underlying streams.
+	CloseConnection(rmnHomeConfigDigest cciptypes.Bytes32) error
+
+	// Close closes all the connections.
 	Close() error
 
-	// Send will send a message to the target RMN node.
-	// If Send is called before InitConnection, it returns an ErrNoConn.
-	Send(rmnNode rmntypes.HomeNodeInfo, request []byte) error
+	// Send will send a message to the target RMN node using the connection of the provided RMNHome config digest.
+	// If Send is called before InitConnection for that digest, it returns an ErrNoConn.
+	Send(rmnHomeConfigDigest cciptypes.Bytes32, rmnNode rmntypes.HomeNodeInfo, request []byte) error
 
 	// Recv returns a channel which can be used to listen on for
 	// responses by all RMN nodes. This is expected to be monitored
@@ -53,14 +61,19 @@ type PeerResponse struct {
 }
 
 type peerClient struct {
-	lggr                        logger.Logger
-	peerGroupFactory            PeerGroupFactory
-	respChan                    chan PeerResponse
-	peerGroup                   PeerGroup // nil initially, until InitConnection is called
+	lggr             logger.Logger
+	peerGroupFactory PeerGroupFactory
+	respChan         chan PeerResponse
+	bootstrappers    []commontypes.BootstrapperLocator
+	connections      map[cciptypes.Bytes32]*peerGroupConnection // rmnHomeConfigDigest -> connection
+	mu               *sync.RWMutex
+}
+
+// peerGroupConnection is the connection to the peer group endpoint of a single RMNHome config.
+type peerGroupConnection struct {
+	peerGroup                   PeerGroup
 	genericEndpointConfigDigest cciptypes.Bytes32
 	rageP2PStreams              map[rmntypes.NodeID]Stream
-	bootstrappers               []commontypes.BootstrapperLocator
-	mu                          *sync.RWMutex
 }
 
 func NewPeerClient(
@@ -69,14 +82,12 @@ func NewPeerClient(
 	bootstrappers []commontypes.BootstrapperLocator,
 ) PeerClient {
 	return &peerClient{
-		lggr:                        lggr,
-		peerGroupFactory:            peerGroupFactory,
-		respChan:                    make(chan PeerResponse),
-		peerGroup:                   nil,
-		rageP2PStreams:              make(map[rmntypes.NodeID]Stream),
-		genericEndpointConfigDigest: cciptypes.Bytes32{},
-		bootstrappers:               bootstrappers,
-		mu:                          &sync.RWMutex{},
+		lggr:             lggr,
+		peerGroupFactory: peerGroupFactory,
+		respChan:         make(chan PeerResponse),
+		bootstrappers:    bootstrappers,
+		connections:      make(map[cciptypes.Bytes32]*peerGroupConnection),
+		mu:               &sync.RWMutex{},
 	}
 }
 
@@ -85,7 +96,7 @@ func (r *peerClient) InitConnection(
 	commitConfigDigest, rmnHomeConfigDigest cciptypes.Bytes32,
 	peerIDs []string,
 ) error {
-	if err := r.Close(); err != nil {
+	if err := r.CloseConnection(rmnHomeConfigDigest); err != nil {
 		return fmt.Errorf("close existing peer group: %w", err)
 	}
 
@@ -93,12 +104,13 @@ func (r *peerClient) InitConnection(
 	defer r.mu.Unlock()
 
 	h := sha256.Sum256(append(commitConfigDigest[:], rmnHomeConfigDigest[:]...))
-	r.genericEndpointConfigDigest = writePrefix(ocr2types.ConfigDigestPrefixCCIPMultiRoleRMNCombo, h)
+	genericEndpointConfigDigest := writePrefix(ocr2types.ConfigDigestPrefixCCIPMultiRoleRMNCombo, h)
 	r.lggr.Infow("Creating new peer group",
-		"genericEndpointConfigDigest", r.genericEndpointConfigDigest.String())
+		"rmnHomeConfigDigest", rmnHomeConfigDigest.String(),
+		"genericEndpointConfigDigest", genericEndpointConfigDigest.String())
 
 	peerGroup, err := r.peerGroupFactory.NewPeerGroup(
-		[32]byte(r.genericEndpointConfigDigest),
+		[32]byte(genericEndpointConfigDigest),
 		peerIDs,
 		r.bootstrappers,
 	)
@@ -107,35 +119,59 @@ func (r *peerClient) InitConnection(
 		return fmt.Errorf("new peer group: %w", err)
 	}
 
-	r.peerGroup = peerGroup
+	r.connections[rmnHomeConfigDigest] = &peerGroupConnection{
+		peerGroup:                   peerGroup,
+		genericEndpointConfigDigest: genericEndpointConfigDigest,
+		rageP2PStreams:              make(map[rmntypes.NodeID]Stream),
+	}
 	return nil
 }
 
-func (r *peerClient) Close() error {
+func (r *peerClient) CloseConnection(rmnHomeConfigDigest cciptypes.Bytes32) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if r.peerGroup == nil {
+	conn, ok := r.connections[rmnHomeConfigDigest]
+	if !ok {
 		return nil
 	}
+	delete(r.connections, rmnHomeConfigDigest)
 
 	// individual streams are closed by the peer group
-	if err := r.peerGroup.Close(); err != nil {
+	if err := conn.peerGroup.Close(); err != nil {
 		return fmt.Errorf("close peer group: %w", err)
 	}
 
 	return nil
 }
 
-func (r *peerClient) Send(rmnNode rmntypes.HomeNodeInfo, request []byte) error {
+func (r *peerClient) Close() error {
+	r.mu.RLock()
+	rmnHomeConfigDigests := make([]cciptypes.Bytes32, 0, len(r.connections))
+	for digest := range r.connections {
+		rmnHomeConfigDigests = append(rmnHomeConfigDigests, digest)
+	}
+	r.mu.RUnlock()
+
+	var errs []error
+	for _, digest := range rmnHomeConfigDigests {
+		if err := r.CloseConnection(digest); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *peerClient) Send(rmnHomeConfigDigest cciptypes.Bytes32, rmnNode rmntypes.HomeNodeInfo, request []byte) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if r.peerGroup == nil {
+	conn, ok := r.connections[rmnHomeConfigDigest]
+	if !ok {
 		return ErrNoConn
 	}
 
-	stream, err := r.getOrCreateRageP2PStream(rmnNode)
+	stream, err := r.getOrCreateRageP2PStream(conn, rmnNode)
 	if err != nil {
 		return fmt.Errorf("get or create rage p2p stream: %w", err)
 	}
@@ -145,19 +181,22 @@ func (r *peerClient) Send(rmnNode rmntypes.HomeNodeInfo, request []byte) error {
 	return nil
 }
 
-func (r *peerClient) getOrCreateRageP2PStream(rmnNode rmntypes.HomeNodeInfo) (Stream, error) {
-	stream, ok := r.rageP2PStreams[rmnNode.ID]
+func (r *peerClient) getOrCreateRageP2PStream(
+	conn *peerGroupConnection,
+	rmnNode rmntypes.HomeNodeInfo,
+) (Stream, error) {
+	stream, ok := conn.rageP2PStreams[rmnNode.ID]
 	if ok {
 		return stream, nil
 	}
 
 	// todo: versioning for stream names e.g. for 'v1_7'
 	streamName := fmt.Sprintf("ccip-rmn/v1_6/%s",
-		strings.TrimPrefix(r.genericEndpointConfigDigest.String(), "0x"))
+		strings.TrimPrefix(conn.genericEndpointConfigDigest.String(), "0x"))
 	r.lggr.Infow("Creating new stream", "streamName", streamName)
 
 	var err error
-	stream, err = r.peerGroup.NewStream(
+	stream, err = conn.peerGroup.NewStream(
 		rmnNode.PeerID.String(),
 		networking.NewStreamArgs1{
 			StreamName:         streamName,
@@ -178,7 +217,7 @@ func (r *peerClient) getOrCreateRageP2PStream(rmnNode rmntypes.HomeNodeInfo) (St
 		return nil, fmt.Errorf("new stream %s: %w", streamName, err)
 	}
 
-	r.rageP2PStreams[rmnNode.ID] = stream
+	conn.rageP2PStreams[rmnNode.ID] = stream
 	go r.listenToStream(rmnNode.ID, stream)
 	return stream, nil
 }
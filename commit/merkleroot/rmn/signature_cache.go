@@ -0,0 +1,145 @@
+package rmn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/rmnpb"
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+const (
+	// reportSignaturesCacheTTL is how long collected report signatures are reused for identical lane updates.
+	reportSignaturesCacheTTL = 10 * time.Minute
+
+	// reportSignaturesCacheMaxEntries bounds the number of cached report signatures.
+	reportSignaturesCacheMaxEntries = 32
+)
+
+type reportSignaturesCacheKey [sha256.Size]byte
+
+type cachedReportSignatures struct {
+	sigs      *ReportSignatures
+	remoteCfg rmntypes.RemoteConfig
+	createdAt time.Time
+}
+
+// reportSignaturesCache keeps the report signatures collected from the RMN nodes, so that they can be reused when
+// the RMN nodes observe the same lane updates with the same merkle roots again, e.g. when the same roots are
+// re-proposed after the report was not transmitted.
+type reportSignaturesCache struct {
+	mu      sync.Mutex
+	entries map[reportSignaturesCacheKey]cachedReportSignatures
+}
+
+func newReportSignaturesCache() *reportSignaturesCache {
+	return &reportSignaturesCache{
+		entries: make(map[reportSignaturesCacheKey]cachedReportSignatures),
+	}
+}
+
+// newReportSignaturesCacheKey derives the cache key from the destination lane, the lane updates to sign, including
+// their merkle roots, and the RMNHome config digest. The order of the lane updates does not affect the key.
+func newReportSignaturesCacheKey(
+	destChain *rmnpb.LaneDest,
+	laneUpdates []*rmnpb.FixedDestLaneUpdate,
+	rmnHomeConfigDigest cciptypes.Bytes32,
+) reportSignaturesCacheKey {
+	sorted := make([]*rmnpb.FixedDestLaneUpdate, len(laneUpdates))
+	copy(sorted, laneUpdates)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetLaneSource().GetSourceChainSelector() < sorted[j].GetLaneSource().GetSourceChainSelector()
+	})
+
+	h := sha256.New()
+	writeBytes := func(b []byte) {
+		_ = binary.Write(h, binary.BigEndian, uint64(len(b)))
+		_, _ = h.Write(b)
+	}
+	writeUint64 := func(v uint64) {
+		_ = binary.Write(h, binary.BigEndian, v)
+	}
+
+	_, _ = h.Write(rmnHomeConfigDigest[:])
+	writeUint64(destChain.GetDestChainSelector())
+	writeBytes(destChain.GetOfframpAddress())
+	for _, req := range sorted {
+		writeUint64(req.GetLaneSource().GetSourceChainSelector())
+		writeBytes(req.GetLaneSource().GetOnrampAddress())
+		writeUint64(req.GetClosedInterval().GetMinMsgNr())
+		writeUint64(req.GetClosedInterval().GetMaxMsgNr())
+		writeBytes(req.GetRoot())
+	}
+
+	var key reportSignaturesCacheKey
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// get returns the cached report signatures if they are still valid for the provided RMNRemote config.
+func (c *reportSignaturesCache) get(
+	key reportSignaturesCacheKey,
+	rmnRemoteCfg rmntypes.RemoteConfig,
+	now time.Time,
+) (*ReportSignatures, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if now.Sub(entry.createdAt) > reportSignaturesCacheTTL || !signaturesValidForRemoteConfig(entry, rmnRemoteCfg) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.sigs, true
+}
+
+func (c *reportSignaturesCache) add(
+	key reportSignaturesCacheKey,
+	rmnRemoteCfg rmntypes.RemoteConfig,
+	sigs *ReportSignatures,
+	now time.Time,
+) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, entry := range c.entries {
+		if now.Sub(entry.createdAt) > reportSignaturesCacheTTL {
+			delete(c.entries, k)
+		}
+	}
+
+	// evict the oldest entry if the cache is full
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= reportSignaturesCacheMaxEntries {
+		var oldestKey reportSignaturesCacheKey
+		var oldest time.Time
+		for k, entry := range c.entries {
+			if oldest.IsZero() || entry.createdAt.Before(oldest) {
+				oldestKey, oldest = k, entry.createdAt
+			}
+		}
+		delete(c.entries, oldestKey)
+	}
+
+	c.entries[key] = cachedReportSignatures{sigs: sigs, remoteCfg: rmnRemoteCfg, createdAt: now}
+}
+
+// signaturesValidForRemoteConfig checks that the RMN report the cached signatures were computed for is the same
+// under the provided RMNRemote config and that the signers are still enough to meet the min signers threshold.
+func signaturesValidForRemoteConfig(entry cachedReportSignatures, rmnRemoteCfg rmntypes.RemoteConfig) bool {
+	if !bytes.Equal(entry.remoteCfg.ContractAddress, rmnRemoteCfg.ContractAddress) ||
+		entry.remoteCfg.ConfigDigest != rmnRemoteCfg.ConfigDigest ||
+		entry.remoteCfg.ConfigVersion != rmnRemoteCfg.ConfigVersion ||
+		entry.remoteCfg.RmnReportVersion != rmnRemoteCfg.RmnReportVersion {
+		return false
+	}
+	return uint64(len(entry.sigs.Signatures)) >= rmnRemoteCfg.MinSigners
+}
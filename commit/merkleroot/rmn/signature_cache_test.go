@@ -0,0 +1,89 @@
+package rmn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/rmnpb"
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+func Test_newReportSignaturesCacheKey(t *testing.T) {
+	dest := &rmnpb.LaneDest{DestChainSelector: 1, OfframpAddress: []byte{1}}
+	lu := func(chain, minMsgNr, maxMsgNr uint64, root byte) *rmnpb.FixedDestLaneUpdate {
+		return &rmnpb.FixedDestLaneUpdate{
+			LaneSource:     &rmnpb.LaneSource{SourceChainSelector: chain, OnrampAddress: []byte{byte(chain)}},
+			ClosedInterval: &rmnpb.ClosedInterval{MinMsgNr: minMsgNr, MaxMsgNr: maxMsgNr},
+			Root:           []byte{root},
+		}
+	}
+	digest := cciptypes.Bytes32{1}
+
+	key := newReportSignaturesCacheKey(dest, []*rmnpb.FixedDestLaneUpdate{lu(2, 1, 5, 1), lu(3, 1, 5, 1)}, digest)
+
+	assert.Equal(t, key,
+		newReportSignaturesCacheKey(dest, []*rmnpb.FixedDestLaneUpdate{lu(3, 1, 5, 1), lu(2, 1, 5, 1)}, digest))
+	assert.NotEqual(t, key,
+		newReportSignaturesCacheKey(dest, []*rmnpb.FixedDestLaneUpdate{lu(2, 1, 6, 1), lu(3, 1, 5, 1)}, digest))
+	assert.NotEqual(t, key,
+		newReportSignaturesCacheKey(dest, []*rmnpb.FixedDestLaneUpdate{lu(2, 1, 5, 1), lu(3, 1, 5, 2)}, digest))
+	assert.NotEqual(t, key,
+		newReportSignaturesCacheKey(dest, []*rmnpb.FixedDestLaneUpdate{lu(2, 1, 5, 1)}, digest))
+	assert.NotEqual(t, key,
+		newReportSignaturesCacheKey(dest, []*rmnpb.FixedDestLaneUpdate{lu(2, 1, 5, 1), lu(3, 1, 5, 1)},
+			cciptypes.Bytes32{2}))
+	assert.NotEqual(t, key,
+		newReportSignaturesCacheKey(&rmnpb.LaneDest{DestChainSelector: 1, OfframpAddress: []byte{2}},
+			[]*rmnpb.FixedDestLaneUpdate{lu(2, 1, 5, 1), lu(3, 1, 5, 1)}, digest))
+}
+
+func Test_reportSignaturesCache(t *testing.T) {
+	remoteCfg := rmntypes.RemoteConfig{
+		ContractAddress:  []byte{1},
+		ConfigDigest:     cciptypes.Bytes32{1},
+		MinSigners:       2,
+		ConfigVersion:    1,
+		RmnReportVersion: cciptypes.Bytes32{2},
+	}
+	sigs := &ReportSignatures{Signatures: []*rmnpb.EcdsaSignature{{}, {}}}
+	now := time.Now()
+
+	t.Run("ttl", func(t *testing.T) {
+		cache := newReportSignaturesCache()
+		cache.add(reportSignaturesCacheKey{1}, remoteCfg, sigs, now)
+
+		got, ok := cache.get(reportSignaturesCacheKey{1}, remoteCfg, now.Add(reportSignaturesCacheTTL))
+		require.True(t, ok)
+		assert.Equal(t, sigs, got)
+
+		_, ok = cache.get(reportSignaturesCacheKey{1}, remoteCfg, now.Add(reportSignaturesCacheTTL+time.Second))
+		assert.False(t, ok)
+		assert.Empty(t, cache.entries)
+	})
+
+	t.Run("min signers increased", func(t *testing.T) {
+		cache := newReportSignaturesCache()
+		cache.add(reportSignaturesCacheKey{1}, remoteCfg, sigs, now)
+
+		newCfg := remoteCfg
+		newCfg.MinSigners = 3
+		_, ok := cache.get(reportSignaturesCacheKey{1}, newCfg, now)
+		assert.False(t, ok)
+	})
+
+	t.Run("oldest entry is evicted", func(t *testing.T) {
+		cache := newReportSignaturesCache()
+		for i := 0; i < reportSignaturesCacheMaxEntries+1; i++ {
+			cache.add(reportSignaturesCacheKey{byte(i)}, remoteCfg, sigs, now.Add(time.Duration(i)*time.Millisecond))
+		}
+		assert.Len(t, cache.entries, reportSignaturesCacheMaxEntries)
+		_, ok := cache.get(reportSignaturesCacheKey{0}, remoteCfg, now)
+		assert.False(t, ok)
+		_, ok = cache.get(reportSignaturesCacheKey{1}, remoteCfg, now)
+		assert.True(t, ok)
+	})
+}
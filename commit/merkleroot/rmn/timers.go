@@ -0,0 +1,114 @@
+package rmn
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/rmnpb"
+	"github.com/goplugin/plugin-ccip/pluginconfig"
+)
+
+// pendingRequestTTL is how long a sent request is tracked while waiting for its response.
+// Requests that are not responded within this duration are dropped from the tracker.
+const pendingRequestTTL = time.Minute
+
+type requestKind int
+
+const (
+	observationRequest requestKind = iota
+	reportSignatureRequest
+)
+
+type pendingRequest struct {
+	kind   requestKind
+	sentAt time.Time
+}
+
+// responseTimeTracker keeps track of the recent RMN node response times and derives the initial request timers
+// from them, based on the provided adaptive timers config.
+type responseTimeTracker struct {
+	cfg pluginconfig.RMNAdaptiveTimersConfig
+
+	mu      sync.Mutex
+	pending map[uint64]pendingRequest
+	// samples holds the most recent response times per request kind, oldest first.
+	samples map[requestKind][]time.Duration
+}
+
+func newResponseTimeTracker(cfg pluginconfig.RMNAdaptiveTimersConfig) *responseTimeTracker {
+	return &responseTimeTracker{
+		cfg:     cfg,
+		pending: make(map[uint64]pendingRequest),
+		samples: make(map[requestKind][]time.Duration),
+	}
+}
+
+// requestSent records the time the provided request was sent at. Requests that are not observation
+// or report signature requests are ignored.
+func (t *responseTimeTracker) requestSent(req *rmnpb.Request, now time.Time) {
+	var kind requestKind
+	switch req.Request.(type) {
+	case *rmnpb.Request_ObservationRequest:
+		kind = observationRequest
+	case *rmnpb.Request_ReportSignatureRequest:
+		kind = reportSignatureRequest
+	default:
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, p := range t.pending {
+		if now.Sub(p.sentAt) > pendingRequestTTL {
+			delete(t.pending, id)
+		}
+	}
+	t.pending[req.RequestId] = pendingRequest{kind: kind, sentAt: now}
+}
+
+// responseReceived records the response time of the provided request if it was sent through requestSent.
+func (t *responseTimeTracker) responseReceived(requestID uint64, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.pending[requestID]
+	if !ok {
+		return
+	}
+	delete(t.pending, requestID)
+
+	samples := append(t.samples[p.kind], now.Sub(p.sentAt))
+	if len(samples) > t.cfg.WindowSize {
+		samples = samples[len(samples)-t.cfg.WindowSize:]
+	}
+	t.samples[p.kind] = samples
+}
+
+// timer returns the configured percentile of the recent response times of the provided kind, bounded by the
+// configured min and max timers. If there are not enough samples yet the provided default is returned.
+func (t *responseTimeTracker) timer(kind requestKind, defaultTimer time.Duration) time.Duration {
+	t.mu.Lock()
+	samples := make([]time.Duration, len(t.samples[kind]))
+	copy(samples, t.samples[kind])
+	t.mu.Unlock()
+
+	if len(samples) < t.cfg.MinSamples {
+		return defaultTimer
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	// nearest-rank percentile
+	idx := (len(samples)*int(t.cfg.Percentile)+99)/100 - 1
+	if idx < 0 {
+		idx = 0
+	}
+	d := samples[idx]
+
+	if d < t.cfg.MinTimer.Duration() {
+		return t.cfg.MinTimer.Duration()
+	}
+	if d > t.cfg.MaxTimer.Duration() {
+		return t.cfg.MaxTimer.Duration()
+	}
+	return d
+}
@@ -0,0 +1,120 @@
+package rmn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	commonconfig "github.com/goplugin/plugin-common/pkg/config"
+
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/rmnpb"
+	"github.com/goplugin/plugin-ccip/pluginconfig"
+)
+
+func Test_responseTimeTracker_timer(t *testing.T) {
+	cfg := pluginconfig.RMNAdaptiveTimersConfig{
+		Percentile: 90,
+		MinSamples: 5,
+		WindowSize: 10,
+		MinTimer:   *commonconfig.MustNewDuration(100 * time.Millisecond),
+		MaxTimer:   *commonconfig.MustNewDuration(3 * time.Second),
+	}
+	const defaultTimer = 2 * time.Second
+
+	testCases := []struct {
+		name          string
+		responseTimes []time.Duration
+		expTimer      time.Duration
+	}{
+		{
+			name:          "not enough samples",
+			responseTimes: []time.Duration{time.Second, time.Second},
+			expTimer:      defaultTimer,
+		},
+		{
+			name: "percentile of the samples",
+			responseTimes: []time.Duration{
+				100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 400 * time.Millisecond,
+				500 * time.Millisecond, 600 * time.Millisecond, 700 * time.Millisecond, 800 * time.Millisecond,
+				900 * time.Millisecond, 1000 * time.Millisecond,
+			},
+			expTimer: 900 * time.Millisecond,
+		},
+		{
+			name: "only the most recent samples are considered",
+			responseTimes: []time.Duration{
+				time.Second, time.Second, time.Second, time.Second, time.Second,
+				time.Second, time.Second, time.Second, time.Second, time.Second,
+				200 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond,
+				200 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond,
+				200 * time.Millisecond, 200 * time.Millisecond,
+			},
+			expTimer: 200 * time.Millisecond,
+		},
+		{
+			name: "bounded by the min timer",
+			responseTimes: []time.Duration{
+				time.Millisecond, time.Millisecond, time.Millisecond, time.Millisecond, time.Millisecond,
+			},
+			expTimer: 100 * time.Millisecond,
+		},
+		{
+			name: "bounded by the max timer",
+			responseTimes: []time.Duration{
+				10 * time.Second, 10 * time.Second, 10 * time.Second, 10 * time.Second, 10 * time.Second,
+			},
+			expTimer: 3 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tracker := newResponseTimeTracker(cfg)
+			now := time.Now()
+			for i, d := range tc.responseTimes {
+				req := &rmnpb.Request{
+					RequestId: uint64(i),
+					Request:   &rmnpb.Request_ObservationRequest{ObservationRequest: &rmnpb.ObservationRequest{}},
+				}
+				tracker.requestSent(req, now)
+				tracker.responseReceived(req.RequestId, now.Add(d))
+			}
+
+			assert.Equal(t, tc.expTimer, tracker.timer(observationRequest, defaultTimer))
+			// samples are tracked per request kind
+			assert.Equal(t, defaultTimer, tracker.timer(reportSignatureRequest, defaultTimer))
+		})
+	}
+}
+
+func Test_responseTimeTracker_unknownRequests(t *testing.T) {
+	tracker := newResponseTimeTracker(pluginconfig.RMNAdaptiveTimersConfig{
+		Percentile: 50,
+		MinSamples: 1,
+		WindowSize: 1,
+		MaxTimer:   *commonconfig.MustNewDuration(time.Minute),
+	})
+	now := time.Now()
+
+	// responses of requests that were never sent are ignored
+	tracker.responseReceived(1, now)
+	assert.Equal(t, time.Second, tracker.timer(reportSignatureRequest, time.Second))
+
+	// pending requests expire after the ttl
+	tracker.requestSent(&rmnpb.Request{
+		RequestId: 2,
+		Request:   &rmnpb.Request_ReportSignatureRequest{ReportSignatureRequest: &rmnpb.ReportSignatureRequest{}},
+	}, now)
+	tracker.requestSent(&rmnpb.Request{
+		RequestId: 3,
+		Request:   &rmnpb.Request_ReportSignatureRequest{ReportSignatureRequest: &rmnpb.ReportSignatureRequest{}},
+	}, now.Add(2*pendingRequestTTL))
+	tracker.responseReceived(2, now.Add(2*pendingRequestTTL))
+	assert.Equal(t, time.Second, tracker.timer(reportSignatureRequest, time.Second))
+
+	tracker.responseReceived(3, now.Add(2*pendingRequestTTL+5*time.Second))
+	assert.Equal(t, 5*time.Second, tracker.timer(reportSignatureRequest, time.Second))
+}
@@ -3,11 +3,37 @@ package rmn
 import (
 	"fmt"
 
+	chainsel "github.com/goplugin/chain-selectors"
+
 	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/rmnpb"
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
 
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 )
 
+// NewRMNReport builds the RMN report that is signed by the RMN nodes and verified by RMNRemote on the dest chain.
+func NewRMNReport(
+	destChain cciptypes.ChainSelector,
+	offRampAddress cciptypes.Bytes,
+	rmnRemoteCfg rmntypes.RemoteConfig,
+	laneUpdates []cciptypes.RMNLaneUpdate,
+) (cciptypes.RMNReport, error) {
+	ch, exists := chainsel.ChainBySelector(uint64(destChain))
+	if !exists {
+		return cciptypes.RMNReport{}, fmt.Errorf("unknown dest chain selector %d", destChain)
+	}
+
+	return cciptypes.RMNReport{
+		ReportVersionDigest:         rmnRemoteCfg.RmnReportVersion,
+		DestChainID:                 cciptypes.NewBigIntFromInt64(int64(ch.EvmChainID)),
+		DestChainSelector:           destChain,
+		RmnRemoteContractAddress:    rmnRemoteCfg.ContractAddress,
+		OfframpAddress:              offRampAddress,
+		RmnHomeContractConfigDigest: rmnRemoteCfg.ConfigDigest,
+		LaneUpdates:                 laneUpdates,
+	}, nil
+}
+
 // NewLaneUpdatesFromPB converts a slice of pb FixedDestLaneUpdate to a slice of RMNLaneUpdate
 func NewLaneUpdatesFromPB(pbLaneUpdates []*rmnpb.FixedDestLaneUpdate) ([]cciptypes.RMNLaneUpdate, error) {
 	laneUpdates := make([]cciptypes.RMNLaneUpdate, 0, len(pbLaneUpdates))
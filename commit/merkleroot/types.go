@@ -1,6 +1,7 @@
 package merkleroot
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
@@ -174,3 +175,16 @@ const (
 	BuildingReport
 	WaitingForReportTransmission
 )
+
+func (s State) String() string {
+	switch s {
+	case SelectingRangesForReport:
+		return "SelectingRangesForReport"
+	case BuildingReport:
+		return "BuildingReport"
+	case WaitingForReportTransmission:
+		return "WaitingForReportTransmission"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
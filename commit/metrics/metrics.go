@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/goplugin/plugin-ccip/internal/plugincommon/consensus"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// Phase is the name of an OCR phase or a processor step which is timed.
+type Phase string
+
+const (
+	PhaseQuery       Phase = "query"
+	PhaseObservation Phase = "observation"
+	PhaseOutcome     Phase = "outcome"
+	PhaseReports     Phase = "reports"
+)
+
+// Reporter reports metrics of the commit plugin and its processors.
+type Reporter interface {
+	consensus.FailureTracker
+
+	// TrackPhaseDuration reports how long the plugin or a processor spent in a phase.
+	TrackPhaseDuration(processor string, phase Phase, duration time.Duration)
+	// TrackObservationSize reports the size of the encoded observation of this oracle.
+	TrackObservationSize(sizeBytes int)
+	// TrackMerkleRootsReported reports the number of merkle roots included in a report for a source chain.
+	TrackMerkleRootsReported(sourceChain cciptypes.ChainSelector, count int)
+	// TrackPriceUpdates reports the number of token and gas price updates included in a report.
+	TrackPriceUpdates(tokenPriceUpdates, gasPriceUpdates int)
+	// TrackMerkleRootStateTransition reports a transition of the merkle root processor state machine.
+	TrackMerkleRootStateTransition(from, to string)
+	// TrackError reports an error which was handled during a phase without failing it, e.g. an unreadable object.
+	TrackError(phase Phase, object string)
+	// TrackReorgDetected reports a committed merkle root which no longer matches the source chain messages.
+	TrackReorgDetected(sourceChain cciptypes.ChainSelector)
+}
+
+// Noop is a Reporter which discards all metrics.
+type Noop struct{}
+
+func (Noop) TrackConsensusFailure(string, string)                  {}
+func (Noop) TrackPhaseDuration(string, Phase, time.Duration)       {}
+func (Noop) TrackObservationSize(int)                              {}
+func (Noop) TrackMerkleRootsReported(cciptypes.ChainSelector, int) {}
+func (Noop) TrackPriceUpdates(int, int)                            {}
+func (Noop) TrackMerkleRootStateTransition(string, string)         {}
+func (Noop) TrackError(Phase, string)                              {}
+func (Noop) TrackReorgDetected(cciptypes.ChainSelector)            {}
+
+var (
+	promPhaseDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ccip_commit_phase_duration_seconds",
+			Help:    "Duration of commit plugin phases, per processor",
+			Buckets: []float64{.01, .05, .1, .25, .5, 1, 2, 5, 10, 20},
+		},
+		[]string{"destChainSelector", "processor", "phase"},
+	)
+	promObservationSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ccip_commit_observation_size_bytes",
+			Help: "Size of the last encoded commit observation",
+		},
+		[]string{"destChainSelector"},
+	)
+	promConsensusFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccip_commit_consensus_failures_total",
+			Help: "Number of times the commit plugin could not reach consensus on an object for a key",
+		},
+		[]string{"destChainSelector", "object", "key"},
+	)
+	promMerkleRootsReported = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccip_commit_merkle_roots_reported_total",
+			Help: "Number of merkle roots included in commit reports, per source chain",
+		},
+		[]string{"destChainSelector", "sourceChainSelector"},
+	)
+	promPriceUpdates = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccip_commit_price_updates_total",
+			Help: "Number of price updates included in commit reports",
+		},
+		[]string{"destChainSelector", "type"},
+	)
+	promMerkleRootStateTransitions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccip_commit_merkle_root_state_transitions_total",
+			Help: "Number of merkle root processor state transitions",
+		},
+		[]string{"destChainSelector", "from", "to"},
+	)
+	promErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccip_commit_errors_total",
+			Help: "Number of errors handled by the commit plugin, per phase and object",
+		},
+		[]string{"destChainSelector", "phase", "object"},
+	)
+	promReorgsDetected = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccip_commit_reorgs_detected_total",
+			Help: "Number of committed merkle roots which no longer match the source chain messages",
+		},
+		[]string{"destChainSelector", "sourceChainSelector"},
+	)
+)
+
+// PromReporter is a Reporter backed by Prometheus metrics. All metrics are labeled with the destination chain.
+type PromReporter struct {
+	destChain string
+}
+
+// NewPromReporter creates a Prometheus Reporter for the commit plugin of the given destination chain.
+func NewPromReporter(destChain cciptypes.ChainSelector) *PromReporter {
+	return &PromReporter{
+		destChain: strconv.FormatUint(uint64(destChain), 10),
+	}
+}
+
+func (r *PromReporter) TrackConsensusFailure(objectName string, key string) {
+	promConsensusFailures.WithLabelValues(r.destChain, objectName, key).Inc()
+}
+
+func (r *PromReporter) TrackPhaseDuration(processor string, phase Phase, duration time.Duration) {
+	promPhaseDuration.WithLabelValues(r.destChain, processor, string(phase)).Observe(duration.Seconds())
+}
+
+func (r *PromReporter) TrackObservationSize(sizeBytes int) {
+	promObservationSize.WithLabelValues(r.destChain).Set(float64(sizeBytes))
+}
+
+func (r *PromReporter) TrackMerkleRootsReported(sourceChain cciptypes.ChainSelector, count int) {
+	promMerkleRootsReported.
+		WithLabelValues(r.destChain, strconv.FormatUint(uint64(sourceChain), 10)).
+		Add(float64(count))
+}
+
+func (r *PromReporter) TrackPriceUpdates(tokenPriceUpdates, gasPriceUpdates int) {
+	promPriceUpdates.WithLabelValues(r.destChain, "token").Add(float64(tokenPriceUpdates))
+	promPriceUpdates.WithLabelValues(r.destChain, "gas").Add(float64(gasPriceUpdates))
+}
+
+func (r *PromReporter) TrackMerkleRootStateTransition(from, to string) {
+	promMerkleRootStateTransitions.WithLabelValues(r.destChain, from, to).Inc()
+}
+
+func (r *PromReporter) TrackError(phase Phase, object string) {
+	promErrors.WithLabelValues(r.destChain, string(phase), object).Inc()
+}
+
+func (r *PromReporter) TrackReorgDetected(sourceChain cciptypes.ChainSelector) {
+	promReorgsDetected.WithLabelValues(r.destChain, strconv.FormatUint(uint64(sourceChain), 10)).Inc()
+}
+
+// Interface compatibility checks.
+var _ Reporter = Noop{}
+var _ Reporter = &PromReporter{}
@@ -19,6 +19,7 @@ import (
 	"github.com/goplugin/plugin-ccip/commit/chainfee"
 	"github.com/goplugin/plugin-ccip/commit/merkleroot"
 	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
+	"github.com/goplugin/plugin-ccip/commit/metrics"
 	"github.com/goplugin/plugin-ccip/commit/tokenprice"
 	"github.com/goplugin/plugin-ccip/internal/plugincommon"
 	"github.com/goplugin/plugin-ccip/internal/plugincommon/discovery"
@@ -34,23 +35,35 @@ type MerkleRootObservation = plugincommon.AttributedObservation[merkleroot.Obser
 type TokenPricesObservation = plugincommon.AttributedObservation[tokenprice.Observation]
 type ChainFeeObservation = plugincommon.AttributedObservation[chainfee.Observation]
 
+// Names of the processors as reported in metrics.
+const (
+	processorPlugin     = "plugin"
+	processorMerkleRoot = "merkleroot"
+	processorTokenPrice = "tokenprice"
+	processorChainFee   = "chainfee"
+)
+
 type Plugin struct {
 	donID               plugintypes.DonID
 	oracleID            commontypes.OracleID
 	oracleIDToP2PID     map[commontypes.OracleID]libocrtypes.PeerID
 	offchainCfg         pluginconfig.CommitOffchainConfig
+	destChain           cciptypes.ChainSelector
 	ccipReader          readerpkg.CCIPReader
 	tokenPricesReader   readerpkg.PriceReader
 	reportCodec         cciptypes.CommitPluginCodec
 	lggr                logger.Logger
 	homeChain           reader.HomeChain
 	rmnHomeReader       readerpkg.RMNHome
+	rmnRemoteReader     readerpkg.RMNRemote
+	rmnCrypto           cciptypes.RMNCrypto
 	reportingCfg        ocr3types.ReportingPluginConfig
 	chainSupport        plugincommon.ChainSupport
 	merkleRootProcessor plugincommon.PluginProcessor[merkleroot.Query, merkleroot.Observation, merkleroot.Outcome]
 	tokenPriceProcessor plugincommon.PluginProcessor[tokenprice.Query, tokenprice.Observation, tokenprice.Outcome]
 	chainFeeProcessor   plugincommon.PluginProcessor[chainfee.Query, chainfee.Observation, chainfee.Outcome]
 	discoveryProcessor  *discovery.ContractDiscoveryProcessor
+	metrics             metrics.Reporter
 
 	// state
 	contractsInitialized bool
@@ -69,9 +82,11 @@ func NewPlugin(
 	lggr logger.Logger,
 	homeChain reader.HomeChain,
 	rmnHomeReader readerpkg.RMNHome,
+	rmnRemoteReader readerpkg.RMNRemote,
 	rmnCrypto cciptypes.RMNCrypto,
 	rmnPeerClient rmn.PeerClient,
 	reportingCfg ocr3types.ReportingPluginConfig,
+	metricsReporter metrics.Reporter,
 ) *Plugin {
 	lggr = logger.Named(lggr, "CommitPlugin")
 	lggr = logger.With(lggr, "donID", donID, "oracleID", reportingCfg.OracleID)
@@ -83,6 +98,10 @@ func NewPlugin(
 		offchainCfg.MaxMerkleTreeSize = merklemulti.MaxNumberTreeLeaves
 	}
 
+	if metricsReporter == nil {
+		metricsReporter = metrics.Noop{}
+	}
+
 	chainSupport := plugincommon.NewCCIPChainSupport(
 		lggr,
 		homeChain,
@@ -97,8 +116,9 @@ func NewPlugin(
 		offchainCfg.SignObservationPrefix,
 		rmnPeerClient,
 		rmnHomeReader,
-		2*time.Second, /* observationsInitialRequestTimerDuration */
-		2*time.Second, /* observationsRequestTimerDuration */
+		offchainCfg.RMNObservationsInitialRequestTimer.Duration(),
+		offchainCfg.RMNReportsInitialRequestTimer.Duration(),
+		offchainCfg.RMNAdaptiveTimers,
 	)
 
 	merkleRootProcessor := merkleroot.NewProcessor(
@@ -115,6 +135,8 @@ func NewPlugin(
 		rmnController,
 		rmnCrypto,
 		rmnHomeReader,
+		rmnRemoteReader,
+		metricsReporter,
 	)
 
 	tokenPriceProcessor := tokenprice.NewProcessor(
@@ -126,6 +148,7 @@ func NewPlugin(
 		tokenPricesReader,
 		homeChain,
 		reportingCfg.F,
+		metricsReporter,
 	)
 
 	discoveryProcessor := discovery.NewContractDiscoveryProcessor(
@@ -145,6 +168,7 @@ func NewPlugin(
 		offchainCfg,
 		chainSupport,
 		reportingCfg.F,
+		metricsReporter,
 	)
 
 	return &Plugin{
@@ -153,10 +177,13 @@ func NewPlugin(
 		oracleIDToP2PID:     oracleIDToP2pID,
 		lggr:                lggr,
 		offchainCfg:         offchainCfg,
+		destChain:           destChain,
 		tokenPricesReader:   tokenPricesReader,
 		ccipReader:          ccipReader,
 		homeChain:           homeChain,
 		rmnHomeReader:       rmnHomeReader,
+		rmnRemoteReader:     rmnRemoteReader,
+		rmnCrypto:           rmnCrypto,
 		reportCodec:         reportCodec,
 		reportingCfg:        reportingCfg,
 		chainSupport:        chainSupport,
@@ -164,29 +191,38 @@ func NewPlugin(
 		tokenPriceProcessor: tokenPriceProcessor,
 		chainFeeProcessor:   chainFeeProcessr,
 		discoveryProcessor:  discoveryProcessor,
+		metrics:             metricsReporter,
 	}
 }
 
 func (p *Plugin) Query(ctx context.Context, outCtx ocr3types.OutcomeContext) (types.Query, error) {
+	defer p.trackDuration(processorPlugin, metrics.PhaseQuery, time.Now())
+
 	var err error
 	var q Query
 
 	prevOutcome := p.decodeOutcome(outCtx.PreviousOutcome)
 
+	tStart := time.Now()
 	q.MerkleRootQuery, err = p.merkleRootProcessor.Query(ctx, prevOutcome.MerkleRootOutcome)
 	if err != nil {
 		p.lggr.Errorw("get merkle roots query", "err", err)
 	}
+	p.trackDuration(processorMerkleRoot, metrics.PhaseQuery, tStart)
 
+	tStart = time.Now()
 	q.TokenPriceQuery, err = p.tokenPriceProcessor.Query(ctx, prevOutcome.TokenPriceOutcome)
 	if err != nil {
 		p.lggr.Errorw("get token prices query", "err", err)
 	}
+	p.trackDuration(processorTokenPrice, metrics.PhaseQuery, tStart)
 
+	tStart = time.Now()
 	q.ChainFeeQuery, err = p.chainFeeProcessor.Query(ctx, prevOutcome.ChainFeeOutcome)
 	if err != nil {
 		p.lggr.Errorw("get chain fee query", "err", err)
 	}
+	p.trackDuration(processorChainFee, metrics.PhaseQuery, tStart)
 
 	return q.Encode()
 }
@@ -202,6 +238,8 @@ func (p *Plugin) ObservationQuorum(
 func (p *Plugin) Observation(
 	ctx context.Context, outCtx ocr3types.OutcomeContext, q types.Query,
 ) (types.Observation, error) {
+	defer p.trackDuration(processorPlugin, metrics.PhaseObservation, time.Now())
+
 	prevOutcome := p.decodeOutcome(outCtx.PreviousOutcome)
 	fChain := p.ObserveFChain()
 
@@ -232,18 +270,26 @@ func (p *Plugin) Observation(
 		}
 	}
 
+	tStart := time.Now()
 	merkleRootObs, err := p.merkleRootProcessor.Observation(ctx, prevOutcome.MerkleRootOutcome, decodedQ.MerkleRootQuery)
 	if err != nil {
 		p.lggr.Errorw("failed to get merkle observation", "err", err)
 	}
+	p.trackDuration(processorMerkleRoot, metrics.PhaseObservation, tStart)
+
+	tStart = time.Now()
 	tokenPriceObs, err := p.tokenPriceProcessor.Observation(ctx, prevOutcome.TokenPriceOutcome, decodedQ.TokenPriceQuery)
 	if err != nil {
 		p.lggr.Errorw("failed to get token prices", "err", err)
 	}
+	p.trackDuration(processorTokenPrice, metrics.PhaseObservation, tStart)
+
+	tStart = time.Now()
 	chainFeeObs, err := p.chainFeeProcessor.Observation(ctx, prevOutcome.ChainFeeOutcome, decodedQ.ChainFeeQuery)
 	if err != nil {
 		p.lggr.Errorw("failed to get gas prices", "err", err)
 	}
+	p.trackDuration(processorChainFee, metrics.PhaseObservation, tStart)
 
 	obs := Observation{
 		MerkleRootObs: merkleRootObs,
@@ -256,6 +302,7 @@ func (p *Plugin) Observation(
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode observation: %w, observation: %+v", err, obs)
 	}
+	p.metrics.TrackObservationSize(len(encoded))
 
 	p.lggr.Debugw("Commit plugin making observation",
 		"encodedObservation", encoded, "observation", obs)
@@ -265,7 +312,7 @@ func (p *Plugin) Observation(
 func (p *Plugin) ObserveFChain() map[cciptypes.ChainSelector]int {
 	fChain, err := p.homeChain.GetFChain()
 	if err != nil {
-		// TODO: metrics
+		p.metrics.TrackError(metrics.PhaseObservation, "fChain")
 		p.lggr.Errorw("call to GetFChain failed", "err", err)
 		return map[cciptypes.ChainSelector]int{}
 	}
@@ -279,6 +326,8 @@ func (p *Plugin) ObserveFChain() map[cciptypes.ChainSelector]int {
 func (p *Plugin) Outcome(
 	ctx context.Context, outCtx ocr3types.OutcomeContext, q types.Query, aos []types.AttributedObservation,
 ) (ocr3types.Outcome, error) {
+	defer p.trackDuration(processorPlugin, metrics.PhaseOutcome, time.Now())
+
 	p.lggr.Debugw("Commit plugin performing outcome",
 		"outctx", outCtx,
 		"query", q,
@@ -340,6 +389,7 @@ func (p *Plugin) Outcome(
 		p.contractsInitialized = true
 	}
 
+	tStart := time.Now()
 	merkleRootOutcome, err := p.merkleRootProcessor.Outcome(
 		ctx,
 		prevOutcome.MerkleRootOutcome,
@@ -349,7 +399,9 @@ func (p *Plugin) Outcome(
 	if err != nil {
 		p.lggr.Errorw("failed to get merkle outcome", "err", err)
 	}
+	p.trackDuration(processorMerkleRoot, metrics.PhaseOutcome, tStart)
 
+	tStart = time.Now()
 	tokenPriceOutcome, err := p.tokenPriceProcessor.Outcome(
 		ctx,
 		prevOutcome.TokenPriceOutcome,
@@ -359,7 +411,9 @@ func (p *Plugin) Outcome(
 	if err != nil {
 		p.lggr.Warnw("failed to get token prices outcome", "err", err)
 	}
+	p.trackDuration(processorTokenPrice, metrics.PhaseOutcome, tStart)
 
+	tStart = time.Now()
 	chainFeeOutcome, err := p.chainFeeProcessor.Outcome(
 		ctx,
 		prevOutcome.ChainFeeOutcome,
@@ -369,12 +423,29 @@ func (p *Plugin) Outcome(
 	if err != nil {
 		p.lggr.Warnw("failed to get gas prices outcome", "err", err)
 	}
+	p.trackDuration(processorChainFee, metrics.PhaseOutcome, tStart)
 
-	return Outcome{
+	outcome := Outcome{
 		MerkleRootOutcome: merkleRootOutcome,
 		TokenPriceOutcome: tokenPriceOutcome,
 		ChainFeeOutcome:   chainFeeOutcome,
-	}.Encode()
+	}
+	outcome.PriceOnlyReport = p.priceOnlyReport(prevOutcome.PriceOnlyReport, outcome)
+
+	return outcome.Encode()
+}
+
+// Start starts the background services of the processors. It is called once by the factory when the plugin is
+// created, the services are stopped by Close.
+func (p *Plugin) Start(ctx context.Context) error {
+	for _, processor := range []any{p.merkleRootProcessor, p.tokenPriceProcessor, p.chainFeeProcessor} {
+		if starter, ok := processor.(interface{ Start(context.Context) error }); ok {
+			if err := starter.Start(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func (p *Plugin) Close() error {
@@ -386,6 +457,17 @@ func (p *Plugin) Close() error {
 	}...)
 }
 
+// HealthReport collects the health reports of the processors which report their health.
+func (p *Plugin) HealthReport() map[string]error {
+	report := make(map[string]error)
+	for _, processor := range []any{p.merkleRootProcessor, p.tokenPriceProcessor, p.chainFeeProcessor} {
+		if reporter, ok := processor.(interface{ HealthReport() map[string]error }); ok {
+			services.CopyHealth(report, reporter.HealthReport())
+		}
+	}
+	return report
+}
+
 func (p *Plugin) decodeOutcome(outcome ocr3types.Outcome) Outcome {
 	if len(outcome) == 0 {
 		return Outcome{}
@@ -400,5 +482,9 @@ func (p *Plugin) decodeOutcome(outcome ocr3types.Outcome) Outcome {
 	return decodedOutcome
 }
 
+func (p *Plugin) trackDuration(processor string, phase metrics.Phase, tStart time.Time) {
+	p.metrics.TrackPhaseDuration(processor, phase, time.Since(tStart))
+}
+
 // Interface compatibility checks.
 var _ ocr3types.ReportingPlugin[[]byte] = &Plugin{}
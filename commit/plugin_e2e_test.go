@@ -13,6 +13,7 @@ import (
 
 	mapset "github.com/deckarep/golang-set/v2"
 
+	chainsel "github.com/goplugin/chain-selectors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -29,15 +30,19 @@ import (
 
 	"github.com/goplugin/plugin-ccip/chainconfig"
 	"github.com/goplugin/plugin-ccip/commit/merkleroot"
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/rmnpb"
 	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
 	"github.com/goplugin/plugin-ccip/commit/tokenprice"
 	"github.com/goplugin/plugin-ccip/internal/libs/testhelpers"
+	"github.com/goplugin/plugin-ccip/internal/libs/testhelpers/fakermn"
 	"github.com/goplugin/plugin-ccip/internal/mocks"
 	"github.com/goplugin/plugin-ccip/internal/plugintypes"
 	"github.com/goplugin/plugin-ccip/internal/reader"
 	reader_mock "github.com/goplugin/plugin-ccip/mocks/internal_/reader"
 	readerpkg_mock "github.com/goplugin/plugin-ccip/mocks/pkg/reader"
 	"github.com/goplugin/plugin-ccip/pkg/consts"
+	readerpkg "github.com/goplugin/plugin-ccip/pkg/reader"
 	"github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 	"github.com/goplugin/plugin-ccip/pluginconfig"
 )
@@ -255,7 +260,7 @@ func TestPlugin_E2E_AllNodesAgree_MerkleRoots(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, normalizeOutcome(tc.expOutcome), normalizeOutcome(decodedOutcome))
 
-			assert.Len(t, res.Transmitted, len(tc.expTransmittedReports))
+			require.Len(t, res.Transmitted, len(tc.expTransmittedReports))
 			for i := range res.Transmitted {
 				decoded, err := reportCodec.Decode(params.ctx, res.Transmitted[i].Report)
 				assert.NoError(t, err)
@@ -265,6 +270,168 @@ func TestPlugin_E2E_AllNodesAgree_MerkleRoots(t *testing.T) {
 	}
 }
 
+func TestPlugin_E2E_RMN(t *testing.T) {
+	rmnDestChain := ccipocr3.ChainSelector(chainsel.TEST_90000004.Selector)
+
+	testCases := []struct {
+		name           string
+		rmnBehaviors   map[rmntypes.NodeID]fakermn.Behavior
+		expOutcomeType merkleroot.OutcomeType
+		expRetry       bool
+	}{
+		{
+			name:           "all rmn nodes are honest",
+			expOutcomeType: merkleroot.ReportGenerated,
+		},
+		{
+			name: "slow, silent and lying rmn nodes",
+			rmnBehaviors: map[rmntypes.NodeID]fakermn.Behavior{
+				0: {Delay: time.Second},
+				1: {Silent: true},
+				2: {LieAboutRoots: true},
+			},
+			expOutcomeType: merkleroot.ReportGenerated,
+		},
+		{
+			name: "not enough honest rmn nodes, signatures are retried",
+			rmnBehaviors: map[rmntypes.NodeID]fakermn.Behavior{
+				0: {Silent: true},
+				1: {InvalidSignatures: true},
+				2: {LieAboutRoots: true},
+			},
+			expRetry: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			params := defaultNodeParams(t)
+			params.destChain = rmnDestChain
+			params.chainCfg[rmnDestChain] = params.chainCfg[destChain]
+			delete(params.chainCfg, destChain)
+
+			rmnNetwork, err := fakermn.NewNetwork(fakermn.Config{
+				NumNodes:              4,
+				SourceChains:          []ccipocr3.ChainSelector{sourceChain1, sourceChain2},
+				HomeConfigDigest:      ccipocr3.Bytes32{1},
+				ReportVersion:         ccipocr3.Bytes32{2},
+				SignObservationPrefix: "plugin ccip 1.6 rmn observation",
+				Roots: func(laneSource *rmnpb.LaneSource, interval *rmnpb.ClosedInterval) (ccipocr3.Bytes32, bool) {
+					if ccipocr3.ChainSelector(laneSource.SourceChainSelector) == sourceChain1 &&
+						interval.MinMsgNr == 10 && interval.MaxMsgNr == 10 {
+						return merkleRoot1, true
+					}
+					return ccipocr3.Bytes32{}, false
+				},
+			})
+			require.NoError(t, err)
+			for nodeID, behavior := range tc.rmnBehaviors {
+				rmnNetwork.Node(nodeID).SetBehavior(behavior)
+			}
+
+			params.rmnNetwork = rmnNetwork
+			params.rmnMinObservers = map[ccipocr3.ChainSelector]int{sourceChain1: 2, sourceChain2: 2}
+			params.rmnReportCfg = rmnNetwork.RemoteConfig(ccipocr3.Bytes{3}, 2)
+			params.offchainCfg.RMNEnabled = true
+			params.offchainCfg.RMNSignaturesTimeout = 3 * time.Second
+			params.offchainCfg.SignObservationPrefix = "plugin ccip 1.6 rmn observation"
+			params.offchainCfg.RMNObservationsInitialRequestTimer = *commonconfig.MustNewDuration(200 * time.Millisecond)
+			params.offchainCfg.RMNReportsInitialRequestTimer = *commonconfig.MustNewDuration(200 * time.Millisecond)
+
+			nodes := make([]ocr3types.ReportingPlugin[[]byte], len(oracleIDs))
+			var reportCodec ccipocr3.CommitPluginCodec
+			for i := range oracleIDs {
+				n := setupNode(params, oracleIDs[i])
+				nodes[i] = n.node
+				if i == 0 {
+					reportCodec = n.reportCodec
+				}
+				prepareCcipReaderMock(params.ctx, n.ccipReader, false, false)
+				n.priceReader.EXPECT().
+					GetFeeQuoterTokenUpdates(params.ctx, mock.Anything, mock.Anything).
+					Return(map[ocr2types.Account]plugintypes.TimestampedBig{}, nil).
+					Maybe()
+				n.priceReader.EXPECT().
+					GetFeedPricesUSD(mock.Anything, mock.Anything).
+					Return([]*big.Int{}, nil).
+					Maybe()
+			}
+
+			prevOutcome := Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{
+					OutcomeType: merkleroot.ReportIntervalsSelected,
+					RangesSelectedForReport: []plugintypes.ChainRange{
+						{ChainSel: sourceChain1, SeqNumRange: ccipocr3.SeqNumRange{10, 10}},
+					},
+					OffRampNextSeqNums: []plugintypes.SeqNumChain{
+						{ChainSel: sourceChain1, SeqNum: 10},
+						{ChainSel: sourceChain2, SeqNum: 20},
+					},
+					RMNRemoteCfg: params.rmnReportCfg,
+				},
+			}
+			encodedPrevOutcome, err := prevOutcome.Encode()
+			require.NoError(t, err)
+
+			runner := testhelpers.NewOCR3Runner(nodes, oracleIDs, encodedPrevOutcome)
+			res, err := runner.RunRound(params.ctx)
+			require.NoError(t, err)
+
+			decodedOutcome, err := DecodeOutcome(res.Outcome)
+			require.NoError(t, err)
+
+			if tc.expRetry {
+				assert.NotEqual(t, merkleroot.ReportGenerated, decodedOutcome.MerkleRootOutcome.OutcomeType)
+				assert.Empty(t, decodedOutcome.MerkleRootOutcome.RMNReportSignatures)
+				assert.Empty(t, res.Transmitted)
+				return
+			}
+
+			assert.Equal(t, tc.expOutcomeType, decodedOutcome.MerkleRootOutcome.OutcomeType)
+			assert.Equal(t, []ccipocr3.MerkleRootChain{
+				{
+					ChainSel:      sourceChain1,
+					OnRampAddress: ccipocr3.Bytes{},
+					SeqNumsRange:  ccipocr3.NewSeqNumRange(10, 10),
+					MerkleRoot:    merkleRoot1,
+				},
+			}, decodedOutcome.MerkleRootOutcome.RootsToReport)
+
+			require.Len(t, res.Transmitted, 1)
+			report, err := reportCodec.Decode(params.ctx, res.Transmitted[0].Report)
+			require.NoError(t, err)
+			require.Len(t, report.RMNSignatures, int(params.rmnReportCfg.MinSigners))
+
+			chainInfo, ok := chainsel.ChainBySelector(uint64(rmnDestChain))
+			require.True(t, ok)
+			signers := make([]ccipocr3.Bytes, 0, len(params.rmnReportCfg.Signers))
+			for _, signer := range params.rmnReportCfg.Signers {
+				signers = append(signers, signer.OnchainPublicKey)
+			}
+			rmnReport := ccipocr3.RMNReport{
+				ReportVersionDigest:         params.rmnReportCfg.RmnReportVersion,
+				DestChainID:                 ccipocr3.NewBigIntFromInt64(int64(chainInfo.EvmChainID)),
+				DestChainSelector:           rmnDestChain,
+				RmnRemoteContractAddress:    params.rmnReportCfg.ContractAddress,
+				OfframpAddress:              ccipocr3.Bytes{},
+				RmnHomeContractConfigDigest: params.rmnReportCfg.ConfigDigest,
+				LaneUpdates: []ccipocr3.RMNLaneUpdate{
+					{
+						SourceChainSelector: sourceChain1,
+						OnRampAddress:       ccipocr3.Bytes{},
+						MinSeqNr:            10,
+						MaxSeqNr:            10,
+						MerkleRoot:          merkleRoot1,
+					},
+				},
+			}
+			assert.NoError(t, rmnNetwork.Crypto().VerifyReportSignatures(
+				params.ctx, report.RMNSignatures, rmnReport, signers))
+		})
+	}
+}
+
 func TestPlugin_E2E_AllNodesAgree_TokenPrices(t *testing.T) {
 	params := defaultNodeParams(t)
 
@@ -314,6 +481,17 @@ func TestPlugin_E2E_AllNodesAgree_TokenPrices(t *testing.T) {
 				TokenPriceOutcome: tokenprice.Outcome{
 					TokenPrices: orderedTokenPrices,
 				},
+				PriceOnlyReport: &PriceOnlyReport{
+					PriceUpdates: ccipocr3.PriceUpdates{TokenPriceUpdates: orderedTokenPrices},
+				},
+			},
+			// No merkle roots are pending, so the price updates are reported on their own.
+			expTransmittedReports: []ccipocr3.CommitPluginReport{
+				{
+					PriceUpdates: ccipocr3.PriceUpdates{
+						TokenPriceUpdates: orderedTokenPrices,
+					},
+				},
 			},
 		},
 		{
@@ -375,6 +553,18 @@ func TestPlugin_E2E_AllNodesAgree_TokenPrices(t *testing.T) {
 						tokenPriceMap[ethAddr],
 					},
 				},
+				PriceOnlyReport: &PriceOnlyReport{
+					PriceUpdates: ccipocr3.PriceUpdates{
+						TokenPriceUpdates: []ccipocr3.TokenPrice{tokenPriceMap[ethAddr]},
+					},
+				},
+			},
+			expTransmittedReports: []ccipocr3.CommitPluginReport{
+				{
+					PriceUpdates: ccipocr3.PriceUpdates{
+						TokenPriceUpdates: []ccipocr3.TokenPrice{tokenPriceMap[ethAddr]},
+					},
+				},
 			},
 		},
 	}
@@ -480,6 +670,10 @@ type SetupNodeParams struct {
 	onRampLastSeqNum  map[ccipocr3.ChainSelector]ccipocr3.SeqNum
 	rmnReportCfg      rmntypes.RemoteConfig
 	enableDiscovery   bool
+	destChain         ccipocr3.ChainSelector
+	// rmnNetwork is the fake RMN network the nodes talk to when RMN is enabled.
+	rmnNetwork      *fakermn.Network
+	rmnMinObservers map[ccipocr3.ChainSelector]int
 }
 
 //nolint:gocyclo // todo
@@ -566,19 +760,38 @@ func setupNode(params SetupNodeParams, nodeID commontypes.OracleID) nodeSetup {
 
 	for _, ch := range sourceChains {
 		ccipReader.EXPECT().GetExpectedNextSequenceNumber(
-			params.ctx, ch, destChain).Return(params.offRampNextSeqNum[ch]+1, nil).Maybe()
+			params.ctx, ch, params.destChain).Return(params.offRampNextSeqNum[ch]+1, nil).Maybe()
 	}
 
+	rmnRemoteReader := readerpkg_mock.NewMockRMNRemote(params.t)
+	rmnRemoteReader.EXPECT().GetRemoteConfig().Return(params.rmnReportCfg, nil).Maybe()
+
 	ccipReader.EXPECT().
-		GetRMNRemoteConfig(params.ctx, mock.Anything).
-		Return(params.rmnReportCfg, nil).Maybe()
+		GetRmnCurseInfo(mock.Anything, params.destChain, mock.Anything).
+		Return(readerpkg.CurseInfo{}, nil).Maybe()
+
+	var rmnCrypto ccipocr3.RMNCrypto
+	var rmnPeerClient rmn.PeerClient
+	if params.rmnNetwork != nil {
+		rmnCrypto = params.rmnNetwork.Crypto()
+		rmnPeerClient = params.rmnNetwork.NewPeerClient()
+		rmnHomeReader.EXPECT().
+			GetAllConfigDigests().
+			Return(params.rmnReportCfg.ConfigDigest, ccipocr3.Bytes32{}).Maybe()
+		rmnHomeReader.EXPECT().
+			GetRMNNodesInfo(params.rmnReportCfg.ConfigDigest).
+			Return(params.rmnNetwork.HomeNodes(), nil).Maybe()
+		rmnHomeReader.EXPECT().
+			GetMinObservers(params.rmnReportCfg.ConfigDigest).
+			Return(params.rmnMinObservers, nil).Maybe()
+	}
 
 	p := NewPlugin(
 		params.donID,
 		nodeID,
 		params.oracleIDToP2pID,
 		params.offchainCfg,
-		destChain,
+		params.destChain,
 		ccipReader,
 		tokenPricesReader,
 		reportCodec,
@@ -586,9 +799,11 @@ func setupNode(params SetupNodeParams, nodeID commontypes.OracleID) nodeSetup {
 		params.lggr,
 		homeChainReader,
 		rmnHomeReader,
-		nil,
-		nil,
+		rmnRemoteReader,
+		rmnCrypto,
+		rmnPeerClient,
 		params.reportingCfg,
+		nil,
 	)
 
 	if !params.enableDiscovery {
@@ -665,6 +880,7 @@ func defaultNodeParams(t *testing.T) SetupNodeParams {
 		onRampLastSeqNum:  onRampLastSeqNum,
 		rmnReportCfg:      rmnRemoteCfg,
 		enableDiscovery:   false,
+		destChain:         destChain,
 	}
 
 	return params
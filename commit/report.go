@@ -4,11 +4,16 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/goplugin/plugin-libocr/offchainreporting2plus/ocr3types"
+	"github.com/goplugin/plugin-libocr/offchainreporting2plus/types"
 
 	"github.com/goplugin/plugin-ccip/commit/merkleroot"
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
+	"github.com/goplugin/plugin-ccip/commit/metrics"
 	"github.com/goplugin/plugin-ccip/pkg/consts"
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 )
@@ -32,33 +37,45 @@ func (ri *ReportInfo) Decode(encodedReportInfo []byte) error {
 func (p *Plugin) Reports(
 	ctx context.Context, seqNr uint64, outcomeBytes ocr3types.Outcome,
 ) ([]ocr3types.ReportPlus[[]byte], error) {
+	defer p.trackDuration(processorPlugin, metrics.PhaseReports, time.Now())
+
 	outcome, err := DecodeOutcome(outcomeBytes)
 	if err != nil {
-		// TODO: metrics
+		p.metrics.TrackError(metrics.PhaseReports, "outcome")
 		p.lggr.Errorw("failed to decode Outcome", "outcomeBytes", outcomeBytes, "err", err)
 		return nil, fmt.Errorf("failed to decode Outcome (%s): %w", hex.EncodeToString(outcomeBytes), err)
 	}
 
-	// Until we start adding tokens and gas to the report, we don't need to report anything
-	if outcome.MerkleRootOutcome.OutcomeType != merkleroot.ReportGenerated {
-		return []ocr3types.ReportPlus[[]byte]{}, nil
-	}
-	p.lggr.Infow("generating report",
-		"roots", outcome.MerkleRootOutcome.RootsToReport,
-		"tokenPriceUpdates", outcome.TokenPriceOutcome.TokenPrices,
-		"gasPriceUpdates", outcome.ChainFeeOutcome.GasPrices,
-		"rmnSignatures", outcome.MerkleRootOutcome.RMNReportSignatures,
-		"rmnRawVs", outcome.MerkleRootOutcome.RMNRawVs,
-	)
+	priceUpdates := outcome.priceUpdates()
 
-	rep := cciptypes.CommitPluginReport{
-		MerkleRoots: outcome.MerkleRootOutcome.RootsToReport,
-		PriceUpdates: cciptypes.PriceUpdates{
-			TokenPriceUpdates: outcome.TokenPriceOutcome.TokenPrices,
-			GasPriceUpdates:   outcome.ChainFeeOutcome.GasPrices,
-		},
-		RMNSignatures: outcome.MerkleRootOutcome.RMNReportSignatures,
-		RMNRawVs:      outcome.MerkleRootOutcome.RMNRawVs,
+	var rep cciptypes.CommitPluginReport
+	switch {
+	case outcome.MerkleRootOutcome.OutcomeType == merkleroot.ReportGenerated:
+		p.lggr.Infow("generating report",
+			"roots", outcome.MerkleRootOutcome.RootsToReport,
+			"tokenPriceUpdates", outcome.TokenPriceOutcome.TokenPrices,
+			"gasPriceUpdates", outcome.ChainFeeOutcome.GasPrices,
+			"rmnSignatures", outcome.MerkleRootOutcome.RMNReportSignatures,
+			"rmnRawVs", outcome.MerkleRootOutcome.RMNRawVs,
+		)
+		rep = cciptypes.CommitPluginReport{
+			MerkleRoots:   outcome.MerkleRootOutcome.RootsToReport,
+			PriceUpdates:  priceUpdates,
+			RMNSignatures: outcome.MerkleRootOutcome.RMNReportSignatures,
+			RMNRawVs:      outcome.MerkleRootOutcome.RMNRawVs,
+		}
+	case outcome.PriceOnlyReport != nil && outcome.PriceOnlyReport.TransmissionCheckAttempts == 0:
+		// The token and gas prices only contain updates which are required by the deviation or heartbeat
+		// thresholds, report them even though there are no merkle roots to report.
+		p.lggr.Infow("generating price-only report",
+			"tokenPriceUpdates", outcome.PriceOnlyReport.PriceUpdates.TokenPriceUpdates,
+			"gasPriceUpdates", outcome.PriceOnlyReport.PriceUpdates.GasPriceUpdates,
+		)
+		rep = cciptypes.CommitPluginReport{
+			PriceUpdates: outcome.PriceOnlyReport.PriceUpdates,
+		}
+	default:
+		return []ocr3types.ReportPlus[[]byte]{}, nil
 	}
 
 	encodedReport, err := p.reportCodec.Encode(ctx, rep)
@@ -77,12 +94,92 @@ func (p *Plugin) Reports(
 		return nil, fmt.Errorf("encode report info: %w", err)
 	}
 
+	for _, root := range rep.MerkleRoots {
+		p.metrics.TrackMerkleRootsReported(root.ChainSel, 1)
+	}
+	p.metrics.TrackPriceUpdates(len(rep.PriceUpdates.TokenPriceUpdates), len(rep.PriceUpdates.GasPriceUpdates))
+
 	return []ocr3types.ReportPlus[[]byte]{
 		{ReportWithInfo: ocr3types.ReportWithInfo[[]byte]{
 			Report: encodedReport, Info: infoBytes}},
 	}, nil
 }
 
+func hasPriceUpdates(priceUpdates cciptypes.PriceUpdates) bool {
+	return len(priceUpdates.TokenPriceUpdates) > 0 || len(priceUpdates.GasPriceUpdates) > 0
+}
+
+// merkleRootsPending returns true if the merkle root processor is about to build or is waiting for the transmission
+// of a report with merkle roots. Price updates are included in that report, so no separate price-only report should
+// be generated.
+func merkleRootsPending(merkleRootOutcome merkleroot.Outcome) bool {
+	switch merkleRootOutcome.OutcomeType {
+	case merkleroot.ReportIntervalsSelected:
+		return len(merkleRootOutcome.RangesSelectedForReport) > 0
+	case merkleroot.ReportGenerated, merkleroot.ReportInFlight:
+		return true
+	default:
+		return false
+	}
+}
+
+// priceOnlyReport returns the price-only report to track in the outcome. A new price-only report is generated when
+// there are price updates but no merkle roots are pending. Like reports with merkle roots, a generated report is
+// then checked for transmission in the following rounds, and no new price-only report is generated until the
+// FeeQuoter reflects its price updates or MaxReportTransmissionCheckAttempts rounds have passed.
+func (p *Plugin) priceOnlyReport(prev *PriceOnlyReport, outcome Outcome) *PriceOnlyReport {
+	if prev != nil {
+		if priceUpdatesTransmitted(prev.PriceUpdates, outcome.priceUpdates()) {
+			return nil
+		}
+
+		if prev.TransmissionCheckAttempts+1 >= p.offchainCfg.MaxReportTransmissionCheckAttempts {
+			p.lggr.Warnw("Failed to detect price-only report transmission", "priceUpdates", prev.PriceUpdates)
+			return nil
+		}
+
+		return &PriceOnlyReport{
+			PriceUpdates:              prev.PriceUpdates,
+			TransmissionCheckAttempts: prev.TransmissionCheckAttempts + 1,
+		}
+	}
+
+	priceUpdates := outcome.priceUpdates()
+	if outcome.MerkleRootOutcome.OutcomeType == merkleroot.ReportGenerated ||
+		!hasPriceUpdates(priceUpdates) ||
+		merkleRootsPending(outcome.MerkleRootOutcome) {
+		return nil
+	}
+
+	return &PriceOnlyReport{PriceUpdates: priceUpdates}
+}
+
+// priceUpdatesTransmitted returns true if none of the tokens and chains of the reported price updates still require
+// a price update, i.e. the FeeQuoter reflects the reported prices.
+func priceUpdatesTransmitted(reported, required cciptypes.PriceUpdates) bool {
+	requiredTokens := make(map[types.Account]struct{}, len(required.TokenPriceUpdates))
+	for _, tokenPrice := range required.TokenPriceUpdates {
+		requiredTokens[tokenPrice.TokenID] = struct{}{}
+	}
+	for _, tokenPrice := range reported.TokenPriceUpdates {
+		if _, ok := requiredTokens[tokenPrice.TokenID]; ok {
+			return false
+		}
+	}
+
+	requiredChains := make(map[cciptypes.ChainSelector]struct{}, len(required.GasPriceUpdates))
+	for _, gasPrice := range required.GasPriceUpdates {
+		requiredChains[gasPrice.ChainSel] = struct{}{}
+	}
+	for _, gasPrice := range reported.GasPriceUpdates {
+		if _, ok := requiredChains[gasPrice.ChainSel]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (p *Plugin) ShouldAcceptAttestedReport(
 	ctx context.Context, u uint64, r ocr3types.ReportWithInfo[[]byte],
 ) (bool, error) {
@@ -102,6 +199,7 @@ func (p *Plugin) ShouldAcceptAttestedReport(
 		return false, fmt.Errorf("decode report info: %w", err)
 	}
 
+	// Price-only reports are not blessed by RMN and are accepted without RMN signatures.
 	if p.offchainCfg.RMNEnabled &&
 		len(decodedReport.MerkleRoots) > 0 &&
 		len(decodedReport.RMNSignatures) < int(reportInfo.MinSigners) {
@@ -150,6 +248,18 @@ func (p *Plugin) ShouldTransmitAcceptedReport(
 		return false, fmt.Errorf("validate merkle roots state: %w", err)
 	}
 
+	// Price-only reports are not blessed by RMN, reports with merkle roots are only accepted by the destination
+	// chain if they carry enough valid RMN signatures.
+	if p.offchainCfg.RMNEnabled && len(decodedReport.MerkleRoots) > 0 {
+		valid, err := p.verifyRMNSignatures(ctx, decodedReport)
+		if err != nil {
+			return false, fmt.Errorf("verify RMN signatures: %w", err)
+		}
+		if !valid {
+			return false, nil
+		}
+	}
+
 	p.lggr.Infow("transmitting report",
 		"roots", len(decodedReport.MerkleRoots),
 		"tokenPriceUpdates", len(decodedReport.PriceUpdates.TokenPriceUpdates),
@@ -158,6 +268,57 @@ func (p *Plugin) ShouldTransmitAcceptedReport(
 	return true, nil
 }
 
+// verifyRMNSignatures verifies the RMN signatures of the report against the signers of the RMNRemote config
+// that is currently known by this oracle, the same way the destination chain would. It returns false if the
+// signatures are not valid and an error if they could not be verified.
+func (p *Plugin) verifyRMNSignatures(ctx context.Context, rep cciptypes.CommitPluginReport) (bool, error) {
+	if p.rmnRemoteReader == nil {
+		return false, errors.New("RMNRemote reader not set")
+	}
+	rmnRemoteCfg, err := p.rmnRemoteReader.GetRemoteConfig()
+	if err != nil {
+		return false, fmt.Errorf("get RMNRemote config: %w", err)
+	}
+
+	if uint64(len(rep.RMNSignatures)) < rmnRemoteCfg.MinSigners {
+		p.lggr.Errorw("skipping report with insufficient RMN signatures",
+			"signatures", len(rep.RMNSignatures), "minSigners", rmnRemoteCfg.MinSigners)
+		return false, nil
+	}
+
+	offRampAddress, err := p.ccipReader.GetContractAddress(consts.ContractNameOffRamp, p.destChain)
+	if err != nil {
+		return false, fmt.Errorf("get offramp contract address: %w", err)
+	}
+
+	signerAddresses := make([]cciptypes.Bytes, 0, len(rmnRemoteCfg.Signers))
+	for _, signer := range rmnRemoteCfg.Signers {
+		signerAddresses = append(signerAddresses, signer.OnchainPublicKey)
+	}
+
+	laneUpdates := make([]cciptypes.RMNLaneUpdate, 0, len(rep.MerkleRoots))
+	for _, root := range rep.MerkleRoots {
+		laneUpdates = append(laneUpdates, cciptypes.RMNLaneUpdate{
+			SourceChainSelector: root.ChainSel,
+			OnRampAddress:       root.OnRampAddress,
+			MinSeqNr:            root.SeqNumsRange.Start(),
+			MaxSeqNr:            root.SeqNumsRange.End(),
+			MerkleRoot:          root.MerkleRoot,
+		})
+	}
+
+	rmnReport, err := rmn.NewRMNReport(p.destChain, offRampAddress, rmnRemoteCfg, laneUpdates)
+	if err != nil {
+		return false, fmt.Errorf("build RMN report: %w", err)
+	}
+
+	if err := p.rmnCrypto.VerifyReportSignatures(ctx, rep.RMNSignatures, rmnReport, signerAddresses); err != nil {
+		p.lggr.Errorw("skipping report with invalid RMN signatures", "err", err)
+		return false, nil
+	}
+	return true, nil
+}
+
 func (p *Plugin) isCandidateInstance(ctx context.Context) (bool, error) {
 	ocrConfigs, err := p.homeChain.GetOCRConfigs(ctx, p.donID, consts.PluginTypeCommit)
 	if err != nil {
@@ -0,0 +1,400 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	chainsel "github.com/goplugin/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-libocr/offchainreporting2plus/ocr3types"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/utils/tests"
+
+	"github.com/goplugin/plugin-ccip/commit/chainfee"
+	"github.com/goplugin/plugin-ccip/commit/merkleroot"
+	"github.com/goplugin/plugin-ccip/commit/metrics"
+	"github.com/goplugin/plugin-ccip/commit/tokenprice"
+	"github.com/goplugin/plugin-ccip/internal/libs/testhelpers"
+	"github.com/goplugin/plugin-ccip/internal/mocks"
+	"github.com/goplugin/plugin-ccip/internal/plugintypes"
+	"github.com/goplugin/plugin-ccip/internal/reader"
+	plugincommon_mock "github.com/goplugin/plugin-ccip/mocks/internal_/plugincommon"
+	reader_mock "github.com/goplugin/plugin-ccip/mocks/internal_/reader"
+	readerpkg_mock "github.com/goplugin/plugin-ccip/mocks/pkg/reader"
+	"github.com/goplugin/plugin-ccip/pkg/consts"
+	readerpkg "github.com/goplugin/plugin-ccip/pkg/reader"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+	"github.com/goplugin/plugin-ccip/pluginconfig"
+)
+
+func TestPlugin_Reports_PriceOnly(t *testing.T) {
+	tokenPrices := []cciptypes.TokenPrice{cciptypes.NewTokenPrice("0x1", big.NewInt(100))}
+	gasPrices := []cciptypes.GasPriceChain{cciptypes.NewGasPriceChain(big.NewInt(10), 2)}
+	root := cciptypes.MerkleRootChain{
+		ChainSel:      2,
+		OnRampAddress: cciptypes.Bytes{1},
+		SeqNumsRange:  cciptypes.NewSeqNumRange(1, 10),
+	}
+
+	testCases := []struct {
+		name      string
+		outcome   Outcome
+		expReport *cciptypes.CommitPluginReport
+	}{
+		{
+			name: "report with roots includes prices",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{
+					OutcomeType:   merkleroot.ReportGenerated,
+					RootsToReport: []cciptypes.MerkleRootChain{root},
+				},
+				TokenPriceOutcome: tokenprice.Outcome{TokenPrices: tokenPrices},
+			},
+			expReport: &cciptypes.CommitPluginReport{
+				MerkleRoots:  []cciptypes.MerkleRootChain{root},
+				PriceUpdates: cciptypes.PriceUpdates{TokenPriceUpdates: tokenPrices},
+			},
+		},
+		{
+			name: "price-only report generated in this round",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportEmpty},
+				TokenPriceOutcome: tokenprice.Outcome{TokenPrices: tokenPrices},
+				ChainFeeOutcome:   chainfee.Outcome{GasPrices: gasPrices},
+				PriceOnlyReport: &PriceOnlyReport{
+					PriceUpdates: cciptypes.PriceUpdates{
+						TokenPriceUpdates: tokenPrices,
+						GasPriceUpdates:   gasPrices,
+					},
+				},
+			},
+			expReport: &cciptypes.CommitPluginReport{
+				PriceUpdates: cciptypes.PriceUpdates{
+					TokenPriceUpdates: tokenPrices,
+					GasPriceUpdates:   gasPrices,
+				},
+			},
+		},
+		{
+			name: "no report while a price-only report is waiting for transmission",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportEmpty},
+				TokenPriceOutcome: tokenprice.Outcome{TokenPrices: tokenPrices},
+				PriceOnlyReport: &PriceOnlyReport{
+					PriceUpdates:              cciptypes.PriceUpdates{TokenPriceUpdates: tokenPrices},
+					TransmissionCheckAttempts: 1,
+				},
+			},
+		},
+		{
+			name: "no report without a price-only report",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportEmpty},
+				TokenPriceOutcome: tokenprice.Outcome{TokenPrices: tokenPrices},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			codec := mocks.NewCommitPluginJSONReportCodec()
+			p := &Plugin{
+				lggr:        logger.Test(t),
+				reportCodec: codec,
+				metrics:     metrics.Noop{},
+			}
+
+			encodedOutcome, err := tc.outcome.Encode()
+			require.NoError(t, err)
+			reports, err := p.Reports(ctx, 1, encodedOutcome)
+			require.NoError(t, err)
+
+			if tc.expReport == nil {
+				require.Empty(t, reports)
+				return
+			}
+			require.Len(t, reports, 1)
+			decoded, err := codec.Decode(ctx, reports[0].ReportWithInfo.Report)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expReport.MerkleRoots, decoded.MerkleRoots)
+			assert.Equal(t, tc.expReport.PriceUpdates, decoded.PriceUpdates)
+		})
+	}
+}
+
+func TestPlugin_priceOnlyReport(t *testing.T) {
+	tokenPrices := []cciptypes.TokenPrice{cciptypes.NewTokenPrice("0x1", big.NewInt(100))}
+	gasPrices := []cciptypes.GasPriceChain{cciptypes.NewGasPriceChain(big.NewInt(10), 2)}
+	otherGasPrices := []cciptypes.GasPriceChain{cciptypes.NewGasPriceChain(big.NewInt(10), 3)}
+	pending := &PriceOnlyReport{PriceUpdates: cciptypes.PriceUpdates{
+		TokenPriceUpdates: tokenPrices,
+		GasPriceUpdates:   gasPrices,
+	}}
+
+	testCases := []struct {
+		name    string
+		prev    *PriceOnlyReport
+		outcome Outcome
+		exp     *PriceOnlyReport
+	}{
+		{
+			name: "generated when no roots are pending",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportEmpty},
+				TokenPriceOutcome: tokenprice.Outcome{TokenPrices: tokenPrices},
+				ChainFeeOutcome:   chainfee.Outcome{GasPrices: gasPrices},
+			},
+			exp: pending,
+		},
+		{
+			name: "generated when no ranges were selected",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportIntervalsSelected},
+				ChainFeeOutcome:   chainfee.Outcome{GasPrices: gasPrices},
+			},
+			exp: &PriceOnlyReport{PriceUpdates: cciptypes.PriceUpdates{GasPriceUpdates: gasPrices}},
+		},
+		{
+			name: "not generated when roots will be reported in the next round",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{
+					OutcomeType: merkleroot.ReportIntervalsSelected,
+					RangesSelectedForReport: []plugintypes.ChainRange{
+						{ChainSel: 2, SeqNumRange: cciptypes.NewSeqNumRange(1, 10)},
+					},
+				},
+				TokenPriceOutcome: tokenprice.Outcome{TokenPrices: tokenPrices},
+			},
+		},
+		{
+			name: "not generated with a report with roots",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportGenerated},
+				TokenPriceOutcome: tokenprice.Outcome{TokenPrices: tokenPrices},
+			},
+		},
+		{
+			name: "not generated while a report is in flight",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportInFlight},
+				TokenPriceOutcome: tokenprice.Outcome{TokenPrices: tokenPrices},
+			},
+		},
+		{
+			name: "not generated without price updates",
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportEmpty},
+			},
+		},
+		{
+			name: "pending report is not regenerated while its prices still need an update",
+			prev: pending,
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportEmpty},
+				ChainFeeOutcome:   chainfee.Outcome{GasPrices: gasPrices},
+			},
+			exp: &PriceOnlyReport{PriceUpdates: pending.PriceUpdates, TransmissionCheckAttempts: 1},
+		},
+		{
+			name: "pending report is dropped once its prices are reflected",
+			prev: pending,
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportEmpty},
+				ChainFeeOutcome:   chainfee.Outcome{GasPrices: otherGasPrices},
+			},
+		},
+		{
+			name: "pending report is dropped after the max check attempts",
+			prev: &PriceOnlyReport{PriceUpdates: pending.PriceUpdates, TransmissionCheckAttempts: 2},
+			outcome: Outcome{
+				MerkleRootOutcome: merkleroot.Outcome{OutcomeType: merkleroot.ReportEmpty},
+				TokenPriceOutcome: tokenprice.Outcome{TokenPrices: tokenPrices},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p := &Plugin{
+				lggr:        logger.Test(t),
+				offchainCfg: pluginconfig.CommitOffchainConfig{MaxReportTransmissionCheckAttempts: 3},
+			}
+			assert.Equal(t, tc.exp, p.priceOnlyReport(tc.prev, tc.outcome))
+		})
+	}
+}
+
+func TestPlugin_ShouldAcceptAttestedReport_PriceOnlyWithoutRMNSignatures(t *testing.T) {
+	ctx := context.Background()
+	codec := mocks.NewCommitPluginJSONReportCodec()
+	p := &Plugin{
+		lggr:        logger.Test(t),
+		reportCodec: codec,
+		offchainCfg: pluginconfig.CommitOffchainConfig{RMNEnabled: true},
+		metrics:     metrics.Noop{},
+	}
+
+	info, err := ReportInfo{MinSigners: 2}.Encode()
+	require.NoError(t, err)
+
+	priceOnly, err := codec.Encode(ctx, cciptypes.CommitPluginReport{
+		PriceUpdates: cciptypes.PriceUpdates{
+			TokenPriceUpdates: []cciptypes.TokenPrice{cciptypes.NewTokenPrice("0x1", big.NewInt(100))},
+		},
+	})
+	require.NoError(t, err)
+	accepted, err := p.ShouldAcceptAttestedReport(ctx, 1, ocr3types.ReportWithInfo[[]byte]{
+		Report: priceOnly, Info: info,
+	})
+	require.NoError(t, err)
+	assert.True(t, accepted)
+
+	withRoots, err := codec.Encode(ctx, cciptypes.CommitPluginReport{
+		MerkleRoots: []cciptypes.MerkleRootChain{{ChainSel: 2, SeqNumsRange: cciptypes.NewSeqNumRange(1, 10)}},
+	})
+	require.NoError(t, err)
+	accepted, err = p.ShouldAcceptAttestedReport(ctx, 1, ocr3types.ReportWithInfo[[]byte]{
+		Report: withRoots, Info: info,
+	})
+	require.NoError(t, err)
+	assert.False(t, accepted)
+}
+
+func TestPlugin_ShouldTransmitAcceptedReport_RMNSignatures(t *testing.T) {
+	destChain := cciptypes.ChainSelector(chainsel.TEST_90000004.Selector)
+	offRampAddress := cciptypes.Bytes{0xaa}
+	rmnRemoteCfg := testhelpers.CreateRMNRemoteCfg()
+	rmnRemoteCfg.MinSigners = 1
+	root := cciptypes.MerkleRootChain{
+		ChainSel:      2,
+		OnRampAddress: cciptypes.Bytes{1},
+		SeqNumsRange:  cciptypes.NewSeqNumRange(10, 20),
+		MerkleRoot:    cciptypes.Bytes32{3},
+	}
+	validSig := cciptypes.RMNECDSASignature{R: cciptypes.Bytes32{1}, S: cciptypes.Bytes32{2}}
+	invalidSig := cciptypes.RMNECDSASignature{R: cciptypes.Bytes32{3}, S: cciptypes.Bytes32{4}}
+
+	testCases := []struct {
+		name           string
+		sigs           []cciptypes.RMNECDSASignature
+		rmnRemoteErr   error
+		offRampErr     error
+		expTransmitted bool
+		expErr         bool
+	}{
+		{
+			name:           "valid signatures",
+			sigs:           []cciptypes.RMNECDSASignature{validSig},
+			expTransmitted: true,
+		},
+		{
+			name:           "invalid signatures",
+			sigs:           []cciptypes.RMNECDSASignature{validSig, invalidSig},
+			expTransmitted: false,
+		},
+		{
+			name:           "insufficient signatures",
+			sigs:           []cciptypes.RMNECDSASignature{},
+			expTransmitted: false,
+		},
+		{
+			name:           "RMNRemote config not available",
+			sigs:           []cciptypes.RMNECDSASignature{validSig},
+			rmnRemoteErr:   readerpkg.ErrRMNRemoteConfigStale,
+			expTransmitted: false,
+			expErr:         true,
+		},
+		{
+			name:           "offramp address not available",
+			sigs:           []cciptypes.RMNECDSASignature{validSig},
+			offRampErr:     fmt.Errorf("some error"),
+			expTransmitted: false,
+			expErr:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := tests.Context(t)
+			codec := mocks.NewCommitPluginJSONReportCodec()
+
+			chainSupport := plugincommon_mock.NewMockChainSupport(t)
+			chainSupport.EXPECT().SupportsDestChain(mock.Anything).Return(true, nil)
+			homeChain := reader_mock.NewMockHomeChain(t)
+			homeChain.EXPECT().GetOCRConfigs(mock.Anything, mock.Anything, mock.Anything).
+				Return([]reader.OCR3ConfigWithMeta{{}}, nil)
+			ccipReader := readerpkg_mock.NewMockCCIPReader(t)
+			ccipReader.EXPECT().NextSeqNum(mock.Anything, []cciptypes.ChainSelector{root.ChainSel}).
+				Return([]cciptypes.SeqNum{root.SeqNumsRange.Start()}, nil)
+			ccipReader.EXPECT().GetContractAddress(consts.ContractNameOffRamp, destChain).
+				Return(offRampAddress, tc.offRampErr).Maybe()
+			rmnRemote := readerpkg_mock.NewMockRMNRemote(t)
+			rmnRemote.EXPECT().GetRemoteConfig().Return(rmnRemoteCfg, tc.rmnRemoteErr)
+
+			p := &Plugin{
+				lggr:            logger.Test(t),
+				destChain:       destChain,
+				reportCodec:     codec,
+				offchainCfg:     pluginconfig.CommitOffchainConfig{RMNEnabled: true},
+				chainSupport:    chainSupport,
+				homeChain:       homeChain,
+				ccipReader:      ccipReader,
+				rmnRemoteReader: rmnRemote,
+				rmnCrypto: rmnCryptoFunc(func(sigs []cciptypes.RMNECDSASignature, report cciptypes.RMNReport) error {
+					assert.Equal(t, rmnRemoteCfg.ConfigDigest, report.RmnHomeContractConfigDigest)
+					assert.Equal(t, offRampAddress, report.OfframpAddress)
+					assert.Equal(t, []cciptypes.RMNLaneUpdate{{
+						SourceChainSelector: root.ChainSel,
+						OnRampAddress:       root.OnRampAddress,
+						MinSeqNr:            root.SeqNumsRange.Start(),
+						MaxSeqNr:            root.SeqNumsRange.End(),
+						MerkleRoot:          root.MerkleRoot,
+					}}, report.LaneUpdates)
+					for _, sig := range sigs {
+						if sig != validSig {
+							return fmt.Errorf("invalid signature %v", sig)
+						}
+					}
+					return nil
+				}),
+				metrics: metrics.Noop{},
+			}
+
+			rep, err := codec.Encode(ctx, cciptypes.CommitPluginReport{
+				MerkleRoots:   []cciptypes.MerkleRootChain{root},
+				RMNSignatures: tc.sigs,
+			})
+			require.NoError(t, err)
+
+			transmitted, err := p.ShouldTransmitAcceptedReport(ctx, 1, ocr3types.ReportWithInfo[[]byte]{Report: rep})
+			if tc.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expTransmitted, transmitted)
+		})
+	}
+}
+
+// rmnCryptoFunc is an RMNCrypto that verifies the report signatures with the provided function.
+type rmnCryptoFunc func(sigs []cciptypes.RMNECDSASignature, report cciptypes.RMNReport) error
+
+func (f rmnCryptoFunc) VerifyReportSignatures(
+	_ context.Context, sigs []cciptypes.RMNECDSASignature, report cciptypes.RMNReport, _ []cciptypes.Bytes,
+) error {
+	return f(sigs, report)
+}
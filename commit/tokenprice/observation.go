@@ -2,6 +2,8 @@ package tokenprice
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"sort"
 	"time"
 
@@ -9,7 +11,10 @@ import (
 
 	"golang.org/x/exp/maps"
 
+	"github.com/goplugin/plugin-ccip/commit/metrics"
+	"github.com/goplugin/plugin-ccip/internal/plugincommon"
 	"github.com/goplugin/plugin-ccip/internal/plugintypes"
+	pkgreader "github.com/goplugin/plugin-ccip/pkg/reader"
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 )
 
@@ -24,7 +29,16 @@ func (p *processor) Observation(
 		return Observation{}, nil
 	}
 
-	feedTokenPrices := p.ObserveFeedTokenPrices(ctx)
+	feedTokenPrices, failures := p.ObserveFeedTokenPrices(ctx)
+	if len(failures) > 0 {
+		p.lggr.Warnw("some feed token prices could not be observed, continuing with the others",
+			"failures", failures.Err())
+		if p.metrics != nil {
+			for _, f := range failures {
+				p.metrics.TrackError(metrics.PhaseObservation, f.Object)
+			}
+		}
+	}
 	feeQuoterUpdates := p.ObserveFeeQuoterTokenUpdates(ctx)
 	ts := time.Now().UTC()
 	p.lggr.Infow(
@@ -51,47 +65,77 @@ func (p *processor) ObserveFChain() map[cciptypes.ChainSelector]int {
 	return fChain
 }
 
-func (p *processor) ObserveFeedTokenPrices(ctx context.Context) []cciptypes.TokenPrice {
+// ObserveFeedTokenPrices observes the feed prices of all configured tokens. Tokens whose price could not be read are
+// returned as failures and do not prevent observing the prices of the other tokens.
+func (p *processor) ObserveFeedTokenPrices(
+	ctx context.Context,
+) ([]cciptypes.TokenPrice, plugincommon.ObservationFailures) {
 	if p.tokenPriceReader == nil {
 		p.lggr.Debugw("no token price reader available")
-		return []cciptypes.TokenPrice{}
+		return []cciptypes.TokenPrice{}, nil
 	}
 
 	supportedChains, err := p.chainSupport.SupportedChains(p.oracleID)
 	if err != nil {
 		p.lggr.Warnw("call to SupportedChains failed", "err", err)
-		return []cciptypes.TokenPrice{}
+		return []cciptypes.TokenPrice{}, nil
 	}
 
-	if !supportedChains.Contains(p.offChainCfg.PriceFeedChainSelector) {
-		p.lggr.Debugf("oracle does not support feed chain %d", p.offChainCfg.PriceFeedChainSelector)
-		return []cciptypes.TokenPrice{}
-
+	// Tokens are observed by the oracles supporting any of the chains their price is read from, the price reader
+	// skips the sources on unsupported chains.
+	tokensToQuery := make([]types.Account, 0, len(p.offChainCfg.TokenInfo))
+	for token := range p.offChainCfg.TokenInfo {
+		supportsPriceChain := slices.ContainsFunc(p.offChainCfg.TokenPriceChains(token),
+			func(chain cciptypes.ChainSelector) bool { return supportedChains.Contains(chain) })
+		if supportsPriceChain {
+			tokensToQuery = append(tokensToQuery, token)
+		}
+	}
+	if len(tokensToQuery) == 0 {
+		p.lggr.Debugw("oracle does not support the price chains of any token")
+		return []cciptypes.TokenPrice{}, nil
 	}
 
-	tokensToQuery := maps.Keys(p.offChainCfg.TokenInfo)
 	// sort tokens to query to ensure deterministic order
 	sort.Slice(tokensToQuery, func(i, j int) bool { return tokensToQuery[i] < tokensToQuery[j] })
 	p.lggr.Infow("observing feed token prices", "tokens", tokensToQuery)
 	tokenPrices, err := p.tokenPriceReader.GetFeedPricesUSD(ctx, tokensToQuery)
-	if err != nil {
+
+	var failures plugincommon.ObservationFailures
+	var tokenErrs pkgreader.TokenPriceErrors
+	switch {
+	case err == nil:
+	case errors.As(err, &tokenErrs):
+		for _, token := range tokensToQuery {
+			if tokenErr, ok := tokenErrs[token]; ok {
+				failures = append(failures, plugincommon.ObservationFailure{
+					Object: "feedTokenPrice",
+					Token:  string(token),
+					Err:    tokenErr,
+				})
+			}
+		}
+	default:
 		p.lggr.Errorw("call to GetFeedPricesUSD failed",
 			"err", err)
-		return []cciptypes.TokenPrice{}
+		return []cciptypes.TokenPrice{}, nil
 	}
 
-	// If we couldn't fetch all prices log and return only the ones we could fetch
 	if len(tokenPrices) != len(tokensToQuery) {
 		p.lggr.Errorw("token prices length mismatch", "got", tokenPrices, "want", tokensToQuery)
-		return []cciptypes.TokenPrice{}
+		return []cciptypes.TokenPrice{}, nil
 	}
 
 	tokenPricesUSD := make([]cciptypes.TokenPrice, 0, len(tokenPrices))
 	for i, token := range tokensToQuery {
+		// Prices which could not be read are nil and already reported as failures.
+		if tokenPrices[i] == nil {
+			continue
+		}
 		tokenPricesUSD = append(tokenPricesUSD, cciptypes.NewTokenPrice(token, tokenPrices[i]))
 	}
 
-	return tokenPricesUSD
+	return tokenPricesUSD, failures
 }
 
 func (p *processor) ObserveFeeQuoterTokenUpdates(ctx context.Context) map[types.Account]plugintypes.TimestampedBig {
@@ -20,6 +20,7 @@ import (
 	common_mock "github.com/goplugin/plugin-ccip/mocks/internal_/plugincommon"
 	readermock "github.com/goplugin/plugin-ccip/mocks/internal_/reader"
 	readerpkg_mock "github.com/goplugin/plugin-ccip/mocks/pkg/reader"
+	readerpkg "github.com/goplugin/plugin-ccip/pkg/reader"
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 	"github.com/goplugin/plugin-ccip/pluginconfig"
 )
@@ -148,3 +149,68 @@ var defaultCfg = pluginconfig.CommitOffchainConfig{
 	},
 	PriceFeedChainSelector: feedChainSel,
 }
+
+func Test_ObserveFeedTokenPrices_PartialFailure(t *testing.T) {
+	chainSupport := common_mock.NewMockChainSupport(t)
+	chainSupport.EXPECT().SupportedChains(mock.Anything).Return(mapset.NewSet(feedChainSel), nil)
+
+	tokenPriceReader := readerpkg_mock.NewMockPriceReader(t)
+	tokenPriceReader.EXPECT().GetFeedPricesUSD(mock.Anything, []types.Account{tokenA, tokenB}).
+		Return([]*big.Int{nil, bi200}, readerpkg.TokenPriceErrors{tokenA: errors.New("rpc unavailable")})
+
+	p := &processor{
+		oracleID:         1,
+		lggr:             logger.Test(t),
+		chainSupport:     chainSupport,
+		tokenPriceReader: tokenPriceReader,
+		offChainCfg:      defaultCfg,
+		destChain:        destChainSel,
+		fRoleDON:         f,
+	}
+
+	prices, failures := p.ObserveFeedTokenPrices(context.Background())
+	assert.Equal(t, []cciptypes.TokenPrice{cciptypes.NewTokenPrice(tokenB, bi200)}, prices)
+	require.Len(t, failures, 1)
+	assert.Equal(t, string(tokenA), failures[0].Token)
+	assert.Equal(t, "feedTokenPrice", failures[0].Object)
+}
+
+func Test_ObserveFeedTokenPrices_TokenPriceChains(t *testing.T) {
+	sourceChainSel := cciptypes.ChainSelector(3)
+	cfg := defaultCfg
+	cfg.TokenInfo = map[types.Account]pluginconfig.TokenInfo{
+		tokenA: defaultCfg.TokenInfo[tokenA],
+		tokenB: {
+			Decimals:          18,
+			AggregatorAddress: "0x2222222222222222222222Ff18C45Df59775Fbb2",
+			DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+			PriceSources: []pluginconfig.PriceSource{{
+				AggregatorAddress: "0x3333333333333333333333Ff18C45Df59775Fbb2",
+				ChainSelector:     sourceChainSel,
+			}},
+		},
+	}
+
+	// The oracle does not support the feed chain, only the tokens with a price source on a supported chain are
+	// observed.
+	chainSupport := common_mock.NewMockChainSupport(t)
+	chainSupport.EXPECT().SupportedChains(mock.Anything).Return(mapset.NewSet(sourceChainSel), nil)
+
+	tokenPriceReader := readerpkg_mock.NewMockPriceReader(t)
+	tokenPriceReader.EXPECT().GetFeedPricesUSD(mock.Anything, []types.Account{tokenB}).
+		Return([]*big.Int{bi200}, nil)
+
+	p := &processor{
+		oracleID:         1,
+		lggr:             logger.Test(t),
+		chainSupport:     chainSupport,
+		tokenPriceReader: tokenPriceReader,
+		offChainCfg:      cfg,
+		destChain:        destChainSel,
+		fRoleDON:         f,
+	}
+
+	prices, failures := p.ObserveFeedTokenPrices(context.Background())
+	assert.Equal(t, []cciptypes.TokenPrice{cciptypes.NewTokenPrice(tokenB, bi200)}, prices)
+	assert.Empty(t, failures)
+}
@@ -2,6 +2,8 @@ package tokenprice
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"sort"
 	"time"
 
@@ -23,7 +25,7 @@ func (p *processor) getConsensusObservation(
 	// consensus on the fChain map uses the role DON F value
 	// because all nodes can observe the home chain.
 	donThresh := consensus.MakeConstantThreshold[cciptypes.ChainSelector](consensus.TwoFPlus1(p.fRoleDON))
-	fChains := consensus.GetConsensusMap(p.lggr, "fChain", aggObs.FChain, donThresh)
+	fChains := consensus.GetConsensusMap(p.lggr, "fChain", aggObs.FChain, donThresh, p.metrics)
 
 	fDestChain, exists := fChains[p.destChain]
 	if !exists {
@@ -31,20 +33,26 @@ func (p *processor) getConsensusObservation(
 			fmt.Errorf("no consensus value for fDestChain, destChain: %d", p.destChain)
 	}
 
-	fFeedChain, exists := fChains[p.offChainCfg.PriceFeedChainSelector]
-	if !exists {
-		return ConsensusObservation{},
-			fmt.Errorf("no consensus value for f for FeedChain: %d", p.offChainCfg.PriceFeedChainSelector)
+	// The feed prices of a token are observed by the oracles supporting any of the chains its price is read from,
+	// the threshold is derived from the largest f of those chains.
+	fTokens := make(map[types.Account]int, len(aggObs.FeedTokenPrices))
+	for token := range aggObs.FeedTokenPrices {
+		fToken, ok := tokenPriceF(fChains, p.offChainCfg.TokenPriceChains(token))
+		if !ok {
+			p.lggr.Warnw("no consensus value for f of any price chain of the token, skipping its feed price",
+				"token", token)
+			delete(aggObs.FeedTokenPrices, token)
+			continue
+		}
+		fTokens[token] = fToken
 	}
 
 	feedPricesConsensus := consensus.GetConsensusMapAggregator(
 		p.lggr,
 		"FeedTokenPrices",
 		aggObs.FeedTokenPrices,
-		consensus.MakeConstantThreshold[types.Account](consensus.TwoFPlus1(fFeedChain)),
-		func(vals []cciptypes.TokenPrice) cciptypes.TokenPrice {
-			return consensus.Median(vals, consensus.TokenPriceComparator)
-		},
+		consensus.MakeMultiThreshold(fTokens, consensus.TwoFPlus1),
+		consensus.TokenPriceAggregator,
 	)
 
 	feeQuoterUpdatesConsensus := consensus.GetConsensusMapAggregator(
@@ -67,26 +75,40 @@ func (p *processor) getConsensusObservation(
 	return consensusObs, nil
 }
 
+// tokenPriceF returns the largest f of the price chains of a token which have a consensus f.
+func tokenPriceF(fChains map[cciptypes.ChainSelector]int, priceChains []cciptypes.ChainSelector) (int, bool) {
+	fToken, found := 0, false
+	for _, chain := range priceChains {
+		if f, ok := fChains[chain]; ok && (!found || f > fToken) {
+			fToken, found = f, true
+		}
+	}
+	return fToken, found
+}
+
 // selectTokensForUpdate checks which tokens need to be updated based on the observed token prices and
 // the fee quoter updates
 // a token is selected for update if it meets one of 2 conditions:
-// 1. if time passed since the last update is greater than the stale threshold
+// 1. if time passed since the last update is greater than the token's heartbeat
 // 2. if deviation between the fee quoter and feed exceeds token's configured threshold
+// If more tokens are selected than MaxTokenPriceUpdatesPerReport, the most urgent ones are kept.
 func (p *processor) selectTokensForUpdate(
 	obs ConsensusObservation,
 ) []cciptypes.TokenPrice {
-	var tokenPrices []cciptypes.TokenPrice
+	var updates []tokenPriceUpdate
 	cfg := p.offChainCfg
 	tokenInfo := cfg.TokenInfo
 
 	for token, feedPrice := range obs.FeedTokenPrices {
+		tokenPrice := cciptypes.TokenPrice{
+			TokenID: token,
+			Price:   cciptypes.NewBigInt(feedPrice.Price.Int),
+		}
+
 		lastUpdate, exists := obs.FeeQuoterTokenUpdates[token]
 		if !exists {
 			// if the token is not in the fee quoter updates, then we should update it
-			tokenPrices = append(tokenPrices, cciptypes.TokenPrice{
-				TokenID: token,
-				Price:   cciptypes.NewBigInt(feedPrice.Price.Int),
-			})
+			updates = append(updates, tokenPriceUpdate{price: tokenPrice, urgency: math.Inf(1)})
 			continue
 		}
 
@@ -96,18 +118,44 @@ func (p *processor) selectTokensForUpdate(
 			continue
 		}
 
-		nextUpdateTime := lastUpdate.Timestamp.Add(cfg.TokenPriceBatchWriteFrequency.Duration())
+		heartbeat := cfg.TokenPriceBatchWriteFrequency.Duration()
+		if ti.Heartbeat.Duration() > 0 {
+			heartbeat = ti.Heartbeat.Duration()
+		}
+
+		nextUpdateTime := lastUpdate.Timestamp.Add(heartbeat)
 		shouldUpdate :=
 			obs.Timestamp.After(nextUpdateTime) ||
 				mathslib.Deviates(feedPrice.Price.Int, lastUpdate.Value.Int, ti.DeviationPPB.Int64())
 		if shouldUpdate {
-			tokenPrices = append(tokenPrices, cciptypes.TokenPrice{
-				TokenID: token,
-				Price:   cciptypes.NewBigInt(feedPrice.Price.Int),
+			updates = append(updates, tokenPriceUpdate{
+				price: tokenPrice,
+				urgency: updateUrgency(
+					obs.Timestamp.Sub(lastUpdate.Timestamp), heartbeat,
+					feedPrice.Price.Int, lastUpdate.Value.Int, ti.DeviationPPB.Int64(),
+				),
 			})
 		}
 	}
 
+	if maxUpdates := cfg.MaxTokenPriceUpdatesPerReport; maxUpdates > 0 && len(updates) > maxUpdates {
+		// keep the most urgent updates, ties are broken by tokenID to be deterministic across nodes
+		sort.Slice(updates, func(i, j int) bool {
+			if updates[i].urgency != updates[j].urgency {
+				return updates[i].urgency > updates[j].urgency
+			}
+			return updates[i].price.TokenID < updates[j].price.TokenID
+		})
+		p.lggr.Infow("capping token price updates",
+			"maxUpdates", maxUpdates, "numUpdates", len(updates), "skipped", updates[maxUpdates:])
+		updates = updates[:maxUpdates]
+	}
+
+	var tokenPrices []cciptypes.TokenPrice
+	for _, update := range updates {
+		tokenPrices = append(tokenPrices, update.price)
+	}
+
 	// sort the token prices by tokenID
 	sort.Slice(tokenPrices, func(i, j int) bool {
 		return tokenPrices[i].TokenID < tokenPrices[j].TokenID
@@ -115,6 +163,46 @@ func (p *processor) selectTokensForUpdate(
 	return tokenPrices
 }
 
+// tokenPriceUpdate is a token price selected for update with the urgency of the update.
+type tokenPriceUpdate struct {
+	price   cciptypes.TokenPrice
+	urgency float64
+}
+
+// updateUrgency returns how urgent a token price update is, as the greatest of how overdue the update is relative
+// to the heartbeat and how far the price deviates relative to the deviation threshold.
+// e.g. a token whose price deviates twice its threshold is as urgent as a token last updated two heartbeats ago.
+func updateUrgency(
+	sinceLastUpdate, heartbeat time.Duration,
+	feedPrice, lastPrice *big.Int,
+	deviationPPB int64,
+) float64 {
+	urgency := 0.0
+	if heartbeat > 0 {
+		urgency = float64(sinceLastUpdate) / float64(heartbeat)
+	}
+
+	deviation, ok := mathslib.DeviationPPB(feedPrice, lastPrice)
+	if !ok {
+		if feedPrice.Cmp(lastPrice) != 0 {
+			return math.Inf(1)
+		}
+		return urgency
+	}
+	if deviationPPB <= 0 {
+		if deviation.Sign() > 0 {
+			return math.Inf(1)
+		}
+		return urgency
+	}
+
+	deviationUrgency, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(deviation),
+		new(big.Float).SetInt64(deviationPPB),
+	).Float64()
+	return math.Max(urgency, deviationUrgency)
+}
+
 // aggregateObservations takes a list of observations and produces an AggregateObservation
 func aggregateObservations(aos []plugincommon.AttributedObservation[Observation]) AggregateObservation {
 	aggObs := AggregateObservation{
@@ -108,6 +108,50 @@ func TestGetConsensusObservation(t *testing.T) {
 	assert.Len(t, consensusObs.FeedTokenPrices, 4)
 }
 
+func TestGetConsensusObservation_TokenPriceChainsF(t *testing.T) {
+	sourceChainSel := cciptypes.ChainSelector(3)
+	cfg := offChainCfg
+	cfg.TokenInfo = map[types.Account]pluginconfig.TokenInfo{
+		tokenA: {DeviationPPB: cbi(1)},
+		tokenB: {
+			DeviationPPB: cbi(2),
+			PriceSources: []pluginconfig.PriceSource{{ChainSelector: sourceChainSel}},
+		},
+	}
+	p := &processor{
+		lggr:        logger.Test(t),
+		destChain:   destChainSel,
+		offChainCfg: cfg,
+		fRoleDON:    1,
+	}
+
+	// There is no consensus on the f of the feed chain, the price of tokenB is also read from a chain with f=1.
+	o := Observation{
+		FeedTokenPrices: []cciptypes.TokenPrice{feedTokenPricesMap[tokenA], feedTokenPricesMap[tokenB]},
+		FChain:          map[cciptypes.ChainSelector]int{destChainSel: 1, sourceChainSel: 1},
+		Timestamp:       ts,
+	}
+	aos := []plugincommon.AttributedObservation[Observation]{
+		{OracleID: 1, Observation: o},
+		{OracleID: 2, Observation: o},
+		{OracleID: 3, Observation: o},
+	}
+
+	consensusObs, err := p.getConsensusObservation(aos)
+	assert.NoError(t, err)
+	assert.Equal(t, map[types.Account]cciptypes.TokenPrice{tokenB: feedTokenPricesMap[tokenB]},
+		consensusObs.FeedTokenPrices)
+
+	// The threshold is derived from the largest f of the price chains.
+	o.FChain[feedChainSel] = 2
+	for i := range aos {
+		aos[i].Observation = o
+	}
+	consensusObs, err = p.getConsensusObservation(aos)
+	assert.NoError(t, err)
+	assert.Empty(t, consensusObs.FeedTokenPrices)
+}
+
 func TestSelectTokensForUpdate(t *testing.T) {
 	lggr := logger.Test(t)
 	p := &processor{
@@ -134,6 +178,109 @@ func TestSelectTokensForUpdate(t *testing.T) {
 	assert.Equal(t, conObs.FeedTokenPrices[tokenC], tokenPrices[2])
 }
 
+func TestSelectTokensForUpdate_HeartbeatAndCap(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tokenInfo  map[types.Account]pluginconfig.TokenInfo
+		maxUpdates int
+		updates    map[types.Account]plugintypes.TimestampedBig
+		expTokens  []types.Account
+	}{
+		{
+			name: "per token heartbeat overrides the batch write frequency",
+			tokenInfo: map[types.Account]pluginconfig.TokenInfo{
+				// updated 2 minutes ago, a heartbeat of 1h prevents the update
+				tokenA: {DeviationPPB: cbi(1), Heartbeat: *commonconfig.MustNewDuration(time.Hour)},
+				// updated 30s ago, a heartbeat of 10s triggers the update
+				tokenB: {DeviationPPB: cbi(1), Heartbeat: *commonconfig.MustNewDuration(10 * time.Second)},
+			},
+			updates: map[types.Account]plugintypes.TimestampedBig{
+				tokenA: {Timestamp: ts.Add(-2 * time.Minute), Value: cbi100},
+				tokenB: {Timestamp: ts.Add(-30 * time.Second), Value: cbi200},
+			},
+			expTokens: []types.Account{tokenB},
+		},
+		{
+			name: "the most urgent updates are kept when capped",
+			tokenInfo: map[types.Account]pluginconfig.TokenInfo{
+				// 3 heartbeats overdue
+				tokenA: {DeviationPPB: cbi(2e9), Heartbeat: *commonconfig.MustNewDuration(time.Minute)},
+				// deviates by 100% with a threshold of 10%, i.e. 10 times its threshold
+				tokenB: {DeviationPPB: cbi(1e8)},
+				// deviates by 100% with a threshold of 50%, i.e. 2 times its threshold
+				tokenD: {DeviationPPB: cbi(5e8)},
+			},
+			maxUpdates: 2,
+			updates: map[types.Account]plugintypes.TimestampedBig{
+				tokenA: {Timestamp: ts.Add(-3 * time.Minute), Value: cbi100},
+				tokenB: {Timestamp: ts, Value: cbi100},
+				tokenD: {Timestamp: ts, Value: cbi100},
+			},
+			expTokens: []types.Account{tokenA, tokenB},
+		},
+		{
+			name: "tokens missing from the fee quoter are the most urgent",
+			tokenInfo: map[types.Account]pluginconfig.TokenInfo{
+				tokenA: {DeviationPPB: cbi(1)},
+				tokenB: {DeviationPPB: cbi(1)},
+				tokenC: {DeviationPPB: cbi(1)},
+			},
+			maxUpdates: 1,
+			updates: map[types.Account]plugintypes.TimestampedBig{
+				tokenA: {Timestamp: ts.Add(-time.Hour), Value: cbi100},
+				tokenB: {Timestamp: ts, Value: cbi100},
+			},
+			expTokens: []types.Account{tokenC},
+		},
+		{
+			name: "no cap when the updates are within the limit",
+			tokenInfo: map[types.Account]pluginconfig.TokenInfo{
+				tokenA: {DeviationPPB: cbi(1)},
+				tokenB: {DeviationPPB: cbi(1)},
+			},
+			maxUpdates: 2,
+			updates: map[types.Account]plugintypes.TimestampedBig{
+				tokenA: {Timestamp: ts.Add(-time.Hour), Value: cbi100},
+				tokenB: {Timestamp: ts, Value: cbi100},
+			},
+			expTokens: []types.Account{tokenA, tokenB},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := offChainCfg
+			cfg.TokenInfo = tc.tokenInfo
+			cfg.MaxTokenPriceUpdatesPerReport = tc.maxUpdates
+			p := &processor{
+				lggr:        logger.Test(t),
+				destChain:   destChainSel,
+				offChainCfg: cfg,
+				fRoleDON:    1,
+			}
+
+			feedPrices := make(map[types.Account]cciptypes.TokenPrice)
+			for token := range tc.tokenInfo {
+				feedPrices[token] = feedTokenPricesMap[token]
+			}
+
+			tokenPrices := p.selectTokensForUpdate(ConsensusObservation{
+				FeedTokenPrices:       feedPrices,
+				FeeQuoterTokenUpdates: tc.updates,
+				Timestamp:             ts,
+			})
+
+			tokens := make([]types.Account, 0, len(tokenPrices))
+			for _, tokenPrice := range tokenPrices {
+				tokens = append(tokens, tokenPrice.TokenID)
+			}
+			assert.Equal(t, tc.expTokens, tokens)
+		})
+	}
+}
+
 // Test Plugin Outcome method returns the correct token prices
 func TestOutcome(t *testing.T) {
 	ctx := tests.Context(t)
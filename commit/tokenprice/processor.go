@@ -11,6 +11,7 @@ import (
 	"github.com/goplugin/plugin-libocr/commontypes"
 	"github.com/goplugin/plugin-libocr/offchainreporting2plus/types"
 
+	"github.com/goplugin/plugin-ccip/commit/metrics"
 	"github.com/goplugin/plugin-ccip/internal/plugincommon"
 	"github.com/goplugin/plugin-ccip/internal/reader"
 	pkgreader "github.com/goplugin/plugin-ccip/pkg/reader"
@@ -27,6 +28,7 @@ type processor struct {
 	tokenPriceReader pkgreader.PriceReader
 	homeChain        reader.HomeChain
 	fRoleDON         int
+	metrics          metrics.Reporter
 }
 
 func NewProcessor(
@@ -38,6 +40,7 @@ func NewProcessor(
 	tokenPriceReader pkgreader.PriceReader,
 	homeChain reader.HomeChain,
 	fRoleDON int,
+	metricsReporter metrics.Reporter,
 ) plugincommon.PluginProcessor[Query, Observation, Outcome] {
 	return &processor{
 		oracleID:         oracleID,
@@ -48,6 +51,7 @@ func NewProcessor(
 		tokenPriceReader: tokenPriceReader,
 		homeChain:        homeChain,
 		fRoleDON:         fRoleDON,
+		metrics:          metricsReporter,
 	}
 }
 
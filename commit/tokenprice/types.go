@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/goplugin/plugin-ccip/internal/plugincommon"
 	"github.com/goplugin/plugin-ccip/internal/plugintypes"
 
 	"github.com/goplugin/plugin-libocr/offchainreporting2plus/types"
@@ -42,8 +43,9 @@ type ConsensusObservation struct {
 }
 
 type Observer interface {
-	// ObserveFeedTokenPrices returns the latest token prices from the feed chain
-	ObserveFeedTokenPrices(ctx context.Context) []cciptypes.TokenPrice
+	// ObserveFeedTokenPrices returns the latest token prices from the feed chain, along with the tokens whose price
+	// could not be read.
+	ObserveFeedTokenPrices(ctx context.Context) ([]cciptypes.TokenPrice, plugincommon.ObservationFailures)
 
 	// ObserveFeeQuoterTokenUpdates returns the latest token prices from the FeeQuoter on the dest chain
 	ObserveFeeQuoterTokenUpdates(ctx context.Context) map[types.Account]plugintypes.TimestampedBig
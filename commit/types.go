@@ -54,6 +54,25 @@ type Outcome struct {
 	MerkleRootOutcome merkleroot.Outcome `json:"merkleRootOutcome"`
 	TokenPriceOutcome tokenprice.Outcome `json:"tokenPriceOutcome"`
 	ChainFeeOutcome   chainfee.Outcome   `json:"chainFeeOutcome"`
+	// PriceOnlyReport is set when a price-only report is generated in this round, or when a price-only report
+	// generated in a previous round is waiting to be reflected by the FeeQuoter.
+	PriceOnlyReport *PriceOnlyReport `json:"priceOnlyReport,omitempty"`
+}
+
+// PriceOnlyReport tracks a report with price updates but no merkle roots.
+type PriceOnlyReport struct {
+	PriceUpdates cciptypes.PriceUpdates `json:"priceUpdates"`
+	// TransmissionCheckAttempts is the number of rounds in which the transmission of the report was checked, it is 0
+	// in the round in which the report is generated.
+	TransmissionCheckAttempts uint `json:"transmissionCheckAttempts"`
+}
+
+// priceUpdates returns the token and gas price updates of the outcome.
+func (o Outcome) priceUpdates() cciptypes.PriceUpdates {
+	return cciptypes.PriceUpdates{
+		TokenPriceUpdates: o.TokenPriceOutcome.TokenPrices,
+		GasPriceUpdates:   o.ChainFeeOutcome.GasPrices,
+	}
 }
 
 // Encode encodes an Outcome deterministically
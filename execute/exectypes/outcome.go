@@ -1,6 +1,7 @@
 package exectypes
 
 import (
+	"bytes"
 	"encoding/json"
 	"sort"
 
@@ -49,6 +50,10 @@ func (p PluginState) Next() PluginState {
 	}
 }
 
+// MaxEncodedMessageStatusLength is an upper bound of the length of a JSON encoded MessageStatus, used to reserve
+// room for the message statuses in the maximum outcome length.
+const MaxEncodedMessageStatusLength = 256
+
 // Outcome is the outcome of the ExecutePlugin.
 type Outcome struct {
 	// State that the outcome was generated for.
@@ -60,6 +65,62 @@ type Outcome struct {
 
 	// Report is built from the oldest pending commit reports.
 	Report cciptypes.ExecutePluginReport `json:"report"`
+
+	// MessageStatuses explains why messages from the pending commit reports were not included in the report, keyed by
+	// message ID. It is only populated in the Filter state. The slice is sorted by message ID and holds the statuses of
+	// the oldest messages, up to the configured maximum, see SetMessageStatuses.
+	MessageStatuses []MessageStatus `json:"messageStatuses,omitempty"`
+
+	// MessageStatusCounts is the number of messages per status for all the messages that were not included in the
+	// report, including the ones left out of MessageStatuses.
+	MessageStatusCounts map[string]int `json:"messageStatusCounts,omitempty"`
+}
+
+// SetMessageStatuses counts the statuses per status and keeps the statuses of the maxStatuses oldest messages, by
+// source chain and sequence number, in the outcome. A maxStatuses of zero keeps only the counts.
+func (o *Outcome) SetMessageStatuses(statuses []MessageStatus, maxStatuses int) {
+	if len(statuses) == 0 {
+		o.MessageStatuses, o.MessageStatusCounts = nil, nil
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, status := range statuses {
+		counts[status.Status]++
+	}
+	o.MessageStatusCounts = counts
+
+	oldest := append([]MessageStatus{}, statuses...)
+	sort.Slice(oldest, func(i, j int) bool {
+		if oldest[i].SourceChain != oldest[j].SourceChain {
+			return oldest[i].SourceChain < oldest[j].SourceChain
+		}
+		return oldest[i].SeqNum < oldest[j].SeqNum
+	})
+	if len(oldest) > maxStatuses {
+		oldest = oldest[:maxStatuses]
+	}
+	o.MessageStatuses = sortMessageStatuses(oldest)
+}
+
+// MessageStatus returns the status of the message with the given ID, if it is part of the outcome.
+func (o Outcome) MessageStatus(messageID cciptypes.Bytes32) (MessageStatus, bool) {
+	statuses := sortMessageStatuses(o.MessageStatuses)
+	i := sort.Search(len(statuses), func(i int) bool {
+		return bytes.Compare(statuses[i].MessageID[:], messageID[:]) >= 0
+	})
+	if i < len(statuses) && statuses[i].MessageID == messageID {
+		return statuses[i], true
+	}
+	return MessageStatus{}, false
+}
+
+// MessageStatus is a structured explanation of the execution status of a single message.
+type MessageStatus struct {
+	MessageID   cciptypes.Bytes32       `json:"messageID"`
+	SourceChain cciptypes.ChainSelector `json:"sourceChain"`
+	SeqNum      cciptypes.SeqNum        `json:"seqNum"`
+	Status      string                  `json:"status"`
 }
 
 // IsEmpty returns true if the outcome has no pending commit reports or chain reports.
@@ -73,7 +134,7 @@ func NewOutcome(
 	pendingCommits []CommitData,
 	report cciptypes.ExecutePluginReport,
 ) Outcome {
-	return newSortedOutcome(state, pendingCommits, report)
+	return newSortedOutcome(state, pendingCommits, report, nil, nil)
 }
 
 // newSortedOutcome ensures canonical ordering of the outcome.
@@ -82,6 +143,8 @@ func newSortedOutcome(
 	state PluginState,
 	pendingCommits []CommitData,
 	report cciptypes.ExecutePluginReport,
+	messageStatuses []MessageStatus,
+	messageStatusCounts map[string]int,
 ) Outcome {
 	pendingCommitsCP := append([]CommitData{}, pendingCommits...)
 	reportCP := append([]cciptypes.ExecutePluginReportSingleChain{}, report.ChainReports...)
@@ -99,7 +162,23 @@ func newSortedOutcome(
 		State:                state,
 		PendingCommitReports: pendingCommitsCP,
 		Report:               cciptypes.ExecutePluginReport{ChainReports: reportCP},
+		MessageStatuses:      sortMessageStatuses(messageStatuses),
+		MessageStatusCounts:  messageStatusCounts,
+	}
+}
+
+// sortMessageStatuses returns a copy of the statuses sorted by message ID.
+func sortMessageStatuses(messageStatuses []MessageStatus) []MessageStatus {
+	if len(messageStatuses) == 0 {
+		return nil
 	}
+	messageStatusesCP := append([]MessageStatus{}, messageStatuses...)
+	sort.Slice(
+		messageStatusesCP,
+		func(i, j int) bool {
+			return bytes.Compare(messageStatusesCP[i].MessageID[:], messageStatusesCP[j].MessageID[:]) < 0
+		})
+	return messageStatusesCP
 }
 
 // Encode encodes the outcome by first sorting the pending commit reports and the chain reports
@@ -107,7 +186,8 @@ func newSortedOutcome(
 // The encoding MUST be deterministic.
 func (o Outcome) Encode() (ocr3types.Outcome, error) {
 	// We sort again here in case construction is not via the constructor.
-	return json.Marshal(newSortedOutcome(o.State, o.PendingCommitReports, o.Report))
+	return json.Marshal(newSortedOutcome(
+		o.State, o.PendingCommitReports, o.Report, o.MessageStatuses, o.MessageStatusCounts))
 }
 
 // DecodeOutcome decodes the outcome from JSON. An empty string is treated as an empty outcome.
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 )
 
 func TestPluginState_Next(t *testing.T) {
@@ -61,3 +63,77 @@ func TestPluginState_Next(t *testing.T) {
 		})
 	}
 }
+
+func TestOutcome_EncodeSortsMessageStatuses(t *testing.T) {
+	outcome := Outcome{
+		State: Filter,
+		MessageStatuses: []MessageStatus{
+			{MessageID: cciptypes.Bytes32{3}, SourceChain: 2, SeqNum: 5, Status: "invalid_nonce"},
+			{MessageID: cciptypes.Bytes32{1}, SourceChain: 1, SeqNum: 9, Status: "tooCostly"},
+			{MessageID: cciptypes.Bytes32{2}, SourceChain: 1, SeqNum: 3, Status: "token_data_not_ready"},
+		},
+	}
+
+	encoded, err := outcome.Encode()
+	require.NoError(t, err)
+
+	decoded, err := DecodeOutcome(encoded)
+	require.NoError(t, err)
+	require.Equal(t, []MessageStatus{
+		{MessageID: cciptypes.Bytes32{1}, SourceChain: 1, SeqNum: 9, Status: "tooCostly"},
+		{MessageID: cciptypes.Bytes32{2}, SourceChain: 1, SeqNum: 3, Status: "token_data_not_ready"},
+		{MessageID: cciptypes.Bytes32{3}, SourceChain: 2, SeqNum: 5, Status: "invalid_nonce"},
+	}, decoded.MessageStatuses)
+}
+
+func TestOutcome_SetMessageStatusesCapsAndCounts(t *testing.T) {
+	const numStatuses, maxStatuses = 30, 16
+	statuses := make([]MessageStatus, 0, numStatuses)
+	for i := numStatuses - 1; i >= 0; i-- {
+		status := "tooCostly"
+		if i%2 == 0 {
+			status = "invalid_nonce"
+		}
+		statuses = append(statuses, MessageStatus{
+			// message IDs are not ordered like the sequence numbers
+			MessageID:   cciptypes.Bytes32{byte(numStatuses - i)},
+			SourceChain: 1,
+			SeqNum:      cciptypes.SeqNum(i),
+			Status:      status,
+		})
+	}
+
+	var outcome Outcome
+	outcome.SetMessageStatuses(statuses, maxStatuses)
+
+	// the statuses of the oldest messages are kept, sorted by message ID
+	require.Len(t, outcome.MessageStatuses, maxStatuses)
+	for i, status := range outcome.MessageStatuses {
+		require.Equal(t, cciptypes.SeqNum(maxStatuses-1-i), status.SeqNum)
+	}
+	require.Equal(t, map[string]int{
+		"tooCostly":     numStatuses / 2,
+		"invalid_nonce": numStatuses / 2,
+	}, outcome.MessageStatusCounts)
+
+	status, ok := outcome.MessageStatus(cciptypes.Bytes32{byte(numStatuses)})
+	require.True(t, ok)
+	require.Equal(t, cciptypes.SeqNum(0), status.SeqNum)
+	_, ok = outcome.MessageStatus(cciptypes.Bytes32{1})
+	require.False(t, ok, "status of a newer message is dropped")
+
+	encoded, err := outcome.Encode()
+	require.NoError(t, err)
+	decoded, err := DecodeOutcome(encoded)
+	require.NoError(t, err)
+	require.Equal(t, outcome.MessageStatuses, decoded.MessageStatuses)
+	require.Equal(t, outcome.MessageStatusCounts, decoded.MessageStatusCounts)
+
+	outcome.SetMessageStatuses(statuses, 0)
+	require.Nil(t, outcome.MessageStatuses)
+	require.Len(t, outcome.MessageStatusCounts, 2)
+
+	outcome.SetMessageStatuses(nil, maxStatuses)
+	require.Nil(t, outcome.MessageStatuses)
+	require.Nil(t, outcome.MessageStatusCounts)
+}
@@ -99,7 +99,7 @@ func (p PluginFactory) NewReportingPlugin(
 		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to decode exec offchain config: %w", err)
 	}
 
-	if err = offchainConfig.Validate(); err != nil {
+	if err = offchainConfig.ApplyDefaultsAndValidate(); err != nil {
 		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to validate exec offchain config: %w", err)
 	}
 
@@ -117,6 +117,7 @@ func (p PluginFactory) NewReportingPlugin(
 	ccipReader := readerpkg.NewCCIPChainReader(
 		ctx,
 		p.lggr,
+		p.homeChainReader,
 		readers,
 		p.chainWriters,
 		p.ocrConfig.Config.ChainSelector,
@@ -143,30 +144,31 @@ func (p PluginFactory) NewReportingPlugin(
 	)
 
 	return NewPlugin(
-			p.donID,
-			config,
-			offchainConfig,
-			p.ocrConfig.Config.ChainSelector,
-			oracleIDToP2PID,
-			ccipReader,
-			p.execCodec,
-			p.msgHasher,
-			p.homeChainReader,
-			tokenDataObserver,
-			p.estimateProvider,
-			p.lggr,
-			costlyMessageObserver,
-		), ocr3types.ReportingPluginInfo{
-			Name: "CCIPRoleExecute",
-			Limits: ocr3types.ReportingPluginLimits{
-				// No query for this execute implementation.
-				MaxQueryLength:       0,
-				MaxObservationLength: 20_000,             // 20kB
-				MaxOutcomeLength:     20_000,             // 20kB
-				MaxReportLength:      maxReportSizeBytes, // 250kB
-				MaxReportCount:       10,
-			},
-		}, nil
+		p.donID,
+		config,
+		offchainConfig,
+		p.ocrConfig.Config.ChainSelector,
+		oracleIDToP2PID,
+		ccipReader,
+		p.execCodec,
+		p.msgHasher,
+		p.homeChainReader,
+		tokenDataObserver,
+		p.estimateProvider,
+		p.lggr,
+		costlyMessageObserver,
+	), ocr3types.ReportingPluginInfo{
+		Name: "CCIPRoleExecute",
+		Limits: ocr3types.ReportingPluginLimits{
+			// No query for this execute implementation.
+			MaxQueryLength:       0,
+			MaxObservationLength: 20_000, // 20kB
+			MaxOutcomeLength: 20_000 + // 20kB and room for the message statuses
+				int(offchainConfig.MaxMessageStatuses)*exectypes.MaxEncodedMessageStatusLength,
+			MaxReportLength: int(offchainConfig.MaxReportSizeBytes),
+			MaxReportCount:  10,
+		},
+	}, nil
 }
 
 func (p PluginFactory) Name() string {
@@ -3,6 +3,7 @@ package execute
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"golang.org/x/exp/maps"
@@ -100,7 +101,7 @@ func (p *Plugin) getCommitReportsObservation(
 			return exectypes.Observation{}, err
 		}
 
-		observation.CommitReports = groupedCommits
+		observation.CommitReports = p.filterCursedLanes(ctx, groupedCommits)
 
 		// TODO: truncate grouped to a maximum observation size?
 		return observation, nil
@@ -110,6 +111,36 @@ func (p *Plugin) getCommitReportsObservation(
 	return observation, nil
 }
 
+// filterCursedLanes removes the commit reports of lanes which are cursed on the destination RMNRemote. Executing
+// messages of a cursed lane reverts, so no reports are built for them until the curse is lifted. If the curse state
+// can not be read, the commit reports are returned as is.
+func (p *Plugin) filterCursedLanes(
+	ctx context.Context,
+	groupedCommits exectypes.CommitObservations,
+) exectypes.CommitObservations {
+	if len(groupedCommits) == 0 {
+		return groupedCommits
+	}
+
+	sourceChains := maps.Keys(groupedCommits)
+	sort.Slice(sourceChains, func(i, j int) bool { return sourceChains[i] < sourceChains[j] })
+	curseInfo, err := p.ccipReader.GetRmnCurseInfo(ctx, p.destChain, sourceChains)
+	if err != nil {
+		p.lggr.Errorw("failed to get RMN curse info, not filtering cursed lanes", "err", err)
+		return groupedCommits
+	}
+
+	for _, sourceChain := range sourceChains {
+		if curseInfo.IsLaneCursed(sourceChain) {
+			p.lggr.Warnw("lane is cursed, not executing its messages",
+				"sourceChain", sourceChain, "destChain", p.destChain,
+				"globalCurse", curseInfo.GlobalCurse, "cursedDestination", curseInfo.CursedDestination)
+			delete(groupedCommits, sourceChain)
+		}
+	}
+	return groupedCommits
+}
+
 // regroup converts the previous outcome to the observation format.
 // TODO: use same format for Observation and Outcome.
 func regroup(commitData []exectypes.CommitData) exectypes.CommitObservations {
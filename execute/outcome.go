@@ -161,12 +161,20 @@ func (p *Plugin) getFilterOutcome(
 	builder := report.NewBuilder(
 		p.lggr,
 		p.msgHasher,
+		p.leafCache,
+		p.treeCache,
 		p.reportCodec,
 		p.estimateProvider,
 		observation.Nonces,
 		p.destChain,
-		uint64(maxReportSizeBytes),
-		p.offchainCfg.BatchGasLimit,
+		report.Limits{
+			MaxReportSizeBytes:  p.offchainCfg.MaxReportSizeBytes,
+			MaxGas:              p.offchainCfg.BatchGasLimit,
+			MaxMessages:         p.offchainCfg.MaxMessagesPerReport,
+			MaxTokenTransfers:   p.offchainCfg.MaxTokenTransfersPerReport,
+			MaxMessageDataBytes: p.offchainCfg.MaxMessageDataBytesPerReport,
+			MaxSourceChains:     p.offchainCfg.MaxSourceChainsPerReport,
+		},
 	)
 	outcomeReports, commitReports, err := selectReport(
 		ctx,
@@ -183,5 +191,7 @@ func (p *Plugin) getFilterOutcome(
 
 	// Must use 'NewOutcome' rather than direct struct initialization to ensure the outcome is sorted.
 	// TODO: sort in the encoder.
-	return exectypes.NewOutcome(exectypes.Filter, commitReports, execReport), nil
+	outcome := exectypes.NewOutcome(exectypes.Filter, commitReports, execReport)
+	outcome.SetMessageStatuses(builder.MessageStatuses(), int(p.offchainCfg.MaxMessageStatuses))
+	return outcome, nil
 }
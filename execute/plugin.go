@@ -14,6 +14,7 @@ import (
 	libocrtypes "github.com/goplugin/plugin-libocr/ragep2p/types"
 
 	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/merklemulti"
 
 	"github.com/goplugin/plugin-ccip/execute/exectypes"
 	"github.com/goplugin/plugin-ccip/execute/internal/gas"
@@ -28,9 +29,6 @@ import (
 	"github.com/goplugin/plugin-ccip/pluginconfig"
 )
 
-// maxReportSizeBytes that should be returned as an execution report payload.
-const maxReportSizeBytes = 250_000
-
 // Plugin implements the main ocr3 plugin logic.
 type Plugin struct {
 	donID        plugintypes.DonID
@@ -53,6 +51,10 @@ type Plugin struct {
 
 	// state
 	contractsInitialized bool
+	// leafCache holds the verified message leaf hashes of pending commit reports across rounds.
+	leafCache *report.LeafHashCache
+	// treeCache holds the verified merkle trees of pending commit reports across rounds.
+	treeCache *report.TreeCache
 }
 
 func NewPlugin(
@@ -88,6 +90,11 @@ func NewPlugin(
 		estimateProvider:      estimateProvider,
 		lggr:                  lggr,
 		costlyMessageObserver: costlyMessageObserver,
+		// A commit report holds at most merklemulti.MaxNumberTreeLeaves messages, size the leaf cache to hold the
+		// leaves of all the cached trees.
+		leafCache: report.NewLeafHashCache(
+			int(offchainCfg.MerkleTreeCacheSize) * merklemulti.MaxNumberTreeLeaves),
+		treeCache: report.NewTreeCache(int(offchainCfg.MerkleTreeCacheSize)),
 		discovery: discovery.NewContractDiscoveryProcessor(
 			lggr,
 			&ccipReader,
@@ -195,7 +202,7 @@ func (p *Plugin) ObservationQuorum(
 }
 
 // selectReport takes a list of reports in execution order and selects the first reports that fit within the
-// maxReportSizeBytes. Individual messages in a commit report may be skipped for various reasons, for example if an
+// report limits. Individual messages in a commit report may be skipped for various reasons, for example if an
 // out-of-order execution is detected or the message requires additional off-chain metadata which is not yet available.
 // If there is not enough space in the final report, it may be partially executed by searching for a subset of messages
 // which can fit in the final report.
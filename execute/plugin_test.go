@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/exp/maps"
 
 	"github.com/goplugin/plugin-libocr/commontypes"
 	"github.com/goplugin/plugin-libocr/offchainreporting2plus/ocr3types"
@@ -306,6 +307,68 @@ func TestPlugin_Observation_EligibilityCheckFailure(t *testing.T) {
 	assert.Contains(t, err.Error(), "unable to determine if the destination chain is supported: error getting supported chains: oracle ID 0 not found in oracleIDToP2pID")
 }
 
+func TestPlugin_filterCursedLanes(t *testing.T) {
+	const destChain = cciptypes.ChainSelector(1)
+	commits := func() exectypes.CommitObservations {
+		return exectypes.CommitObservations{
+			2: {{SourceChain: 2, SequenceNumberRange: cciptypes.NewSeqNumRange(1, 10)}},
+			3: {{SourceChain: 3, SequenceNumberRange: cciptypes.NewSeqNumRange(1, 10)}},
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		curseInfo reader.CurseInfo
+		curseErr  error
+		expChains []cciptypes.ChainSelector
+	}{
+		{
+			name:      "no curses",
+			curseInfo: reader.CurseInfo{CursedSourceChains: map[cciptypes.ChainSelector]bool{2: false, 3: false}},
+			expChains: []cciptypes.ChainSelector{2, 3},
+		},
+		{
+			name:      "cursed source chain",
+			curseInfo: reader.CurseInfo{CursedSourceChains: map[cciptypes.ChainSelector]bool{2: false, 3: true}},
+			expChains: []cciptypes.ChainSelector{2},
+		},
+		{
+			name:      "cursed destination chain",
+			curseInfo: reader.CurseInfo{CursedDestination: true},
+			expChains: []cciptypes.ChainSelector{},
+		},
+		{
+			name:      "global curse",
+			curseInfo: reader.CurseInfo{GlobalCurse: true},
+			expChains: []cciptypes.ChainSelector{},
+		},
+		{
+			name:      "curse state not readable",
+			curseErr:  fmt.Errorf("rpc unavailable"),
+			expChains: []cciptypes.ChainSelector{2, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ccipReader := readerpkg_mock.NewMockCCIPReader(t)
+			ccipReader.EXPECT().GetRmnCurseInfo(mock.Anything, destChain, []cciptypes.ChainSelector{2, 3}).
+				Return(tc.curseInfo, tc.curseErr)
+
+			p := &Plugin{
+				lggr:       logger.Test(t),
+				destChain:  destChain,
+				ccipReader: ccipReader,
+			}
+
+			filtered := p.filterCursedLanes(tests.Context(t), commits())
+			assert.ElementsMatch(t, tc.expChains, maps.Keys(filtered))
+		})
+	}
+}
+
 func TestPlugin_Outcome_BadObservationEncoding(t *testing.T) {
 	ctx := tests.Context(t)
 	p := &Plugin{lggr: logger.Test(t)}
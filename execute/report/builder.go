@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/goplugin/plugin-common/pkg/logger"
 
@@ -17,30 +18,52 @@ var _ ExecReportBuilder = &execReportBuilder{}
 type ExecReportBuilder interface {
 	Add(ctx context.Context, report exectypes.CommitData) (exectypes.CommitData, error)
 	Build() ([]cciptypes.ExecutePluginReportSingleChain, error)
+	// MessageStatuses returns an explanation for each message which was checked by the builder but is not part of
+	// the built reports. Already executed messages are omitted.
+	MessageStatuses() []exectypes.MessageStatus
+}
+
+// Limits are the constraints that the combined execution report must satisfy. MaxReportSizeBytes and MaxGas are
+// always enforced, the remaining limits are disabled when set to zero.
+type Limits struct {
+	// MaxReportSizeBytes is the maximum size of the encoded report.
+	MaxReportSizeBytes uint64
+	// MaxGas is the maximum estimated gas of the report.
+	MaxGas uint64
+	// MaxMessages is the maximum number of messages in the report.
+	MaxMessages uint64
+	// MaxTokenTransfers is the maximum number of token transfers across all messages in the report.
+	MaxTokenTransfers uint64
+	// MaxMessageDataBytes is the maximum sum of message data payload sizes in the report.
+	MaxMessageDataBytes uint64
+	// MaxSourceChains is the maximum number of single chain reports in the report.
+	MaxSourceChains uint64
 }
 
 func NewBuilder(
 	logger logger.Logger,
 	hasher cciptypes.MessageHasher,
+	leafCache *LeafHashCache,
+	treeCache *TreeCache,
 	encoder cciptypes.ExecutePluginCodec,
 	estimateProvider gas.EstimateProvider,
 	nonces map[cciptypes.ChainSelector]map[string]uint64,
 	destChainSelector cciptypes.ChainSelector,
-	maxReportSizeBytes uint64,
-	maxGas uint64,
+	limits Limits,
 ) ExecReportBuilder {
 	return &execReportBuilder{
 		lggr: logger,
 
 		encoder:          encoder,
 		hasher:           hasher,
+		leafCache:        leafCache,
+		treeCache:        treeCache,
 		estimateProvider: estimateProvider,
 		sendersNonce:     nonces,
 		expectedNonce:    make(map[cciptypes.ChainSelector]map[string]uint64),
 
-		destChainSelector:  destChainSelector,
-		maxReportSizeBytes: maxReportSizeBytes,
-		maxGas:             maxGas,
+		destChainSelector: destChainSelector,
+		limits:            limits,
 	}
 }
 
@@ -48,12 +71,18 @@ func NewBuilder(
 type validationMetadata struct {
 	encodedSizeBytes uint64
 	gas              uint64
+	messages         uint64
+	tokenTransfers   uint64
+	messageDataBytes uint64
 }
 
 func (vm validationMetadata) accumulate(other validationMetadata) validationMetadata {
 	var result validationMetadata
 	result.encodedSizeBytes = vm.encodedSizeBytes + other.encodedSizeBytes
 	result.gas = vm.gas + other.gas
+	result.messages = vm.messages + other.messages
+	result.tokenTransfers = vm.tokenTransfers + other.tokenTransfers
+	result.messageDataBytes = vm.messageDataBytes + other.messageDataBytes
 	return result
 }
 
@@ -63,13 +92,14 @@ type execReportBuilder struct {
 	// Providers
 	encoder          cciptypes.ExecutePluginCodec
 	hasher           cciptypes.MessageHasher
+	leafCache        *LeafHashCache
+	treeCache        *TreeCache
 	estimateProvider gas.EstimateProvider
 	sendersNonce     map[cciptypes.ChainSelector]map[string]uint64
 
 	// Config
-	destChainSelector  cciptypes.ChainSelector
-	maxReportSizeBytes uint64
-	maxGas             uint64
+	destChainSelector cciptypes.ChainSelector
+	limits            Limits
 
 	// State
 	accumulated validationMetadata
@@ -78,12 +108,26 @@ type execReportBuilder struct {
 
 	// Result
 	execReports []cciptypes.ExecutePluginReportSingleChain
+	// messageStatuses explains why messages were not selected for execution.
+	messageStatuses []exectypes.MessageStatus
 }
 
 func (b *execReportBuilder) Add(
 	ctx context.Context,
 	commitReport exectypes.CommitData,
 ) (exectypes.CommitData, error) {
+	if b.limits.MaxSourceChains != 0 && uint64(len(b.execReports)) >= b.limits.MaxSourceChains {
+		b.lggr.Infow("skipping commit report, max source chains per report reached",
+			"sourceChain", commitReport.SourceChain,
+			"maxSourceChains", b.limits.MaxSourceChains)
+		for _, msg := range commitReport.Messages {
+			if !slices.Contains(commitReport.ExecutedMessages, msg.Header.SequenceNumber) {
+				b.recordMessageStatus(commitReport.SourceChain, msg, ReportLimitsExceeded)
+			}
+		}
+		return commitReport, nil
+	}
+
 	execReport, updatedReport, err := b.buildSingleChainReport(ctx, commitReport)
 
 	// No messages fit into the report, move to next report
@@ -104,6 +148,27 @@ func (b *execReportBuilder) Build() ([]cciptypes.ExecutePluginReportSingleChain,
 		"selected commit reports for execution report",
 		"numReports", len(b.execReports),
 		"sizeBytes", b.accumulated.encodedSizeBytes,
-		"maxSize", b.maxReportSizeBytes)
+		"maxSize", b.limits.MaxReportSizeBytes,
+		"numMessages", b.accumulated.messages,
+		"numTokenTransfers", b.accumulated.tokenTransfers,
+		"messageDataBytes", b.accumulated.messageDataBytes)
 	return b.execReports, nil
 }
+
+func (b *execReportBuilder) MessageStatuses() []exectypes.MessageStatus {
+	return b.messageStatuses
+}
+
+// recordMessageStatus stores the status of a message which was not selected for execution.
+func (b *execReportBuilder) recordMessageStatus(
+	sourceChain cciptypes.ChainSelector,
+	msg cciptypes.Message,
+	status messageStatus,
+) {
+	b.messageStatuses = append(b.messageStatuses, exectypes.MessageStatus{
+		MessageID:   msg.Header.MessageID,
+		SourceChain: sourceChain,
+		SeqNum:      msg.Header.SequenceNumber,
+		Status:      string(status),
+	})
+}
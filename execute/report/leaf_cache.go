@@ -0,0 +1,102 @@
+package report
+
+import (
+	"container/list"
+	"sync"
+
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// LeafHashCache is a bounded, least recently used cache of message leaf hashes keyed by message ID. It allows the
+// messages of partially executed commit reports to be hashed once across execute rounds instead of rehashing every
+// message each time a report is built. Cached leaves are only an optimization: the merkle root is always recomputed
+// from the leaves and compared with the committed root. A nil *LeafHashCache is valid and never caches anything.
+type LeafHashCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[cciptypes.Bytes32]*list.Element
+	// lru is ordered from most recently used (front) to least recently used (back).
+	lru *list.List
+}
+
+type leafHashCacheEntry struct {
+	messageID   cciptypes.Bytes32
+	sourceChain cciptypes.ChainSelector
+	seqNum      cciptypes.SeqNum
+	leaf        [32]byte
+}
+
+// NewLeafHashCache creates a LeafHashCache which holds at most capacity leaf hashes.
+func NewLeafHashCache(capacity int) *LeafHashCache {
+	return &LeafHashCache{
+		capacity: capacity,
+		entries:  make(map[cciptypes.Bytes32]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Get returns the leaf hash cached for the message. The leaf is only returned if it was cached for a message with
+// the same source chain and sequence number.
+func (c *LeafHashCache) Get(msg cciptypes.Message) ([32]byte, bool) {
+	if c == nil {
+		return [32]byte{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[msg.Header.MessageID]
+	if !ok {
+		return [32]byte{}, false
+	}
+
+	entry := elem.Value.(*leafHashCacheEntry)
+	if entry.sourceChain != msg.Header.SourceChainSelector || entry.seqNum != msg.Header.SequenceNumber {
+		return [32]byte{}, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry.leaf, true
+}
+
+// Add stores the leaf hash of a message which is part of a verified merkle tree. If the cache is full the least
+// recently used leaf is evicted.
+func (c *LeafHashCache) Add(msg cciptypes.Message, leaf [32]byte) {
+	if c == nil || c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &leafHashCacheEntry{
+		messageID:   msg.Header.MessageID,
+		sourceChain: msg.Header.SourceChainSelector,
+		seqNum:      msg.Header.SequenceNumber,
+		leaf:        leaf,
+	}
+
+	if elem, ok := c.entries[entry.messageID]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[entry.messageID] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*leafHashCacheEntry).messageID)
+	}
+}
+
+// Len returns the number of cached leaf hashes.
+func (c *LeafHashCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,149 @@
+package report
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+
+	"github.com/goplugin/plugin-ccip/execute/internal/gas/evm"
+	"github.com/goplugin/plugin-ccip/internal/mocks"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// countingHasher counts the number of hashed messages.
+type countingHasher struct {
+	cciptypes.MessageHasher
+	count atomic.Int64
+}
+
+func (h *countingHasher) Hash(ctx context.Context, msg cciptypes.Message) (cciptypes.Bytes32, error) {
+	h.count.Add(1)
+	return h.MessageHasher.Hash(ctx, msg)
+}
+
+func TestLeafHashCache_GetAdd(t *testing.T) {
+	msg := func(id byte, sourceChain cciptypes.ChainSelector, seqNum cciptypes.SeqNum) cciptypes.Message {
+		return cciptypes.Message{Header: cciptypes.RampMessageHeader{
+			MessageID:           cciptypes.Bytes32{id},
+			SourceChainSelector: sourceChain,
+			SequenceNumber:      seqNum,
+		}}
+	}
+	msg1, msg2, msg3 := msg(1, 1, 100), msg(2, 1, 101), msg(3, 1, 102)
+
+	cache := NewLeafHashCache(2)
+	cache.Add(msg1, [32]byte{11})
+	cache.Add(msg2, [32]byte{12})
+
+	leaf, ok := cache.Get(msg1)
+	require.True(t, ok)
+	require.Equal(t, [32]byte{11}, leaf)
+
+	// Mismatching metadata is a cache miss.
+	_, ok = cache.Get(msg(1, 2, 100))
+	require.False(t, ok)
+	_, ok = cache.Get(msg(1, 1, 101))
+	require.False(t, ok)
+
+	// msg2 is the least recently used leaf and is evicted.
+	cache.Add(msg3, [32]byte{13})
+	require.Equal(t, 2, cache.Len())
+	_, ok = cache.Get(msg2)
+	require.False(t, ok)
+	_, ok = cache.Get(msg1)
+	require.True(t, ok)
+	_, ok = cache.Get(msg3)
+	require.True(t, ok)
+}
+
+func TestLeafHashCache_Nil(t *testing.T) {
+	var cache *LeafHashCache
+	msg := cciptypes.Message{Header: cciptypes.RampMessageHeader{MessageID: cciptypes.Bytes32{1}}}
+	cache.Add(msg, [32]byte{1})
+	_, ok := cache.Get(msg)
+	require.False(t, ok)
+	require.Equal(t, 0, cache.Len())
+}
+
+func TestGetVerifiedMerkleTree_RecomputesRoot(t *testing.T) {
+	ctx := context.Background()
+	hasher := &countingHasher{MessageHasher: mocks.NewMessageHasher()}
+	commitReport := makeTestCommitReport(hasher, 4, 1, 100, 999, 10101010101,
+		cciptypes.Bytes{}, cciptypes.Bytes32{}, nil)
+	hasher.count.Store(0)
+
+	cache := NewLeafHashCache(10)
+	tree, err := getVerifiedMerkleTree(ctx, logger.Test(t), hasher, cache, nil, commitReport)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte(commitReport.MerkleRoot), tree.Root())
+	require.Equal(t, 4, cache.Len())
+	require.Equal(t, int64(4), hasher.count.Load())
+
+	// All the leaves are cached, the root is recomputed without hashing the messages.
+	tree, err = getVerifiedMerkleTree(ctx, logger.Test(t), hasher, cache, nil, commitReport)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte(commitReport.MerkleRoot), tree.Root())
+	require.Equal(t, int64(4), hasher.count.Load())
+
+	// A bad cached leaf does not produce a tree, the messages are rehashed and the cache is fixed.
+	cache.Add(commitReport.Messages[2], [32]byte{0xff})
+	tree, err = getVerifiedMerkleTree(ctx, logger.Test(t), hasher, cache, nil, commitReport)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte(commitReport.MerkleRoot), tree.Root())
+	require.Equal(t, int64(8), hasher.count.Load())
+	leaf, ok := cache.Get(commitReport.Messages[2])
+	require.True(t, ok)
+	require.NotEqual(t, [32]byte{0xff}, leaf)
+
+	// Messages which do not match the committed root are rejected, even with all the leaves cached.
+	badReport := commitReport
+	badReport.MerkleRoot = cciptypes.Bytes32{0xaa}
+	_, err = getVerifiedMerkleTree(ctx, logger.Test(t), hasher, cache, nil, badReport)
+	require.ErrorContains(t, err, "merkle root mismatch")
+}
+
+func TestLeafHashCache_ReusedAcrossBuilders(t *testing.T) {
+	hasher := &countingHasher{MessageHasher: mocks.NewMessageHasher()}
+	sender, err := cciptypes.NewBytesFromString(randomAddress())
+	require.NoError(t, err)
+	commitReport := makeTestCommitReport(hasher, 10, 1, 100, 999, 10101010101,
+		sender, cciptypes.Bytes32{}, nil)
+	hasher.count.Store(0)
+
+	cache := NewLeafHashCache(10)
+	newBuilder := func(nonce uint64) ExecReportBuilder {
+		nonces := map[cciptypes.ChainSelector]map[string]uint64{1: {sender.String(): nonce}}
+		return NewBuilder(
+			logger.Test(t),
+			hasher,
+			cache,
+			nil,
+			mocks.NewExecutePluginJSONReportCodec(),
+			evm.EstimateProvider{},
+			nonces,
+			1,
+			Limits{
+				// Only part of the report fits, so the leaves are needed for several proofs.
+				MaxReportSizeBytes: 2654,
+				MaxGas:             10000000,
+			},
+		)
+	}
+
+	builder := newBuilder(0)
+	updated, err := builder.Add(context.Background(), commitReport)
+	require.NoError(t, err)
+	require.Len(t, updated.ExecutedMessages, 5)
+	require.Equal(t, int64(10), hasher.count.Load(), "each message should be hashed once")
+
+	// The next round reuses the cached leaves.
+	builder = newBuilder(5)
+	updated, err = builder.Add(context.Background(), updated)
+	require.NoError(t, err)
+	require.Len(t, updated.ExecutedMessages, 10)
+	require.Equal(t, int64(10), hasher.count.Load(), "cached leaves should be reused")
+}
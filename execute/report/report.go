@@ -8,6 +8,7 @@ import (
 	"slices"
 
 	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/merklemulti"
 
 	"github.com/goplugin/plugin-ccip/execute/exectypes"
 	"github.com/goplugin/plugin-ccip/internal/libs/slicelib"
@@ -21,11 +22,15 @@ import (
 //
 // Before calling this function all messages should have been checked and processed by the checkMessage function.
 //
-// The hasher and encoding codec are provided as arguments to allow for chain-specific formats to be used.
+// The hasher and encoding codec are provided as arguments to allow for chain-specific formats to be used. When a
+// leafCache is provided, previously verified message leaf hashes are reused instead of rehashing the messages. When
+// a treeCache is provided, previously verified merkle trees are reused to generate the proofs.
 func buildSingleChainReportHelper(
 	ctx context.Context,
 	lggr logger.Logger,
 	hasher cciptypes.MessageHasher,
+	leafCache *LeafHashCache,
+	treeCache *TreeCache,
 	report exectypes.CommitData,
 	readyMessages map[int]struct{},
 ) (cciptypes.ExecutePluginReportSingleChain, error) {
@@ -49,31 +54,11 @@ func buildSingleChainReportHelper(
 			fmt.Errorf("token data length mismatch: got %d, expected %d", len(report.MessageTokenData), numMsg)
 	}
 
-	lggr.Infow(
-		"constructing merkle tree",
-		"sourceChain", report.SourceChain,
-		"expectedRoot", report.MerkleRoot.String(),
-		"treeLeaves", len(report.Messages))
-
-	tree, err := ConstructMerkleTree(ctx, hasher, report, lggr)
+	tree, err := getVerifiedMerkleTree(ctx, lggr, hasher, leafCache, treeCache, report)
 	if err != nil {
-		return cciptypes.ExecutePluginReportSingleChain{},
-			fmt.Errorf("unable to construct merkle tree from messages for report (%s): %w", report.MerkleRoot.String(), err)
-	}
-
-	// Verify merkle root.
-	hash := tree.Root()
-	if !bytes.Equal(hash[:], report.MerkleRoot[:]) {
-		actualStr := "0x" + hex.EncodeToString(hash[:])
-		return cciptypes.ExecutePluginReportSingleChain{},
-			fmt.Errorf("merkle root mismatch: expected %s, got %s", report.MerkleRoot.String(), actualStr)
+		return cciptypes.ExecutePluginReportSingleChain{}, err
 	}
 
-	lggr.Debugw("merkle root verified",
-		"sourceChain", report.SourceChain,
-		"commitRoot", report.MerkleRoot.String(),
-		"computedRoot", cciptypes.Bytes32(hash))
-
 	// Iterate sequence range and executed messages to select messages to execute.
 	numMsgs := len(report.Messages)
 	var toExecute []int
@@ -120,6 +105,87 @@ func buildSingleChainReportHelper(
 	return finalReport, nil
 }
 
+// getVerifiedMerkleTree returns the merkle tree of the commit report, verified against the committed merkle root. The
+// leaves are taken from the cache where possible. A cached tree is reused when it was verified against the same root
+// and built from the same leaves, otherwise the root is recomputed and compared. If the root does not match with
+// cached leaves, the tree is rebuilt from freshly hashed messages before giving up.
+func getVerifiedMerkleTree(
+	ctx context.Context,
+	lggr logger.Logger,
+	hasher cciptypes.MessageHasher,
+	leafCache *LeafHashCache,
+	treeCache *TreeCache,
+	report exectypes.CommitData,
+) (*merklemulti.Tree[[32]byte], error) {
+	lggr.Infow(
+		"constructing merkle tree",
+		"sourceChain", report.SourceChain,
+		"expectedRoot", report.MerkleRoot.String(),
+		"treeLeaves", len(report.Messages))
+
+	tree, leaves, cached, err := constructVerifiedMerkleTree(ctx, lggr, hasher, leafCache, treeCache, report)
+	if err != nil && cached > 0 {
+		lggr.Warnw("merkle tree with cached leaves could not be verified, rehashing all messages",
+			"sourceChain", report.SourceChain,
+			"commitRoot", report.MerkleRoot.String(),
+			"cachedLeaves", cached,
+			"err", err)
+		tree, leaves, _, err = constructVerifiedMerkleTree(ctx, lggr, hasher, nil, treeCache, report)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lggr.Debugw("merkle root verified",
+		"sourceChain", report.SourceChain,
+		"commitRoot", report.MerkleRoot.String(),
+		"cachedLeaves", cached)
+
+	for i, msg := range report.Messages {
+		leafCache.Add(msg, leaves[i])
+	}
+	treeCache.Add(report.MerkleRoot, leaves, tree)
+	return tree, nil
+}
+
+// constructVerifiedMerkleTree constructs the merkle tree of the commit report and verifies its root. It returns the
+// tree, its leaves and the number of leaves taken from the leafCache. A tree found in the treeCache for the same root
+// and leaves has already been verified and is returned as is.
+func constructVerifiedMerkleTree(
+	ctx context.Context,
+	lggr logger.Logger,
+	hasher cciptypes.MessageHasher,
+	leafCache *LeafHashCache,
+	treeCache *TreeCache,
+	report exectypes.CommitData,
+) (*merklemulti.Tree[[32]byte], [][32]byte, int, error) {
+	leaves, cached, err := hashTreeLeaves(ctx, hasher, leafCache, report, lggr)
+	if err != nil {
+		return nil, nil, 0,
+			fmt.Errorf("unable to construct merkle tree from messages for report (%s): %w", report.MerkleRoot.String(), err)
+	}
+
+	if tree, ok := treeCache.Get(report.MerkleRoot, leaves); ok {
+		return tree, leaves, cached, nil
+	}
+
+	tree, err := newMerkleTree(leaves)
+	if err != nil {
+		return nil, nil, cached,
+			fmt.Errorf("unable to construct merkle tree from messages for report (%s): %w", report.MerkleRoot.String(), err)
+	}
+
+	// Verify merkle root.
+	hash := tree.Root()
+	if !bytes.Equal(hash[:], report.MerkleRoot[:]) {
+		actualStr := "0x" + hex.EncodeToString(hash[:])
+		return nil, nil, cached,
+			fmt.Errorf("merkle root mismatch: expected %s, got %s", report.MerkleRoot.String(), actualStr)
+	}
+
+	return tree, leaves, cached, nil
+}
+
 type messageStatus string
 
 const (
@@ -133,6 +199,7 @@ const (
 	MissingNonce                  messageStatus = "missing_nonce"
 	InvalidNonce                  messageStatus = "invalid_nonce"
 	TooCostly                     messageStatus = "tooCostly"
+	ReportLimitsExceeded          messageStatus = "report_limits_exceeded"
 	/*
 		SenderAlreadySkipped                 messageStatus = "sender_already_skipped"
 		MessageMaxGasCalcError               messageStatus = "message_max_gas_calc_error"
@@ -288,9 +355,46 @@ func (b *execReportBuilder) verifyReport(
 		return false, validationMetadata{}, fmt.Errorf("unable to encode report: %w", err)
 	}
 
-	maxSizeBytes := int(b.maxReportSizeBytes - b.accumulated.encodedSizeBytes)
-	if len(encoded) > maxSizeBytes {
-		b.lggr.Infow("invalid report, report size exceeds limit", "size", len(encoded), "maxSize", maxSizeBytes)
+	return b.verifyReportLimits(execReport, uint64(len(encoded)))
+}
+
+// verifyReportLimits checks a report of the given encoded size against the remaining report limits.
+func (b *execReportBuilder) verifyReportLimits(
+	execReport cciptypes.ExecutePluginReportSingleChain,
+	encodedSizeBytes uint64,
+) (bool, validationMetadata, error) {
+	maxSizeBytes := b.limits.MaxReportSizeBytes - b.accumulated.encodedSizeBytes
+	if encodedSizeBytes > maxSizeBytes {
+		b.lggr.Infow("invalid report, report size exceeds limit", "size", encodedSizeBytes, "maxSize", maxSizeBytes)
+		return false, validationMetadata{}, nil
+	}
+
+	numMessages := uint64(len(execReport.Messages))
+	if b.limits.MaxMessages != 0 && numMessages > b.limits.MaxMessages-b.accumulated.messages {
+		b.lggr.Infow("invalid report, number of messages exceeds limit",
+			"messages", numMessages, "maxMessages", b.limits.MaxMessages-b.accumulated.messages)
+		return false, validationMetadata{}, nil
+	}
+
+	tokenTransfers := uint64(0)
+	messageDataBytes := uint64(0)
+	for _, msg := range execReport.Messages {
+		tokenTransfers += uint64(len(msg.TokenAmounts))
+		messageDataBytes += uint64(len(msg.Data))
+	}
+
+	if b.limits.MaxTokenTransfers != 0 && tokenTransfers > b.limits.MaxTokenTransfers-b.accumulated.tokenTransfers {
+		b.lggr.Infow("invalid report, number of token transfers exceeds limit",
+			"tokenTransfers", tokenTransfers,
+			"maxTokenTransfers", b.limits.MaxTokenTransfers-b.accumulated.tokenTransfers)
+		return false, validationMetadata{}, nil
+	}
+
+	if b.limits.MaxMessageDataBytes != 0 &&
+		messageDataBytes > b.limits.MaxMessageDataBytes-b.accumulated.messageDataBytes {
+		b.lggr.Infow("invalid report, message data size exceeds limit",
+			"messageDataBytes", messageDataBytes,
+			"maxMessageDataBytes", b.limits.MaxMessageDataBytes-b.accumulated.messageDataBytes)
 		return false, validationMetadata{}, nil
 	}
 
@@ -305,15 +409,18 @@ func (b *execReportBuilder) verifyReport(
 	merkleTreeGas := b.estimateProvider.CalculateMerkleTreeGas(len(execReport.Messages))
 	totalGas := gasSum + merkleTreeGas
 
-	maxGas := b.maxGas - b.accumulated.gas
+	maxGas := b.limits.MaxGas - b.accumulated.gas
 	if totalGas > maxGas {
 		b.lggr.Infow("invalid report, report estimated gas usage exceeds limit", "gas", totalGas, "maxGas", maxGas)
 		return false, validationMetadata{}, nil
 	}
 
 	return true, validationMetadata{
-		encodedSizeBytes: uint64(len(encoded)),
+		encodedSizeBytes: encodedSizeBytes,
 		gas:              totalGas,
+		messages:         numMessages,
+		tokenTransfers:   tokenTransfers,
+		messageDataBytes: messageDataBytes,
 	}, nil
 }
 
@@ -345,8 +452,12 @@ func (b *execReportBuilder) buildSingleChainReport(
 				fmt.Errorf("unable to check message: %w", err)
 		}
 		report = updatedReport
-		if status == ReadyToExecute {
+		switch status {
+		case ReadyToExecute:
 			readyMessages[i] = struct{}{}
+		case AlreadyExecuted:
+		default:
+			b.recordMessageStatus(report.SourceChain, report.Messages[i], status)
 		}
 	}
 
@@ -354,25 +465,113 @@ func (b *execReportBuilder) buildSingleChainReport(
 		return cciptypes.ExecutePluginReportSingleChain{}, report, ErrEmptyReport
 	}
 
+	// Search for the largest subset of messages which fits. If the codec can estimate report sizes the search is done
+	// with estimates and only the selected report is encoded. Should the estimate turn out too small, the search is
+	// repeated with fully encoded reports.
+	var finalReport cciptypes.ExecutePluginReportSingleChain
+	var meta validationMetadata
+	var msgs map[int]struct{}
+	var err error
+	if estimator, ok := b.encoder.(cciptypes.ExecutePluginReportSizeEstimator); ok {
+		finalReport, msgs, _, err = b.searchLargestReport(ctx, report, readyMessages, estimator)
+		if err != nil {
+			return cciptypes.ExecutePluginReportSingleChain{}, exectypes.CommitData{}, err
+		}
+		if len(msgs) > 0 {
+			var validReport bool
+			validReport, meta, err = b.verifyReport(ctx, finalReport)
+			if err != nil {
+				return cciptypes.ExecutePluginReportSingleChain{},
+					exectypes.CommitData{},
+					fmt.Errorf("unable to verify report: %w", err)
+			}
+			if !validReport {
+				b.lggr.Warnw("report size estimate was too small, searching with encoded reports",
+					"sourceChain", report.SourceChain,
+					"numMessages", len(msgs))
+				msgs = nil
+			}
+		}
+	}
+
+	if msgs == nil {
+		finalReport, msgs, meta, err = b.searchLargestReport(ctx, report, readyMessages, nil)
+		if err != nil {
+			return cciptypes.ExecutePluginReportSingleChain{}, exectypes.CommitData{}, err
+		}
+	}
+
+	for i := range report.Messages {
+		_, ready := readyMessages[i]
+		_, selected := msgs[i]
+		if ready && !selected {
+			b.recordMessageStatus(report.SourceChain, report.Messages[i], ReportLimitsExceeded)
+		}
+	}
+
+	if len(msgs) == 0 {
+		return cciptypes.ExecutePluginReportSingleChain{}, report, ErrEmptyReport
+	}
+
+	return finalize(finalReport, report, meta)
+}
+
+// searchLargestReport selects the ready messages to include in the report. All ready messages are tried first, if
+// they do not fit messages are greedily added, in order, as long as the report satisfies the report limits. When an
+// estimator is provided, report sizes are predicted incrementally instead of encoding every candidate report, and
+// the returned metadata must not be used. The selection is empty if no message fits.
+func (b *execReportBuilder) searchLargestReport(
+	ctx context.Context,
+	report exectypes.CommitData,
+	readyMessages map[int]struct{},
+	estimator cciptypes.ExecutePluginReportSizeEstimator,
+) (cciptypes.ExecutePluginReportSingleChain, map[int]struct{}, validationMetadata, error) {
+	verify := func(
+		candidate cciptypes.ExecutePluginReportSingleChain, msgsSize int,
+	) (bool, validationMetadata, error) {
+		if estimator == nil {
+			return b.verifyReport(ctx, candidate)
+		}
+		size := msgsSize + len(candidate.Proofs)*estimator.EstimateProofSize()
+		return b.verifyReportLimits(candidate, uint64(size))
+	}
+	messageSize := func(i int) int {
+		if estimator == nil {
+			return 0
+		}
+		return estimator.EstimateMessageSize(report.Messages[i], report.MessageTokenData[i].ToByteSlice())
+	}
+
+	var overhead int
+	if estimator != nil {
+		overhead = estimator.EstimateChainReportOverhead()
+	}
+
 	// Attempt to include all messages in the report.
-	finalReport, err :=
-		buildSingleChainReportHelper(ctx, b.lggr, b.hasher, report, readyMessages)
+	allSize := overhead
+	for i := range readyMessages {
+		allSize += messageSize(i)
+	}
+	allReport, err :=
+		buildSingleChainReportHelper(ctx, b.lggr, b.hasher, b.leafCache, b.treeCache, report, readyMessages)
 	if err != nil {
 		return cciptypes.ExecutePluginReportSingleChain{},
-			exectypes.CommitData{},
+			nil,
+			validationMetadata{},
 			fmt.Errorf("unable to build a single chain report (max): %w", err)
 	}
 
-	validReport, meta, err := b.verifyReport(ctx, finalReport)
+	validReport, meta, err := verify(allReport, allSize)
 	if err != nil {
-		return cciptypes.ExecutePluginReportSingleChain{},
-			exectypes.CommitData{},
+		return cciptypes.ExecutePluginReportSingleChain{}, nil, validationMetadata{},
 			fmt.Errorf("unable to verify report: %w", err)
 	} else if validReport {
-		return finalize(finalReport, report, meta)
+		return allReport, readyMessages, meta, nil
 	}
 
-	finalReport = cciptypes.ExecutePluginReportSingleChain{}
+	var finalReport cciptypes.ExecutePluginReportSingleChain
+	meta = validationMetadata{}
+	msgsSize := overhead
 	msgs := make(map[int]struct{})
 	for i := range report.Messages {
 		if _, ok := readyMessages[i]; !ok {
@@ -381,30 +580,32 @@ func (b *execReportBuilder) buildSingleChainReport(
 
 		msgs[i] = struct{}{}
 
-		finalReport2, err := buildSingleChainReportHelper(ctx, b.lggr, b.hasher, report, msgs)
+		candidate, err := buildSingleChainReportHelper(ctx, b.lggr, b.hasher, b.leafCache, b.treeCache, report, msgs)
 		if err != nil {
 			return cciptypes.ExecutePluginReportSingleChain{},
-				exectypes.CommitData{},
+				nil,
+				validationMetadata{},
 				fmt.Errorf("unable to build a single chain report (messages %d): %w", len(msgs), err)
 		}
 
-		validReport, meta2, err := b.verifyReport(ctx, finalReport2)
+		msgSize := messageSize(i)
+		validReport, candidateMeta, err := verify(candidate, msgsSize+msgSize)
 		if err != nil {
 			return cciptypes.ExecutePluginReportSingleChain{},
-				exectypes.CommitData{},
+				nil,
+				validationMetadata{},
 				fmt.Errorf("unable to verify report: %w", err)
-		} else if validReport {
-			finalReport = finalReport2
-			meta = meta2
+		}
+
+		if validReport {
+			finalReport = candidate
+			meta = candidateMeta
+			msgsSize += msgSize
 		} else {
 			// this message didn't work, continue to the next one
 			delete(msgs, i)
 		}
 	}
 
-	if len(msgs) == 0 {
-		return cciptypes.ExecutePluginReportSingleChain{}, report, ErrEmptyReport
-	}
-
-	return finalize(finalReport, report, meta)
+	return finalReport, msgs, meta, nil
 }
@@ -293,6 +293,16 @@ func setMessageData(
 	return commitReport
 }
 
+func setData(msg cciptypes.Message, size uint64) cciptypes.Message {
+	msg.Data = make([]byte, size)
+	return msg
+}
+
+func setTokenAmounts(msg cciptypes.Message, numTokens int) cciptypes.Message {
+	msg.TokenAmounts = make([]cciptypes.RampTokenAmount, numTokens)
+	return msg
+}
+
 type badHasher struct{}
 
 func (bh badHasher) Hash(context.Context, cciptypes.Message) (cciptypes.Bytes32, error) {
@@ -421,7 +431,7 @@ func Test_buildSingleChainReport_Errors(t *testing.T) {
 			}
 
 			test := func(readyMessages map[int]struct{}) {
-				_, err := buildSingleChainReportHelper(ctx, lggr, resolvedHasher, tt.args.report, readyMessages)
+				_, err := buildSingleChainReportHelper(ctx, lggr, resolvedHasher, nil, nil, tt.args.report, readyMessages)
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.wantErr)
 			}
@@ -454,10 +464,11 @@ func Test_Builder_Build(t *testing.T) {
 	}
 
 	type args struct {
-		reports       []exectypes.CommitData
-		nonces        map[cciptypes.ChainSelector]map[string]uint64
-		maxReportSize uint64
-		maxGasLimit   uint64
+		reports         []exectypes.CommitData
+		nonces          map[cciptypes.ChainSelector]map[string]uint64
+		maxReportSize   uint64
+		maxGasLimit     uint64
+		maxSourceChains uint64
 	}
 	tests := []struct {
 		name                  string
@@ -466,6 +477,7 @@ func Test_Builder_Build(t *testing.T) {
 		expectedCommitReports int
 		expectedExecThings    []int
 		lastReportExecuted    []cciptypes.SeqNum
+		expectedStatuses      map[cciptypes.SeqNum]messageStatus
 		wantErr               string
 	}{
 		{
@@ -493,6 +505,13 @@ func Test_Builder_Build(t *testing.T) {
 			expectedCommitReports: 1,
 			expectedExecThings:    []int{5},
 			lastReportExecuted:    []cciptypes.SeqNum{100, 101, 102, 103, 104},
+			expectedStatuses: map[cciptypes.SeqNum]messageStatus{
+				105: ReportLimitsExceeded,
+				106: ReportLimitsExceeded,
+				107: ReportLimitsExceeded,
+				108: ReportLimitsExceeded,
+				109: ReportLimitsExceeded,
+			},
 		},
 		{
 			name: "full report",
@@ -532,6 +551,28 @@ func Test_Builder_Build(t *testing.T) {
 			expectedCommitReports: 0,
 			expectedExecThings:    []int{10, 20},
 		},
+		{
+			name: "two reports, max one source chain",
+			args: args{
+				maxReportSize:   15000,
+				maxGasLimit:     10000000,
+				maxSourceChains: 1,
+				nonces:          defaultNonces,
+				reports: []exectypes.CommitData{
+					makeTestCommitReport(hasher, 10, 1, 100, 999, 10101010101,
+						sender,
+						cciptypes.Bytes32{}, // generate a correct root.
+						nil),
+					makeTestCommitReport(hasher, 20, 2, 100, 999, 10101010101,
+						sender,
+						cciptypes.Bytes32{}, // generate a correct root.
+						nil),
+				},
+			},
+			expectedExecReports:   1,
+			expectedCommitReports: 1,
+			expectedExecThings:    []int{10},
+		},
 		{
 			name: "one and half reports",
 			args: args{
@@ -716,6 +757,9 @@ func Test_Builder_Build(t *testing.T) {
 			expectedCommitReports: 0,
 			expectedExecThings:    []int{9},
 			lastReportExecuted:    []cciptypes.SeqNum{100, 101, 102, 103, 104, 106, 107, 108, 109},
+			expectedStatuses: map[cciptypes.SeqNum]messageStatus{
+				105: ReportLimitsExceeded,
+			},
 		},
 		{
 			name: "skip over two large messages",
@@ -749,12 +793,17 @@ func Test_Builder_Build(t *testing.T) {
 			builder := NewBuilder(
 				lggr,
 				hasher,
+				NewLeafHashCache(10),
+				NewTreeCache(10),
 				codec,
 				evm.EstimateProvider{},
 				tt.args.nonces,
 				1,
-				tt.args.maxReportSize,
-				tt.args.maxGasLimit,
+				Limits{
+					MaxReportSizeBytes: tt.args.maxReportSize,
+					MaxGas:             tt.args.maxGasLimit,
+					MaxSourceChains:    tt.args.maxSourceChains,
+				},
 			)
 
 			var updatedMessages []exectypes.CommitData
@@ -793,6 +842,13 @@ func Test_Builder_Build(t *testing.T) {
 				lastReport := updatedMessages[len(updatedMessages)-1]
 				require.ElementsMatch(t, tt.lastReportExecuted, lastReport.ExecutedMessages)
 			}
+			if tt.expectedStatuses != nil {
+				statuses := make(map[cciptypes.SeqNum]messageStatus)
+				for _, status := range builder.MessageStatuses() {
+					statuses[status.SeqNum] = messageStatus(status.Status)
+				}
+				require.Equal(t, tt.expectedStatuses, statuses)
+			}
 		})
 	}
 }
@@ -809,11 +865,10 @@ func (bc badCodec) Decode(ctx context.Context, bytes []byte) (cciptypes.ExecuteP
 
 func Test_execReportBuilder_verifyReport(t *testing.T) {
 	type fields struct {
-		encoder            cciptypes.ExecutePluginCodec
-		estimateProvider   gas.EstimateProvider
-		maxReportSizeBytes uint64
-		maxGas             uint64
-		accumulated        validationMetadata
+		encoder          cciptypes.ExecutePluginCodec
+		estimateProvider gas.EstimateProvider
+		limits           Limits
+		accumulated      validationMetadata
 	}
 	type args struct {
 		execReport cciptypes.ExecutePluginReportSingleChain
@@ -833,9 +888,11 @@ func Test_execReportBuilder_verifyReport(t *testing.T) {
 				execReport: cciptypes.ExecutePluginReportSingleChain{},
 			},
 			fields: fields{
-				estimateProvider:   evm.EstimateProvider{},
-				maxReportSizeBytes: 1000,
-				maxGas:             1000000,
+				estimateProvider: evm.EstimateProvider{},
+				limits: Limits{
+					MaxReportSizeBytes: 1000,
+					MaxGas:             1000000,
+				},
 			},
 			expectedIsValid: true,
 			expectedMetadata: validationMetadata{
@@ -855,14 +912,110 @@ func Test_execReportBuilder_verifyReport(t *testing.T) {
 				},
 			},
 			fields: fields{
-				estimateProvider:   evm.EstimateProvider{},
-				maxReportSizeBytes: 10000,
-				maxGas:             1000000,
+				estimateProvider: evm.EstimateProvider{},
+				limits: Limits{
+					MaxReportSizeBytes: 10000,
+					MaxGas:             1000000,
+				},
 			},
 			expectedIsValid: true,
 			expectedMetadata: validationMetadata{
 				encodedSizeBytes: 1717,
 				gas:              482_240,
+				messages:         4,
+			},
+		},
+		{
+			name: "too many messages",
+			args: args{
+				execReport: cciptypes.ExecutePluginReportSingleChain{
+					Messages: []cciptypes.Message{
+						makeMessage(1, 100, 0),
+						makeMessage(1, 101, 0),
+						makeMessage(1, 102, 0),
+					},
+				},
+			},
+			fields: fields{
+				estimateProvider: evm.EstimateProvider{},
+				accumulated: validationMetadata{
+					messages: 2,
+				},
+				limits: Limits{
+					MaxReportSizeBytes: 10000,
+					MaxGas:             1000000,
+					MaxMessages:        4,
+				},
+			},
+			expectedLog: "invalid report, number of messages exceeds limit",
+		},
+		{
+			name: "too many token transfers",
+			args: args{
+				execReport: cciptypes.ExecutePluginReportSingleChain{
+					Messages: []cciptypes.Message{
+						setTokenAmounts(makeMessage(1, 100, 0), 2),
+						setTokenAmounts(makeMessage(1, 101, 0), 2),
+					},
+				},
+			},
+			fields: fields{
+				estimateProvider: evm.EstimateProvider{},
+				limits: Limits{
+					MaxReportSizeBytes: 10000,
+					MaxGas:             1000000,
+					MaxTokenTransfers:  3,
+				},
+			},
+			expectedLog: "invalid report, number of token transfers exceeds limit",
+		},
+		{
+			name: "too much message data",
+			args: args{
+				execReport: cciptypes.ExecutePluginReportSingleChain{
+					Messages: []cciptypes.Message{
+						setData(makeMessage(1, 100, 0), 100),
+						setData(makeMessage(1, 101, 0), 100),
+					},
+				},
+			},
+			fields: fields{
+				estimateProvider: evm.EstimateProvider{},
+				limits: Limits{
+					MaxReportSizeBytes:  10000,
+					MaxGas:              1000000,
+					MaxMessageDataBytes: 150,
+				},
+			},
+			expectedLog: "invalid report, message data size exceeds limit",
+		},
+		{
+			name: "within all limits",
+			args: args{
+				execReport: cciptypes.ExecutePluginReportSingleChain{
+					Messages: []cciptypes.Message{
+						setData(setTokenAmounts(makeMessage(1, 100, 0), 1), 10),
+						setData(setTokenAmounts(makeMessage(1, 101, 0), 2), 20),
+					},
+				},
+			},
+			fields: fields{
+				estimateProvider: evm.EstimateProvider{},
+				limits: Limits{
+					MaxReportSizeBytes:  10000,
+					MaxGas:              10000000,
+					MaxMessages:         2,
+					MaxTokenTransfers:   3,
+					MaxMessageDataBytes: 30,
+				},
+			},
+			expectedIsValid: true,
+			expectedMetadata: validationMetadata{
+				encodedSizeBytes: 1277,
+				gas:              1_300_564,
+				messages:         2,
+				tokenTransfers:   3,
+				messageDataBytes: 30,
 			},
 		},
 		{
@@ -878,9 +1031,11 @@ func Test_execReportBuilder_verifyReport(t *testing.T) {
 				},
 			},
 			fields: fields{
-				estimateProvider:   evm.EstimateProvider{},
-				maxReportSizeBytes: 1000,
-				maxGas:             1000000,
+				estimateProvider: evm.EstimateProvider{},
+				limits: Limits{
+					MaxReportSizeBytes: 1000,
+					MaxGas:             1000000,
+				},
 			},
 			expectedLog: "invalid report, report size exceeds limit",
 		},
@@ -901,8 +1056,10 @@ func Test_execReportBuilder_verifyReport(t *testing.T) {
 				accumulated: validationMetadata{
 					encodedSizeBytes: 1000,
 				},
-				maxReportSizeBytes: 2000,
-				maxGas:             1000000,
+				limits: Limits{
+					MaxReportSizeBytes: 2000,
+					MaxGas:             1000000,
+				},
 			},
 			expectedLog: "invalid report, report size exceeds limit",
 		},
@@ -939,12 +1096,11 @@ func Test_execReportBuilder_verifyReport(t *testing.T) {
 			}
 
 			b := &execReportBuilder{
-				lggr:               lggr,
-				encoder:            resolvedEncoder,
-				estimateProvider:   tt.fields.estimateProvider,
-				maxReportSizeBytes: tt.fields.maxReportSizeBytes,
-				maxGas:             tt.fields.maxGas,
-				accumulated:        tt.fields.accumulated,
+				lggr:             lggr,
+				encoder:          resolvedEncoder,
+				estimateProvider: tt.fields.estimateProvider,
+				limits:           tt.fields.limits,
+				accumulated:      tt.fields.accumulated,
 			}
 			isValid, metadata, err := b.verifyReport(context.Background(), tt.args.execReport)
 			if tt.expectedError != "" {
@@ -19,28 +19,57 @@ func ConstructMerkleTree(
 	report exectypes.CommitData,
 	lggr logger.Logger,
 ) (*merklemulti.Tree[[32]byte], error) {
+	treeLeaves, _, err := hashTreeLeaves(ctx, hasher, nil, report, lggr)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMerkleTree(treeLeaves)
+}
+
+// newMerkleTree creates the merkle tree object from the message leaf hashes.
+func newMerkleTree(treeLeaves [][32]byte) (*merklemulti.Tree[[32]byte], error) {
+	// TODO: Do not hard code the hash function, it should be derived from the message hasher.
+	return merklemulti.NewTree(hashutil.NewKeccak(), treeLeaves)
+}
+
+// hashTreeLeaves hashes the messages in the report into merkle tree leaves. Leaves found in the leafCache are not
+// rehashed, the number of leaves taken from the cache is returned.
+func hashTreeLeaves(
+	ctx context.Context,
+	hasher cciptypes.MessageHasher,
+	leafCache *LeafHashCache,
+	report exectypes.CommitData,
+	lggr logger.Logger,
+) ([][32]byte, int, error) {
 	// Ensure we have the expected number of messages
 	numMsgs := int(report.SequenceNumberRange.End() - report.SequenceNumberRange.Start() + 1)
 	if numMsgs != len(report.Messages) {
-		return nil, fmt.Errorf(
+		return nil, 0, fmt.Errorf(
 			"malformed report %s, unexpected number of messages: expected %d, got %d",
 			report.MerkleRoot.String(), numMsgs, len(report.Messages))
 	}
 
-	treeLeaves := make([][32]byte, 0)
+	treeLeaves := make([][32]byte, 0, numMsgs)
+	cached := 0
 	for _, msg := range report.Messages {
 		if !report.SequenceNumberRange.Contains(msg.Header.SequenceNumber) {
-			return nil, fmt.Errorf(
+			return nil, 0, fmt.Errorf(
 				"malformed report, message %s sequence number %d outside of report range %s",
 				report.MerkleRoot.String(), msg.Header.SequenceNumber, report.SequenceNumberRange)
 		}
 		if report.SourceChain != msg.Header.SourceChainSelector {
-			return nil, fmt.Errorf("malformed report, message %s for unexpected source chain: expected %d, got %d",
+			return nil, 0, fmt.Errorf("malformed report, message %s for unexpected source chain: expected %d, got %d",
 				report.MerkleRoot.String(), report.SourceChain, msg.Header.SourceChainSelector)
 		}
+		if leaf, ok := leafCache.Get(msg); ok {
+			treeLeaves = append(treeLeaves, leaf)
+			cached++
+			continue
+		}
 		leaf, err := hasher.Hash(ctx, msg)
 		if err != nil {
-			return nil, fmt.Errorf(
+			return nil, 0, fmt.Errorf(
 				"unable to hash message (%d, %d): %w",
 				msg.Header.SourceChainSelector, msg.Header.SequenceNumber, err)
 		}
@@ -52,6 +81,5 @@ func ConstructMerkleTree(
 		treeLeaves = append(treeLeaves, leaf)
 	}
 
-	// TODO: Do not hard code the hash function, it should be derived from the message hasher.
-	return merklemulti.NewTree(hashutil.NewKeccak(), treeLeaves)
+	return treeLeaves, cached, nil
 }
@@ -0,0 +1,185 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/merklemulti"
+
+	"github.com/goplugin/plugin-ccip/execute/exectypes"
+	"github.com/goplugin/plugin-ccip/execute/internal/gas/evm"
+	"github.com/goplugin/plugin-ccip/internal/mocks"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// estimatingJSONCodec is a JSON codec which implements ExecutePluginReportSizeEstimator and counts Encode calls.
+type estimatingJSONCodec struct {
+	mocks.ExecutePluginJSONReportCodec
+	encodeCalls atomic.Int64
+	// underestimate makes the estimator return sizes which are too small.
+	underestimate bool
+}
+
+func (c *estimatingJSONCodec) Encode(
+	ctx context.Context, report cciptypes.ExecutePluginReport,
+) ([]byte, error) {
+	c.encodeCalls.Add(1)
+	return c.ExecutePluginJSONReportCodec.Encode(ctx, report)
+}
+
+func (c *estimatingJSONCodec) EstimateChainReportOverhead() int {
+	maxFlags := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	encoded, err := json.Marshal(cciptypes.ExecutePluginReport{
+		ChainReports: []cciptypes.ExecutePluginReportSingleChain{{
+			SourceChainSelector: math.MaxUint64,
+			Messages:            []cciptypes.Message{},
+			OffchainTokenData:   [][][]byte{},
+			Proofs:              []cciptypes.Bytes32{},
+			ProofFlagBits:       cciptypes.BigInt{Int: maxFlags},
+		}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return len(encoded)
+}
+
+func (c *estimatingJSONCodec) EstimateMessageSize(msg cciptypes.Message, offchainTokenData [][]byte) int {
+	if c.underestimate {
+		return 1
+	}
+	encodedMsg, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	encodedTokenData, err := json.Marshal(offchainTokenData)
+	if err != nil {
+		panic(err)
+	}
+	// Each list element is followed by a separator.
+	return len(encodedMsg) + 1 + len(encodedTokenData) + 1
+}
+
+func (c *estimatingJSONCodec) EstimateProofSize() int {
+	encoded, err := json.Marshal(cciptypes.Bytes32{})
+	if err != nil {
+		panic(err)
+	}
+	return len(encoded) + 1
+}
+
+func newEstimatorTestBuilder(
+	codec cciptypes.ExecutePluginCodec,
+	sender cciptypes.Bytes,
+	maxReportSize uint64,
+) ExecReportBuilder {
+	return NewBuilder(
+		logger.Nop(),
+		mocks.NewMessageHasher(),
+		NewLeafHashCache(10*merklemulti.MaxNumberTreeLeaves),
+		NewTreeCache(10),
+		codec,
+		evm.EstimateProvider{},
+		map[cciptypes.ChainSelector]map[string]uint64{1: {sender.String(): 0}},
+		1,
+		Limits{
+			MaxReportSizeBytes: maxReportSize,
+			MaxGas:             1_000_000_000,
+		},
+	)
+}
+
+func makeLargeTestCommitReport(numMessages int, dataSize uint64, sender cciptypes.Bytes) exectypes.CommitData {
+	commitReport := makeTestCommitReport(mocks.NewMessageHasher(), numMessages, 1, 100, 999, 10101010101,
+		sender, cciptypes.Bytes32{}, nil)
+	for i := range commitReport.Messages {
+		commitReport.Messages[i].Data = make([]byte, dataSize)
+	}
+	return commitReport
+}
+
+func Test_Builder_SizeEstimator(t *testing.T) {
+	sender, err := cciptypes.NewBytesFromString(randomAddress())
+	require.NoError(t, err)
+	commitReport := makeLargeTestCommitReport(32, 100, sender)
+
+	exactCodec := mocks.NewExecutePluginJSONReportCodec()
+	exactBuilder := newEstimatorTestBuilder(exactCodec, sender, 10_000)
+	exactUpdated, err := exactBuilder.Add(context.Background(), commitReport)
+	require.NoError(t, err)
+	require.NotEmpty(t, exactUpdated.ExecutedMessages)
+	require.Less(t, len(exactUpdated.ExecutedMessages), len(commitReport.Messages))
+
+	t.Run("estimates", func(t *testing.T) {
+		codec := &estimatingJSONCodec{}
+		builder := newEstimatorTestBuilder(codec, sender, 10_000)
+		updated, err := builder.Add(context.Background(), commitReport)
+		require.NoError(t, err)
+
+		// Estimates are upper bounds, so at most the same messages are selected.
+		require.NotEmpty(t, updated.ExecutedMessages)
+		require.LessOrEqual(t, len(updated.ExecutedMessages), len(exactUpdated.ExecutedMessages))
+		// Only the selected report is encoded, candidates are estimated.
+		require.Equal(t, int64(1), codec.encodeCalls.Load())
+
+		reports, err := builder.Build()
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		encoded, err := codec.Encode(context.Background(), cciptypes.ExecutePluginReport{ChainReports: reports})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(encoded), 10_000)
+	})
+
+	t.Run("all messages fit", func(t *testing.T) {
+		codec := &estimatingJSONCodec{}
+		builder := newEstimatorTestBuilder(codec, sender, 1_000_000)
+		updated, err := builder.Add(context.Background(), commitReport)
+		require.NoError(t, err)
+		require.Len(t, updated.ExecutedMessages, len(commitReport.Messages))
+		require.Equal(t, int64(1), codec.encodeCalls.Load())
+	})
+
+	t.Run("underestimate falls back to encoding", func(t *testing.T) {
+		codec := &estimatingJSONCodec{underestimate: true}
+		builder := newEstimatorTestBuilder(codec, sender, 10_000)
+		updated, err := builder.Add(context.Background(), commitReport)
+		require.NoError(t, err)
+		require.Equal(t, exactUpdated.ExecutedMessages, updated.ExecutedMessages)
+	})
+}
+
+func Benchmark_Builder_LargeRoot(b *testing.B) {
+	sender, err := cciptypes.NewBytesFromString(randomAddress())
+	require.NoError(b, err)
+	commitReport := makeLargeTestCommitReport(256, 2_000, sender)
+
+	benchmarks := []struct {
+		name  string
+		codec func() cciptypes.ExecutePluginCodec
+	}{
+		{
+			name:  "encode",
+			codec: func() cciptypes.ExecutePluginCodec { return mocks.NewExecutePluginJSONReportCodec() },
+		},
+		{
+			name:  "estimate",
+			codec: func() cciptypes.ExecutePluginCodec { return &estimatingJSONCodec{} },
+		},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				builder := newEstimatorTestBuilder(bm.codec(), sender, 250_000)
+				_, err := builder.Add(context.Background(), commitReport)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package report
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+
+	"github.com/goplugin/plugin-common/pkg/merklemulti"
+
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// TreeCache is a bounded, least recently used cache of verified merkle trees keyed by their merkle root. It allows
+// proofs for partially executed commit reports to be generated without rebuilding the tree in every execute round
+// and for every candidate report considered while fitting a report into the limits. A tree is only returned when the
+// leaves of the report match the leaves it was built from. A nil *TreeCache is valid and never caches anything.
+type TreeCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[cciptypes.Bytes32]*list.Element
+	// lru is ordered from most recently used (front) to least recently used (back).
+	lru *list.List
+}
+
+type treeCacheEntry struct {
+	root   cciptypes.Bytes32
+	leaves [][32]byte
+	tree   *merklemulti.Tree[[32]byte]
+}
+
+// NewTreeCache creates a TreeCache which holds at most capacity merkle trees.
+func NewTreeCache(capacity int) *TreeCache {
+	return &TreeCache{
+		capacity: capacity,
+		entries:  make(map[cciptypes.Bytes32]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Get returns the tree cached for the merkle root if it was built from exactly the given leaves.
+func (c *TreeCache) Get(root cciptypes.Bytes32, leaves [][32]byte) (*merklemulti.Tree[[32]byte], bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[root]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*treeCacheEntry)
+	if !slices.Equal(entry.leaves, leaves) {
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry.tree, true
+}
+
+// Add stores a tree whose root was verified against the committed merkle root. If the cache is full the least
+// recently used tree is evicted.
+func (c *TreeCache) Add(root cciptypes.Bytes32, leaves [][32]byte, tree *merklemulti.Tree[[32]byte]) {
+	if c == nil || c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &treeCacheEntry{
+		root:   root,
+		leaves: slices.Clone(leaves),
+		tree:   tree,
+	}
+
+	if elem, ok := c.entries[root]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[root] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*treeCacheEntry).root)
+	}
+}
+
+// Len returns the number of cached trees.
+func (c *TreeCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
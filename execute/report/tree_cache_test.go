@@ -0,0 +1,84 @@
+package report
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/merklemulti"
+
+	"github.com/goplugin/plugin-ccip/internal/mocks"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+func TestTreeCache_GetAdd(t *testing.T) {
+	leaves := func(b byte) [][32]byte { return [][32]byte{{b}, {b + 1}} }
+	tree := func(l [][32]byte) *merklemulti.Tree[[32]byte] {
+		tr, err := newMerkleTree(l)
+		require.NoError(t, err)
+		return tr
+	}
+	root1, root2, root3 := cciptypes.Bytes32{1}, cciptypes.Bytes32{2}, cciptypes.Bytes32{3}
+	tree1, tree2, tree3 := tree(leaves(1)), tree(leaves(2)), tree(leaves(3))
+
+	cache := NewTreeCache(2)
+	cache.Add(root1, leaves(1), tree1)
+	cache.Add(root2, leaves(2), tree2)
+
+	cached, ok := cache.Get(root1, leaves(1))
+	require.True(t, ok)
+	require.Same(t, tree1, cached)
+
+	// Different leaves for the same root are a cache miss.
+	_, ok = cache.Get(root1, leaves(2))
+	require.False(t, ok)
+	_, ok = cache.Get(root1, leaves(1)[:1])
+	require.False(t, ok)
+
+	// root2 is the least recently used tree and is evicted.
+	cache.Add(root3, leaves(3), tree3)
+	require.Equal(t, 2, cache.Len())
+	_, ok = cache.Get(root2, leaves(2))
+	require.False(t, ok)
+	_, ok = cache.Get(root1, leaves(1))
+	require.True(t, ok)
+	_, ok = cache.Get(root3, leaves(3))
+	require.True(t, ok)
+}
+
+func TestTreeCache_Nil(t *testing.T) {
+	var cache *TreeCache
+	cache.Add(cciptypes.Bytes32{1}, [][32]byte{{1}}, nil)
+	_, ok := cache.Get(cciptypes.Bytes32{1}, [][32]byte{{1}})
+	require.False(t, ok)
+	require.Equal(t, 0, cache.Len())
+}
+
+func TestGetVerifiedMerkleTree_ReusesCachedTree(t *testing.T) {
+	ctx := context.Background()
+	hasher := &countingHasher{MessageHasher: mocks.NewMessageHasher()}
+	commitReport := makeTestCommitReport(hasher, 4, 1, 100, 999, 10101010101,
+		cciptypes.Bytes{}, cciptypes.Bytes32{}, nil)
+	hasher.count.Store(0)
+
+	leafCache := NewLeafHashCache(10)
+	treeCache := NewTreeCache(10)
+	tree, err := getVerifiedMerkleTree(ctx, logger.Test(t), hasher, leafCache, treeCache, commitReport)
+	require.NoError(t, err)
+	require.Equal(t, 1, treeCache.Len())
+
+	// The verified tree is reused, neither the messages nor the tree are hashed again.
+	cached, err := getVerifiedMerkleTree(ctx, logger.Test(t), hasher, leafCache, treeCache, commitReport)
+	require.NoError(t, err)
+	require.Same(t, tree, cached)
+	require.Equal(t, int64(4), hasher.count.Load())
+
+	// The cached tree is not used for the leaves of another root.
+	badReport := commitReport
+	badReport.MerkleRoot = cciptypes.Bytes32{0xaa}
+	_, err = getVerifiedMerkleTree(ctx, logger.Test(t), hasher, leafCache, treeCache, badReport)
+	require.ErrorContains(t, err, "merkle root mismatch")
+	require.Equal(t, 1, treeCache.Len())
+}
@@ -164,6 +164,7 @@ func (it *IntTest) Start() *testhelpers.OCR3Runner[[]byte] {
 	cfg := pluginconfig.ExecuteOffchainConfig{
 		MessageVisibilityInterval: *commonconfig.MustNewDuration(8 * time.Hour),
 		BatchGasLimit:             100000000,
+		MaxReportSizeBytes:        250_000,
 	}
 	chainConfigInfos := []reader.ChainConfigInfo{
 		{
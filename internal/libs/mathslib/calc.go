@@ -8,10 +8,20 @@ import (
 // ppb is calculated based on the smaller value of the two
 // e.g, if x1 > x2, deviation_parts_per_billion = ((x1 - x2) / x2) * 1e9
 func Deviates(x1, x2 *big.Int, ppb int64) bool {
-	// handle cases when either one or both of the numbers are 0
-	if x1.BitLen() == 0 || x2.BitLen() == 0 {
+	deviation, ok := DeviationPPB(x1, x2)
+	if !ok {
+		// handle cases when either one or both of the numbers are 0
 		return x1.Cmp(x2) != 0
 	}
+	return deviation.Cmp(big.NewInt(ppb)) > 0 // diff > ppb
+}
+
+// DeviationPPB returns the deviation between x1 and x2 in parts per billion of the smaller value of the two.
+// It returns false if either one of the numbers is 0, since the deviation is not defined.
+func DeviationPPB(x1, x2 *big.Int) (*big.Int, bool) {
+	if x1.BitLen() == 0 || x2.BitLen() == 0 {
+		return nil, false
+	}
 	// ensure x1 > x2
 	if x1.Cmp(x2) < 0 {
 		x1, x2 = x2, x1
@@ -20,7 +30,7 @@ func Deviates(x1, x2 *big.Int, ppb int64) bool {
 	diff.Mul(diff, big.NewInt(1e9))   // diff = diff * 1e9
 	// dividing by the smaller value gives consistent ppb regardless of input order, and supports >100% deviation.
 	diff.Div(diff, x2)
-	return diff.Cmp(big.NewInt(ppb)) > 0 // diff > ppb
+	return diff, true
 }
 
 // CalculateUsdPerUnitGas returns: (sourceGasPrice * usdPerFeeCoin) / 1e18
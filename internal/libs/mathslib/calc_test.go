@@ -100,6 +100,32 @@ func TestDeviates(t *testing.T) {
 	}
 }
 
+func TestDeviationPPB(t *testing.T) {
+	tests := []struct {
+		name   string
+		x1     *big.Int
+		x2     *big.Int
+		want   *big.Int
+		wantOk bool
+	}{
+		{name: "x1 is greater", x1: big.NewInt(15), x2: big.NewInt(10), want: big.NewInt(5e8), wantOk: true},
+		{name: "x2 is greater", x1: big.NewInt(10), x2: big.NewInt(15), want: big.NewInt(5e8), wantOk: true},
+		{name: "equal", x1: big.NewInt(10), x2: big.NewInt(10), want: big.NewInt(0), wantOk: true},
+		{name: "more than 100%", x1: big.NewInt(30), x2: big.NewInt(10), want: big.NewInt(2e9), wantOk: true},
+		{name: "x1 is zero", x1: big.NewInt(0), x2: big.NewInt(10), wantOk: false},
+		{name: "x2 is zero", x1: big.NewInt(10), x2: big.NewInt(0), wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DeviationPPB(tt.x1, tt.x2)
+			assert.Equal(t, tt.wantOk, ok)
+			if tt.wantOk {
+				assert.Zero(t, tt.want.Cmp(got), "got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCalculateUsdPerUnitGas(t *testing.T) {
 	testCases := []struct {
 		name           string
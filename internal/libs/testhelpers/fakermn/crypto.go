@@ -0,0 +1,92 @@
+package fakermn
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// Crypto implements cciptypes.RMNCrypto for the report signatures of the fake RMN nodes.
+// Reports are json encoded and signed with ECDSA P-256, signer addresses are derived from the public keys.
+type Crypto struct {
+	signers map[string]*ecdsa.PublicKey // signer address -> public key
+}
+
+var _ cciptypes.RMNCrypto = (*Crypto)(nil)
+
+func (c *Crypto) VerifyReportSignatures(
+	_ context.Context,
+	sigs []cciptypes.RMNECDSASignature,
+	report cciptypes.RMNReport,
+	signerAddresses []cciptypes.Bytes,
+) error {
+	hash, err := reportHash(report)
+	if err != nil {
+		return err
+	}
+
+	for _, sig := range sigs {
+		r := new(big.Int).SetBytes(sig.R[:])
+		s := new(big.Int).SetBytes(sig.S[:])
+
+		valid := false
+		for _, addr := range signerAddresses {
+			pubKey, ok := c.signers[addr.String()]
+			if ok && ecdsa.Verify(pubKey, hash, r, s) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("signature %x%x does not match any of the signers", sig.R, sig.S)
+		}
+	}
+	return nil
+}
+
+func newSigningKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// signerAddress derives an evm like address from the public key.
+func signerAddress(pubKey *ecdsa.PublicKey) (cciptypes.Bytes, error) {
+	ecdhKey, err := pubKey.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("convert public key: %w", err)
+	}
+	h := sha256.Sum256(ecdhKey.Bytes())
+	return h[12:], nil
+}
+
+func signReport(key *ecdsa.PrivateKey, report cciptypes.RMNReport) (cciptypes.RMNECDSASignature, error) {
+	hash, err := reportHash(report)
+	if err != nil {
+		return cciptypes.RMNECDSASignature{}, err
+	}
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash)
+	if err != nil {
+		return cciptypes.RMNECDSASignature{}, fmt.Errorf("sign report: %w", err)
+	}
+
+	var sig cciptypes.RMNECDSASignature
+	r.FillBytes(sig.R[:])
+	s.FillBytes(sig.S[:])
+	return sig, nil
+}
+
+func reportHash(report cciptypes.RMNReport) ([]byte, error) {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("encode report: %w", err)
+	}
+	h := sha256.Sum256(encoded)
+	return h[:], nil
+}
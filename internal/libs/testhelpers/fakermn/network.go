@@ -0,0 +1,232 @@
+// Package fakermn provides an in-process network of fake RMN nodes, to be used in tests that exercise the
+// RMN flow of the commit plugin end to end.
+package fakermn
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/rmnpb"
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// RootsFunc returns the merkle root of the provided source chain interval.
+// If false is returned the interval is not observed by the nodes.
+type RootsFunc func(laneSource *rmnpb.LaneSource, interval *rmnpb.ClosedInterval) (cciptypes.Bytes32, bool)
+
+// Config is the configuration of the fake RMN network.
+type Config struct {
+	// NumNodes is the number of RMN nodes, node ids start from 0.
+	NumNodes int
+	// SourceChains are the source chains supported by all the nodes.
+	SourceChains []cciptypes.ChainSelector
+	// HomeConfigDigest is the RMNHome config digest the nodes observe with.
+	HomeConfigDigest cciptypes.Bytes32
+	// ReportVersion is the RMN report version digest the nodes sign reports with.
+	ReportVersion cciptypes.Bytes32
+	// SignObservationPrefix is the prefix of the signed observations.
+	SignObservationPrefix string
+	// Roots provides the merkle roots observed by the honest nodes.
+	Roots RootsFunc
+}
+
+// Network is a set of fake RMN nodes. Plugin oracles talk to it through the peer clients returned by NewPeerClient.
+type Network struct {
+	nodes                 []*Node
+	homeConfigDigest      cciptypes.Bytes32
+	reportVersion         cciptypes.Bytes32
+	signObservationPrefix string
+	roots                 RootsFunc
+	crypto                *Crypto
+}
+
+func NewNetwork(cfg Config) (*Network, error) {
+	if cfg.Roots == nil {
+		return nil, errors.New("roots func is required")
+	}
+
+	n := &Network{
+		nodes:                 make([]*Node, 0, cfg.NumNodes),
+		homeConfigDigest:      cfg.HomeConfigDigest,
+		reportVersion:         cfg.ReportVersion,
+		signObservationPrefix: cfg.SignObservationPrefix,
+		roots:                 cfg.Roots,
+		crypto:                &Crypto{signers: make(map[string]*ecdsa.PublicKey, cfg.NumNodes)},
+	}
+
+	for i := 0; i < cfg.NumNodes; i++ {
+		node, err := newNode(rmntypes.NodeID(i), cfg.SourceChains, n)
+		if err != nil {
+			return nil, fmt.Errorf("create node %d: %w", i, err)
+		}
+		n.nodes = append(n.nodes, node)
+		n.crypto.signers[node.onchainAddress.String()] = &node.onchainKey.PublicKey
+	}
+	return n, nil
+}
+
+// Node returns the node with the provided id, or nil if it does not exist.
+func (n *Network) Node(id rmntypes.NodeID) *Node {
+	if int(id) >= len(n.nodes) {
+		return nil
+	}
+	return n.nodes[id]
+}
+
+// HomeNodes returns the nodes info as it would be read from the RMNHome contract.
+func (n *Network) HomeNodes() []rmntypes.HomeNodeInfo {
+	nodes := make([]rmntypes.HomeNodeInfo, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		nodes = append(nodes, node.HomeNodeInfo())
+	}
+	return nodes
+}
+
+// RemoteConfig returns the RMNRemote config that has all the nodes as signers.
+func (n *Network) RemoteConfig(contractAddress cciptypes.Bytes, minSigners uint64) rmntypes.RemoteConfig {
+	signers := make([]rmntypes.RemoteSignerInfo, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		signers = append(signers, node.SignerInfo())
+	}
+	return rmntypes.RemoteConfig{
+		ContractAddress:  contractAddress,
+		ConfigDigest:     n.homeConfigDigest,
+		Signers:          signers,
+		MinSigners:       minSigners,
+		ConfigVersion:    1,
+		RmnReportVersion: n.reportVersion,
+	}
+}
+
+// Crypto returns the RMNCrypto that verifies the report signatures of the network nodes.
+func (n *Network) Crypto() *Crypto {
+	return n.crypto
+}
+
+// NewPeerClient returns a new peer client connected to the network, each oracle is expected to use its own client.
+func (n *Network) NewPeerClient() rmn.PeerClient {
+	return &peerClient{
+		network:     n,
+		respChan:    make(chan rmn.PeerResponse),
+		connections: make(map[cciptypes.Bytes32]chan struct{}),
+	}
+}
+
+// validObservation checks that the observation is signed by the node it is attributed to.
+func (n *Network) validObservation(attrObs *rmnpb.AttributedSignedObservation) bool {
+	node := n.Node(rmntypes.NodeID(attrObs.GetSignerNodeIndex()))
+	signedObs := attrObs.GetSignedObservation()
+	if node == nil || signedObs.GetObservation() == nil {
+		return false
+	}
+	for _, lu := range signedObs.Observation.FixedDestLaneUpdates {
+		if len(lu.Root) != len(cciptypes.Bytes32{}) {
+			return false
+		}
+	}
+
+	msg, err := observationSigningMessage(n.signObservationPrefix, signedObs.Observation)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(node.offchainKey.Public().(ed25519.PublicKey), msg, signedObs.Signature)
+}
+
+type peerClient struct {
+	network  *Network
+	respChan chan rmn.PeerResponse
+
+	mu          sync.Mutex
+	connections map[cciptypes.Bytes32]chan struct{} // rmnHomeConfigDigest -> stop channel of the connection
+}
+
+func (c *peerClient) InitConnection(_ context.Context, _, rmnHomeConfigDigest cciptypes.Bytes32, _ []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stopCh, ok := c.connections[rmnHomeConfigDigest]; ok {
+		close(stopCh)
+	}
+	c.connections[rmnHomeConfigDigest] = make(chan struct{})
+	return nil
+}
+
+func (c *peerClient) CloseConnection(rmnHomeConfigDigest cciptypes.Bytes32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stopCh, ok := c.connections[rmnHomeConfigDigest]; ok {
+		close(stopCh)
+		delete(c.connections, rmnHomeConfigDigest)
+	}
+	return nil
+}
+
+func (c *peerClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for rmnHomeConfigDigest, stopCh := range c.connections {
+		close(stopCh)
+		delete(c.connections, rmnHomeConfigDigest)
+	}
+	return nil
+}
+
+// Send dispatches the request to the target node, the response is delivered asynchronously through Recv.
+func (c *peerClient) Send(rmnHomeConfigDigest cciptypes.Bytes32, rmnNode rmntypes.HomeNodeInfo, request []byte) error {
+	c.mu.Lock()
+	stopCh, ok := c.connections[rmnHomeConfigDigest]
+	c.mu.Unlock()
+	if !ok {
+		return rmn.ErrNoConn
+	}
+
+	node := c.network.Node(rmnNode.ID)
+	if node == nil {
+		return fmt.Errorf("rmn node %d not found", rmnNode.ID)
+	}
+
+	req := &rmnpb.Request{}
+	if err := proto.Unmarshal(request, req); err != nil {
+		return fmt.Errorf("unmarshal request: %w", err)
+	}
+
+	behavior := node.Behavior()
+	go func() {
+		resp, err := node.handleRequest(req, behavior)
+		if err != nil {
+			return
+		}
+		body, err := proto.Marshal(resp)
+		if err != nil {
+			return
+		}
+
+		if behavior.Delay > 0 {
+			timer := time.NewTimer(behavior.Delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-stopCh:
+				return
+			}
+		}
+
+		select {
+		case c.respChan <- rmn.PeerResponse{RMNNodeID: node.id, Body: body}:
+		case <-stopCh:
+		}
+	}()
+	return nil
+}
+
+func (c *peerClient) Recv() <-chan rmn.PeerResponse {
+	return c.respChan
+}
@@ -0,0 +1,161 @@
+package fakermn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chainsel "github.com/goplugin/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/utils/tests"
+
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/rmnpb"
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+	readerpkg_mock "github.com/goplugin/plugin-ccip/mocks/pkg/reader"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+const signObservationPrefix = "plugin ccip 1.6 rmn observation"
+
+var (
+	sourceChain1 = cciptypes.ChainSelector(chainsel.TEST_90000002.Selector)
+	sourceChain2 = cciptypes.ChainSelector(chainsel.TEST_90000003.Selector)
+	destChain    = chainsel.TEST_90000004
+)
+
+func TestNetwork_ComputeReportSignatures(t *testing.T) {
+	testCases := []struct {
+		name      string
+		behaviors map[rmntypes.NodeID]Behavior
+		expErr    error
+	}{
+		{
+			name: "all nodes are honest",
+		},
+		{
+			name: "slow and silent nodes",
+			behaviors: map[rmntypes.NodeID]Behavior{
+				0: {Delay: time.Second},
+				1: {Silent: true},
+			},
+		},
+		{
+			name: "lying nodes",
+			behaviors: map[rmntypes.NodeID]Behavior{
+				0: {LieAboutRoots: true},
+				1: {InvalidSignatures: true},
+				2: {StaleConfigDigest: true},
+			},
+		},
+		{
+			name: "not enough honest nodes",
+			behaviors: map[rmntypes.NodeID]Behavior{
+				0: {Silent: true},
+				1: {Silent: true},
+				2: {LieAboutRoots: true},
+				3: {InvalidSignatures: true},
+			},
+			expErr: rmn.ErrTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := tests.Context(t)
+
+			network, err := NewNetwork(Config{
+				NumNodes:              5,
+				SourceChains:          []cciptypes.ChainSelector{sourceChain1, sourceChain2},
+				HomeConfigDigest:      cciptypes.Bytes32{1},
+				ReportVersion:         cciptypes.Bytes32{2},
+				SignObservationPrefix: signObservationPrefix,
+				Roots: func(laneSource *rmnpb.LaneSource, interval *rmnpb.ClosedInterval) (cciptypes.Bytes32, bool) {
+					return cciptypes.Bytes32{byte(laneSource.OnrampAddress[0]), byte(interval.MaxMsgNr)}, true
+				},
+			})
+			require.NoError(t, err)
+			for nodeID, b := range tc.behaviors {
+				network.Node(nodeID).SetBehavior(b)
+			}
+
+			remoteCfg := network.RemoteConfig(cciptypes.Bytes{3}, 2)
+			rmnHome := readerpkg_mock.NewMockRMNHome(t)
+			rmnHome.EXPECT().GetRMNNodesInfo(remoteCfg.ConfigDigest).Return(network.HomeNodes(), nil).Maybe()
+			rmnHome.EXPECT().GetMinObservers(remoteCfg.ConfigDigest).Return(map[cciptypes.ChainSelector]int{
+				sourceChain1: 2,
+				sourceChain2: 2,
+			}, nil).Maybe()
+
+			peerClient := network.NewPeerClient()
+			controller := rmn.NewController(
+				logger.Test(t),
+				network.Crypto(),
+				signObservationPrefix,
+				peerClient,
+				rmnHome,
+				100*time.Millisecond,
+				100*time.Millisecond,
+				nil,
+			)
+			cfgDigests := []cciptypes.Bytes32{remoteCfg.ConfigDigest}
+			require.NoError(t, controller.InitConnection(ctx, cciptypes.Bytes32{}, cfgDigests, nil))
+			t.Cleanup(func() { require.NoError(t, controller.Close()) })
+
+			laneDest := &rmnpb.LaneDest{DestChainSelector: destChain.Selector, OfframpAddress: []byte{4}}
+			updateRequests := []*rmnpb.FixedDestLaneUpdateRequest{
+				{
+					LaneSource:     &rmnpb.LaneSource{SourceChainSelector: uint64(sourceChain1), OnrampAddress: []byte{5}},
+					ClosedInterval: &rmnpb.ClosedInterval{MinMsgNr: 10, MaxMsgNr: 20},
+				},
+				{
+					LaneSource:     &rmnpb.LaneSource{SourceChainSelector: uint64(sourceChain2), OnrampAddress: []byte{6}},
+					ClosedInterval: &rmnpb.ClosedInterval{MinMsgNr: 30, MaxMsgNr: 40},
+				},
+			}
+
+			ctxQuery, cancel := context.WithTimeout(ctx, 3*time.Second)
+			defer cancel()
+			sigs, err := controller.ComputeReportSignatures(ctxQuery, laneDest, updateRequests, remoteCfg)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, sigs.Signatures, 2)
+			require.Len(t, sigs.LaneUpdates, 2)
+			for _, lu := range sigs.LaneUpdates {
+				assert.Equal(t, []byte{lu.LaneSource.OnrampAddress[0], byte(lu.ClosedInterval.MaxMsgNr)}, lu.Root[:2])
+			}
+
+			laneUpdates, err := rmn.NewLaneUpdatesFromPB(sigs.LaneUpdates)
+			require.NoError(t, err)
+			ecdsaSigs, err := rmn.NewECDSASigsFromPB(sigs.Signatures)
+			require.NoError(t, err)
+
+			signerAddresses := make([]cciptypes.Bytes, 0, len(remoteCfg.Signers))
+			for _, signer := range remoteCfg.Signers {
+				signerAddresses = append(signerAddresses, signer.OnchainPublicKey)
+			}
+			report := cciptypes.RMNReport{
+				ReportVersionDigest:         remoteCfg.RmnReportVersion,
+				DestChainID:                 cciptypes.NewBigIntFromInt64(int64(destChain.EvmChainID)),
+				DestChainSelector:           cciptypes.ChainSelector(destChain.Selector),
+				RmnRemoteContractAddress:    remoteCfg.ContractAddress,
+				OfframpAddress:              laneDest.OfframpAddress,
+				RmnHomeContractConfigDigest: remoteCfg.ConfigDigest,
+				LaneUpdates:                 laneUpdates,
+			}
+			require.NoError(t, network.Crypto().VerifyReportSignatures(ctx, ecdsaSigs, report, signerAddresses))
+
+			// a different report must not verify
+			report.LaneUpdates[0].MerkleRoot = cciptypes.Bytes32{}
+			require.Error(t, network.Crypto().VerifyReportSignatures(ctx, ecdsaSigs, report, signerAddresses))
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package fakermn
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"google.golang.org/protobuf/proto"
+
+	ragep2ptypes "github.com/goplugin/plugin-libocr/ragep2p/types"
+
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn"
+	"github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/rmnpb"
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// errNoResponse is returned when a node decides not to respond to a request.
+var errNoResponse = errors.New("no response")
+
+// Behavior configures how a fake RMN node deviates from an honest node.
+type Behavior struct {
+	// Delay is waited before every response.
+	Delay time.Duration
+	// Silent nodes never respond.
+	Silent bool
+	// LieAboutRoots makes the node observe and sign merkle roots different from the ones returned by the RootsFunc.
+	LieAboutRoots bool
+	// InvalidSignatures makes the node sign its responses with keys different from the advertised ones.
+	InvalidSignatures bool
+	// StaleConfigDigest makes the node observe with an outdated RMNHome config digest.
+	StaleConfigDigest bool
+}
+
+// Node is a fake RMN node that answers observation and report signature requests with real signatures.
+type Node struct {
+	id              rmntypes.NodeID
+	offchainKey     ed25519.PrivateKey
+	onchainKey      *ecdsa.PrivateKey
+	onchainAddress  cciptypes.Bytes
+	supportedChains mapset.Set[cciptypes.ChainSelector]
+	network         *Network
+
+	mu       sync.RWMutex
+	behavior Behavior
+}
+
+func newNode(id rmntypes.NodeID, supportedChains []cciptypes.ChainSelector, network *Network) (*Node, error) {
+	_, offchainKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate offchain key: %w", err)
+	}
+	onchainKey, err := newSigningKey()
+	if err != nil {
+		return nil, fmt.Errorf("generate onchain key: %w", err)
+	}
+	onchainAddress, err := signerAddress(&onchainKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{
+		id:              id,
+		offchainKey:     offchainKey,
+		onchainKey:      onchainKey,
+		onchainAddress:  onchainAddress,
+		supportedChains: mapset.NewSet(supportedChains...),
+		network:         network,
+	}, nil
+}
+
+func (n *Node) ID() rmntypes.NodeID {
+	return n.id
+}
+
+// SetBehavior changes the behavior of the node, it applies to the requests received after the call.
+func (n *Node) SetBehavior(b Behavior) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.behavior = b
+}
+
+func (n *Node) Behavior() Behavior {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.behavior
+}
+
+// HomeNodeInfo returns the node info as it would be published in the RMNHome contract.
+func (n *Node) HomeNodeInfo() rmntypes.HomeNodeInfo {
+	offchainPubKey := n.offchainKey.Public().(ed25519.PublicKey)
+	return rmntypes.HomeNodeInfo{
+		ID:                    n.id,
+		PeerID:                ragep2ptypes.PeerID(offchainPubKey),
+		SupportedSourceChains: n.supportedChains.Clone(),
+		OffchainPublicKey:     &offchainPubKey,
+	}
+}
+
+// SignerInfo returns the node info as it would be published in the RMNRemote contract.
+func (n *Node) SignerInfo() rmntypes.RemoteSignerInfo {
+	return rmntypes.RemoteSignerInfo{
+		OnchainPublicKey: n.onchainAddress,
+		NodeIndex:        uint64(n.id),
+	}
+}
+
+// handleRequest computes the response of the node to the provided request.
+// It returns errNoResponse if the node would not respond to the request.
+func (n *Node) handleRequest(req *rmnpb.Request, behavior Behavior) (*rmnpb.Response, error) {
+	if behavior.Silent {
+		return nil, errNoResponse
+	}
+
+	switch r := req.Request.(type) {
+	case *rmnpb.Request_ObservationRequest:
+		signedObs, err := n.observe(r.ObservationRequest, behavior)
+		if err != nil {
+			return nil, err
+		}
+		return &rmnpb.Response{
+			RequestId: req.RequestId,
+			Response:  &rmnpb.Response_SignedObservation{SignedObservation: signedObs},
+		}, nil
+	case *rmnpb.Request_ReportSignatureRequest:
+		reportSig, err := n.signReport(r.ReportSignatureRequest, behavior)
+		if err != nil {
+			return nil, err
+		}
+		return &rmnpb.Response{
+			RequestId: req.RequestId,
+			Response:  &rmnpb.Response_ReportSignature{ReportSignature: reportSig},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected request type %T", req.Request)
+	}
+}
+
+func (n *Node) observe(req *rmnpb.ObservationRequest, behavior Behavior) (*rmnpb.SignedObservation, error) {
+	laneUpdates := make([]*rmnpb.FixedDestLaneUpdate, 0, len(req.FixedDestLaneUpdateRequests))
+	for _, lur := range req.FixedDestLaneUpdateRequests {
+		if !n.supportedChains.Contains(cciptypes.ChainSelector(lur.LaneSource.SourceChainSelector)) {
+			continue
+		}
+		root, ok := n.network.roots(lur.LaneSource, lur.ClosedInterval)
+		if !ok {
+			continue
+		}
+		if behavior.LieAboutRoots {
+			root = sha256.Sum256(root[:])
+		}
+		laneUpdates = append(laneUpdates, &rmnpb.FixedDestLaneUpdate{
+			LaneSource:     lur.LaneSource,
+			ClosedInterval: lur.ClosedInterval,
+			Root:           root[:],
+		})
+	}
+	if len(laneUpdates) == 0 {
+		return nil, errNoResponse
+	}
+
+	configDigest := n.network.homeConfigDigest
+	if behavior.StaleConfigDigest {
+		configDigest = sha256.Sum256(configDigest[:])
+	}
+
+	observation := &rmnpb.Observation{
+		RmnHomeContractConfigDigest: configDigest[:],
+		LaneDest:                    req.LaneDest,
+		FixedDestLaneUpdates:        laneUpdates,
+		Timestamp:                   uint64(time.Now().UnixMilli()),
+	}
+
+	key := n.offchainKey
+	if behavior.InvalidSignatures {
+		var err error
+		if _, key, err = ed25519.GenerateKey(rand.Reader); err != nil {
+			return nil, fmt.Errorf("generate key: %w", err)
+		}
+	}
+	msg, err := observationSigningMessage(n.network.signObservationPrefix, observation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rmnpb.SignedObservation{
+		Observation: observation,
+		Signature:   ed25519.Sign(key, msg),
+	}, nil
+}
+
+// signReport signs the report built from the most observed root of each source chain. Observations with invalid
+// signatures are ignored, like a real RMN node would do.
+func (n *Node) signReport(
+	req *rmnpb.ReportSignatureRequest,
+	behavior Behavior,
+) (*rmnpb.ReportSignature, error) {
+	if !bytes.Equal(req.GetContext().GetRmnHomeContractConfigDigest(), n.network.homeConfigDigest[:]) {
+		return nil, errNoResponse
+	}
+
+	type rootVotes struct {
+		laneUpdate *rmnpb.FixedDestLaneUpdate
+		votes      int
+	}
+	votes := make(map[uint64]map[cciptypes.Bytes32]*rootVotes)
+	for _, attrObs := range req.AttributedSignedObservations {
+		if !n.network.validObservation(attrObs) {
+			continue
+		}
+		for _, lu := range attrObs.SignedObservation.Observation.FixedDestLaneUpdates {
+			sourceChain := lu.LaneSource.SourceChainSelector
+			if _, ok := votes[sourceChain]; !ok {
+				votes[sourceChain] = make(map[cciptypes.Bytes32]*rootVotes)
+			}
+			root := cciptypes.Bytes32(lu.Root)
+			if _, ok := votes[sourceChain][root]; !ok {
+				votes[sourceChain][root] = &rootVotes{laneUpdate: lu}
+			}
+			votes[sourceChain][root].votes++
+		}
+	}
+
+	laneUpdates := make([]*rmnpb.FixedDestLaneUpdate, 0, len(votes))
+	for _, rootsVotes := range votes {
+		var best *rootVotes
+		for _, rv := range rootsVotes {
+			if best == nil || rv.votes > best.votes {
+				best = rv
+			}
+		}
+		laneUpdates = append(laneUpdates, best.laneUpdate)
+	}
+	sort.Slice(laneUpdates, func(i, j int) bool {
+		return laneUpdates[i].LaneSource.SourceChainSelector < laneUpdates[j].LaneSource.SourceChainSelector
+	})
+
+	rmnLaneUpdates, err := rmn.NewLaneUpdatesFromPB(laneUpdates)
+	if err != nil {
+		return nil, err
+	}
+	if behavior.LieAboutRoots {
+		for i := range rmnLaneUpdates {
+			rmnLaneUpdates[i].MerkleRoot = sha256.Sum256(rmnLaneUpdates[i].MerkleRoot[:])
+		}
+	}
+
+	report := cciptypes.RMNReport{
+		ReportVersionDigest:         n.network.reportVersion,
+		DestChainID:                 cciptypes.NewBigIntFromInt64(int64(req.Context.EvmDestChainId)),
+		DestChainSelector:           cciptypes.ChainSelector(req.Context.LaneDest.DestChainSelector),
+		RmnRemoteContractAddress:    req.Context.RmnRemoteContractAddress,
+		OfframpAddress:              req.Context.LaneDest.OfframpAddress,
+		RmnHomeContractConfigDigest: n.network.homeConfigDigest,
+		LaneUpdates:                 rmnLaneUpdates,
+	}
+
+	key := n.onchainKey
+	if behavior.InvalidSignatures {
+		if key, err = newSigningKey(); err != nil {
+			return nil, fmt.Errorf("generate key: %w", err)
+		}
+	}
+	sig, err := signReport(key, report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rmnpb.ReportSignature{
+		Signature: &rmnpb.EcdsaSignature{R: sig.R[:], S: sig.S[:]},
+	}, nil
+}
+
+// observationSigningMessage returns the message signed by the RMN nodes for an observation,
+// i.e. sha256(prefix|sha256(observation)).
+func observationSigningMessage(prefix string, observation *rmnpb.Observation) ([]byte, error) {
+	observationBytes, err := proto.Marshal(observation)
+	if err != nil {
+		return nil, fmt.Errorf("marshal observation: %w", err)
+	}
+	observationSha256 := sha256.Sum256(observationBytes)
+	msg := sha256.Sum256(append([]byte(prefix), observationSha256[:]...))
+	return msg[:], nil
+}
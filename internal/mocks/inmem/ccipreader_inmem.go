@@ -149,6 +149,14 @@ func (r InMemoryCCIPReader) GetRMNRemoteConfig(
 	return rmntypes.RemoteConfig{}, nil
 }
 
+func (r InMemoryCCIPReader) GetRmnCurseInfo(
+	ctx context.Context,
+	destChainSelector cciptypes.ChainSelector,
+	sourceChainSelectors []cciptypes.ChainSelector,
+) (reader.CurseInfo, error) {
+	return reader.CurseInfo{}, nil
+}
+
 func (r InMemoryCCIPReader) LinkPriceUSD(ctx context.Context) (cciptypes.BigInt, error) {
 	return cciptypes.NewBigIntFromInt64(100), nil
 }
@@ -0,0 +1,178 @@
+package consensus
+
+import (
+	"math/big"
+	"sort"
+
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// BigIntLike is the set of big integer types supported by the numeric aggregators.
+type BigIntLike interface {
+	*big.Int | cciptypes.BigInt
+}
+
+// MedianBig returns the median of the provided big integers, see Median.
+// Nil values are skipped. If no values are left, it returns the zero value of the type.
+func MedianBig[T BigIntLike](vals []T) T {
+	return medianOfSorted[T](sortedBigInts(vals))
+}
+
+// TrimmedMean drops the trim lowest and highest values and returns the mean of the rest, rounded down.
+// With trim set to f, up to f faulty values cannot move the result outside the range of the honest values.
+// If no values are left after trimming, the median is returned instead.
+// Nil values are skipped. If no values are left, it returns the zero value of the type.
+func TrimmedMean[T BigIntLike](vals []T, trim int) T {
+	sorted := sortedBigInts(vals)
+	if trim < 0 || len(sorted) <= 2*trim {
+		return medianOfSorted[T](sorted)
+	}
+
+	kept := sorted[trim : len(sorted)-trim]
+	sum := big.NewInt(0)
+	for _, val := range kept {
+		sum.Add(sum, val)
+	}
+	return fromBigInt[T](sum.Div(sum, big.NewInt(int64(len(kept)))))
+}
+
+// InterquartileMedian drops the outliers, i.e. the values more than 1.5 times the interquartile range below the
+// first quartile or above the third quartile, and returns the median of the rest.
+// Quartiles are taken with the nearest-rank method, so the result is deterministic for a given set of values.
+// Nil values are skipped. If no values are left, it returns the zero value of the type.
+func InterquartileMedian[T BigIntLike](vals []T) T {
+	return InterquartileMedianFunc(vals, toBigInt[T])
+}
+
+// InterquartileMedianFunc is the variant of InterquartileMedian for items carrying a big integer, e.g. token prices.
+// The items are compared by the value returned by the value function and the median item is returned as is.
+// Items with a nil value are skipped. If no items are left, it returns the zero value of the type.
+func InterquartileMedianFunc[T any](vals []T, value func(T) *big.Int) T {
+	sorted := make([]T, 0, len(vals))
+	for _, val := range vals {
+		if value(val) != nil {
+			sorted = append(sorted, val)
+		}
+	}
+	if len(sorted) == 0 {
+		var zero T
+		return zero
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return value(sorted[i]).Cmp(value(sorted[j])) == -1
+	})
+
+	q1 := value(sorted[len(sorted)/4])
+	q3 := value(sorted[len(sorted)*3/4])
+
+	// 1.5 * IQR = 3 * (q3 - q1) / 2
+	fence := new(big.Int).Sub(q3, q1)
+	fence.Mul(fence, big.NewInt(3)).Div(fence, big.NewInt(2))
+	lower := new(big.Int).Sub(q1, fence)
+	upper := new(big.Int).Add(q3, fence)
+
+	kept := make([]T, 0, len(sorted))
+	for _, val := range sorted {
+		if value(val).Cmp(lower) >= 0 && value(val).Cmp(upper) <= 0 {
+			kept = append(kept, val)
+		}
+	}
+	// kept is never empty since the quartiles are always within the fences.
+	return kept[len(kept)/2]
+}
+
+// ModeWithMinRatio returns the most frequent value if it makes up at least minRatio of the values,
+// e.g. 2/3 requires a two-thirds supermajority. It returns false if there is no such value, or if several values
+// are the most frequent.
+func ModeWithMinRatio[T comparable](vals []T, minRatio float64) (T, bool) {
+	mode, count, ok := uniqueMode(vals)
+	if !ok || float64(count) < minRatio*float64(len(vals)) {
+		var zero T
+		return zero, false
+	}
+	return mode, true
+}
+
+// ModeWithMinCount returns the most frequent value if it was observed at least minCount times, e.g. 2f+1.
+// It returns false if there is no such value, or if several values are the most frequent.
+func ModeWithMinCount[T comparable](vals []T, minCount int) (T, bool) {
+	mode, count, ok := uniqueMode(vals)
+	if !ok || count < minCount {
+		var zero T
+		return zero, false
+	}
+	return mode, true
+}
+
+// TrimmedMeanAggregator returns an Aggregator computing the TrimmedMean of the values, to be used with
+// GetConsensusMapAggregator.
+func TrimmedMeanAggregator[T BigIntLike](trim int) Aggregator[T] {
+	return func(vals []T) T {
+		return TrimmedMean(vals, trim)
+	}
+}
+
+// InterquartileMedianAggregator returns an Aggregator computing the InterquartileMedian of the values, to be used
+// with GetConsensusMapAggregator.
+func InterquartileMedianAggregator[T BigIntLike]() Aggregator[T] {
+	return InterquartileMedian[T]
+}
+
+// uniqueMode returns the most frequent value and its count, ok is false if the slice is empty or if several
+// values are the most frequent.
+func uniqueMode[T comparable](vals []T) (mode T, count int, ok bool) {
+	counts := make(map[T]int, len(vals))
+	tied := false
+	for _, val := range vals {
+		counts[val]++
+		switch c := counts[val]; {
+		case c > count:
+			mode, count, tied = val, c, false
+		case c == count:
+			tied = true
+		}
+	}
+	return mode, count, count > 0 && !tied
+}
+
+// sortedBigInts returns the non-nil values as a sorted slice of *big.Int, the values are not copied.
+func sortedBigInts[T BigIntLike](vals []T) []*big.Int {
+	sorted := make([]*big.Int, 0, len(vals))
+	for _, val := range vals {
+		if v := toBigInt(val); v != nil {
+			sorted = append(sorted, v)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) == -1
+	})
+	return sorted
+}
+
+func medianOfSorted[T BigIntLike](sorted []*big.Int) T {
+	if len(sorted) == 0 {
+		var zero T
+		return zero
+	}
+	return fromBigInt[T](sorted[len(sorted)/2])
+}
+
+// toBigInt returns the underlying *big.Int of the value, which is nil for a nil *big.Int or a BigInt without a value.
+func toBigInt[T BigIntLike](val T) *big.Int {
+	if v, ok := any(val).(cciptypes.BigInt); ok {
+		return v.Int
+	}
+	v, _ := any(val).(*big.Int)
+	return v
+}
+
+func fromBigInt[T BigIntLike](val *big.Int) T {
+	var res T
+	switch r := any(&res).(type) {
+	case **big.Int:
+		*r = val
+	case *cciptypes.BigInt:
+		*r = cciptypes.NewBigInt(val)
+	}
+	return res
+}
@@ -0,0 +1,236 @@
+package consensus
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+func bigInts(vals ...int64) []*big.Int {
+	res := make([]*big.Int, len(vals))
+	for i, val := range vals {
+		res[i] = big.NewInt(val)
+	}
+	return res
+}
+
+func TestMedianBig(t *testing.T) {
+	assert.Equal(t, big.NewInt(3), MedianBig(bigInts(5, 1, 3)))
+	assert.Equal(t, big.NewInt(4), MedianBig(bigInts(5, 1, 3, 4)))
+	assert.Equal(t, cciptypes.NewBigInt(big.NewInt(3)), MedianBig([]cciptypes.BigInt{
+		cciptypes.NewBigInt(big.NewInt(5)),
+		cciptypes.NewBigInt(big.NewInt(1)),
+		cciptypes.NewBigInt(big.NewInt(3)),
+	}))
+	assert.Nil(t, MedianBig[*big.Int](nil))
+}
+
+func TestTrimmedMean(t *testing.T) {
+	testCases := []struct {
+		name string
+		vals []*big.Int
+		trim int
+		exp  *big.Int
+	}{
+		{
+			name: "no trim is the mean",
+			vals: bigInts(1, 2, 3, 6),
+			trim: 0,
+			exp:  big.NewInt(3),
+		},
+		{
+			name: "outliers are trimmed",
+			vals: bigInts(1, 10, 1000, 12, 14),
+			trim: 1,
+			exp:  big.NewInt(12),
+		},
+		{
+			name: "mean is rounded down",
+			vals: bigInts(0, 10, 11, 100),
+			trim: 1,
+			exp:  big.NewInt(10),
+		},
+		{
+			name: "median when all values would be trimmed",
+			vals: bigInts(1, 10, 100, 1000),
+			trim: 2,
+			exp:  big.NewInt(100),
+		},
+		{
+			name: "empty",
+			trim: 1,
+			exp:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.exp, TrimmedMean(tc.vals, tc.trim))
+		})
+	}
+}
+
+func TestTrimmedMean_DoesNotModifyInput(t *testing.T) {
+	vals := bigInts(3, 1, 2)
+	TrimmedMean(vals, 1)
+	assert.Equal(t, bigInts(3, 1, 2), vals)
+}
+
+func TestInterquartileMedian(t *testing.T) {
+	testCases := []struct {
+		name string
+		vals []*big.Int
+		exp  *big.Int
+	}{
+		{
+			name: "no outliers is the median",
+			vals: bigInts(10, 11, 12, 13, 14),
+			exp:  big.NewInt(12),
+		},
+		{
+			name: "high outliers are dropped",
+			// q1 = 12, q3 = 16, fences = [6, 22], the plain median would be 14
+			vals: bigInts(10, 11, 12, 13, 1000, 14, 15, 16),
+			exp:  big.NewInt(13),
+		},
+		{
+			name: "low outliers are dropped",
+			// q1 = 101, q3 = 105, fences = [95, 111], the plain median would be 103
+			vals: bigInts(100, 101, 102, 103, 104, 105, 106, 107, 1),
+			exp:  big.NewInt(104),
+		},
+		{
+			name: "single value",
+			vals: bigInts(7),
+			exp:  big.NewInt(7),
+		},
+		{
+			name: "empty",
+			exp:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.exp, InterquartileMedian(tc.vals))
+		})
+	}
+}
+
+func TestModeWithMinRatio(t *testing.T) {
+	testCases := []struct {
+		name     string
+		vals     []string
+		minRatio float64
+		exp      string
+		expOk    bool
+	}{
+		{
+			name:     "mode with a supermajority",
+			vals:     []string{"a", "b", "a", "a"},
+			minRatio: 2.0 / 3,
+			exp:      "a",
+			expOk:    true,
+		},
+		{
+			name:     "mode without enough agreement",
+			vals:     []string{"a", "b", "a", "c"},
+			minRatio: 2.0 / 3,
+			expOk:    false,
+		},
+		{
+			name:     "tied values",
+			vals:     []string{"a", "b", "a", "b"},
+			minRatio: 0,
+			expOk:    false,
+		},
+		{
+			name:     "unanimous",
+			vals:     []string{"a", "a"},
+			minRatio: 1,
+			exp:      "a",
+			expOk:    true,
+		},
+		{
+			name:     "empty",
+			minRatio: 0,
+			expOk:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mode, ok := ModeWithMinRatio(tc.vals, tc.minRatio)
+			assert.Equal(t, tc.expOk, ok)
+			assert.Equal(t, tc.exp, mode)
+		})
+	}
+}
+
+func TestAggregators_WithGetConsensusMapAggregator(t *testing.T) {
+	lggr := logger.Test(t)
+	items := map[cciptypes.ChainSelector][]cciptypes.BigInt{
+		1: {
+			cciptypes.NewBigInt(big.NewInt(1)),
+			cciptypes.NewBigInt(big.NewInt(10)),
+			cciptypes.NewBigInt(big.NewInt(12)),
+			cciptypes.NewBigInt(big.NewInt(14)),
+			cciptypes.NewBigInt(big.NewInt(1000)),
+		},
+		2: {
+			cciptypes.NewBigInt(big.NewInt(10)),
+		},
+	}
+	thresh := MakeConstantThreshold[cciptypes.ChainSelector](TwoFPlus1(1))
+
+	trimmed := GetConsensusMapAggregator(lggr, "trimmed", items, thresh, TrimmedMeanAggregator[cciptypes.BigInt](1))
+	assert.Equal(t, map[cciptypes.ChainSelector]cciptypes.BigInt{1: cciptypes.NewBigInt(big.NewInt(12))}, trimmed)
+
+	iqr := GetConsensusMapAggregator(lggr, "iqr", items, thresh, InterquartileMedianAggregator[cciptypes.BigInt]())
+	assert.Equal(t, map[cciptypes.ChainSelector]cciptypes.BigInt{1: cciptypes.NewBigInt(big.NewInt(12))}, iqr)
+}
+
+func TestAggregators_SkipNilValues(t *testing.T) {
+	vals := []cciptypes.BigInt{
+		cciptypes.NewBigInt(big.NewInt(3)),
+		{Int: nil},
+		cciptypes.NewBigInt(big.NewInt(1)),
+		{Int: nil},
+		cciptypes.NewBigInt(big.NewInt(2)),
+	}
+	assert.Equal(t, cciptypes.NewBigInt(big.NewInt(2)), MedianBig(vals))
+	assert.Equal(t, cciptypes.NewBigInt(big.NewInt(2)), TrimmedMean(vals, 1))
+	assert.Equal(t, cciptypes.NewBigInt(big.NewInt(2)), InterquartileMedian(vals))
+
+	onlyNil := []cciptypes.BigInt{{Int: nil}}
+	assert.Equal(t, cciptypes.BigInt{}, MedianBig(onlyNil))
+	assert.Equal(t, cciptypes.BigInt{}, TrimmedMean(onlyNil, 0))
+	assert.Equal(t, cciptypes.BigInt{}, InterquartileMedian(onlyNil))
+	assert.Nil(t, MedianBig([]*big.Int{nil}))
+}
+
+func TestModeWithMinCount(t *testing.T) {
+	mode, ok := ModeWithMinCount([]int{1, 2, 1, 1}, 3)
+	assert.True(t, ok)
+	assert.Equal(t, 1, mode)
+
+	_, ok = ModeWithMinCount([]int{1, 2, 1, 3}, 3)
+	assert.False(t, ok)
+
+	_, ok = ModeWithMinCount([]int{1, 2, 1, 2}, 2)
+	assert.False(t, ok)
+
+	_, ok = ModeWithMinCount[int](nil, 0)
+	assert.False(t, ok)
+}
@@ -1,7 +1,10 @@
 package consensus
 
 import (
+	"fmt"
+	"math/big"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/goplugin/plugin-common/pkg/logger"
@@ -10,15 +13,22 @@ import (
 	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
 )
 
+// FailureTracker is notified whenever consensus could not be reached on an object for a key.
+type FailureTracker interface {
+	TrackConsensusFailure(objectName string, key string)
+}
+
 // GetConsensusMap takes a mapping from chains to a list of items,
 // return a mapping from chains to a single consensus item.
 // The consensus item for a given chain is the item with the
 // most observations that was observed at least fChain times.
+// Failures to reach consensus are reported to the tracker, which may be nil.
 func GetConsensusMap[K comparable, T any](
 	lggr logger.Logger,
 	objectName string,
 	itemsByKey map[K][]T,
 	minObs MultiThreshold[K],
+	tracker FailureTracker,
 ) map[K]T {
 	consensus := make(map[K]T)
 
@@ -30,7 +40,7 @@ func GetConsensusMap[K comparable, T any](
 			}
 			items = minObservations.GetValid()
 			if len(items) != 1 {
-				// TODO: metrics
+				trackFailure(tracker, objectName, key)
 				lggr.Warnf("failed to reach consensus on a %s's for key %+v "+
 					"because no single item was observed more than the expected min (%d) times, "+
 					"all observed items: %v",
@@ -39,13 +49,58 @@ func GetConsensusMap[K comparable, T any](
 				consensus[key] = items[0]
 			}
 		} else {
-			// TODO: metrics
+			trackFailure(tracker, objectName, key)
+			lggr.Warnf("getConsensus(%s): min not found for chain %d", objectName, key)
+		}
+	}
+	return consensus
+}
+
+// GetConsensusMapMode is the variant of GetConsensusMap for comparable items. The consensus item for a given key
+// is the most frequent item if it was observed at least the threshold of the key times, see ModeWithMinCount.
+// Failures to reach consensus are reported to the tracker, which may be nil.
+func GetConsensusMapMode[K comparable, T comparable](
+	lggr logger.Logger,
+	objectName string,
+	itemsByKey map[K][]T,
+	minObs MultiThreshold[K],
+	tracker FailureTracker,
+) map[K]T {
+	consensus := make(map[K]T)
+
+	for key, items := range itemsByKey {
+		minThresh, exists := minObs.Get(key)
+		if !exists {
+			trackFailure(tracker, objectName, key)
 			lggr.Warnf("getConsensus(%s): min not found for chain %d", objectName, key)
+			continue
 		}
+		mode, ok := ModeWithMinCount(items, int(minThresh))
+		if !ok {
+			trackFailure(tracker, objectName, key)
+			lggr.Warnf("failed to reach consensus on a %s's for key %+v "+
+				"because no single item was observed more than the expected min (%d) times, "+
+				"all observed items: %v",
+				objectName, key, minThresh, items)
+			continue
+		}
+		consensus[key] = mode
 	}
 	return consensus
 }
 
+func trackFailure[K comparable](tracker FailureTracker, objectName string, key K) {
+	if tracker == nil {
+		return
+	}
+	switch k := any(key).(type) {
+	case cciptypes.ChainSelector:
+		tracker.TrackConsensusFailure(objectName, strconv.FormatUint(uint64(k), 10))
+	default:
+		tracker.TrackConsensusFailure(objectName, fmt.Sprint(key))
+	}
+}
+
 // Aggregator is a function type that aggregates a slice of values into a single value.
 type Aggregator[T any] func(vals []T) T
 
@@ -99,6 +154,14 @@ func TokenPriceComparator(a, b cciptypes.TokenPrice) bool {
 	return a.Price.Int.Cmp(b.Price.Int) == -1
 }
 
+// TokenPriceAggregator aggregates the feed token prices by taking the token price with the median price after
+// dropping the outliers, see InterquartileMedianFunc. Token prices without a price are skipped.
+func TokenPriceAggregator(prices []cciptypes.TokenPrice) cciptypes.TokenPrice {
+	return InterquartileMedianFunc(prices, func(price cciptypes.TokenPrice) *big.Int {
+		return price.Price.Int
+	})
+}
+
 // TimestampedBigAggregator aggregates the fee quoter updates by taking the median of the prices and timestamps
 func TimestampedBigAggregator(updates []plugintypes.TimestampedBig) plugintypes.TimestampedBig {
 	timestamps := make([]time.Time, len(updates))
@@ -107,7 +170,7 @@ func TimestampedBigAggregator(updates []plugintypes.TimestampedBig) plugintypes.
 		timestamps[i] = updates[i].Timestamp
 		prices[i] = updates[i].Value
 	}
-	medianPrice := Median(prices, BigIntComparator)
+	medianPrice := MedianBig(prices)
 	medianTimestamp := Median(timestamps, TimestampComparator)
 	return plugintypes.TimestampedBig{
 		Value:     medianPrice,
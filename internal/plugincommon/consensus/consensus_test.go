@@ -51,12 +51,34 @@ func Test_fChainConsensus(t *testing.T) {
 	for _, scenario := range testCases {
 		t.Run(scenario.name, func(t *testing.T) {
 			minObs := MakeConstantThreshold[cciptypes.ChainSelector](Threshold(scenario.f))
-			result := GetConsensusMap(lggr, "fChain", scenario.inputMap, minObs)
+			result := GetConsensusMap(lggr, "fChain", scenario.inputMap, minObs, nil)
 			assert.Equal(t, scenario.expectedOutput, result)
 		})
 	}
 }
 
+type failureRecorder struct {
+	failures []string
+}
+
+func (r *failureRecorder) TrackConsensusFailure(objectName string, key string) {
+	r.failures = append(r.failures, objectName+":"+key)
+}
+
+func Test_GetConsensusMapTracksFailures(t *testing.T) {
+	inputMap := map[cciptypes.ChainSelector][]int{
+		cciptypes.ChainSelector(1): {5, 5, 5},
+		cciptypes.ChainSelector(2): {5, 5},
+		cciptypes.ChainSelector(3): {5, 5, 5, 3, 3, 3},
+	}
+	minObs := MakeConstantThreshold[cciptypes.ChainSelector](Threshold(3))
+	recorder := &failureRecorder{}
+
+	result := GetConsensusMap(logger.Test(t), "fChain", inputMap, minObs, recorder)
+	assert.Equal(t, map[cciptypes.ChainSelector]int{1: 5}, result)
+	assert.ElementsMatch(t, []string{"fChain:2", "fChain:3"}, recorder.failures)
+}
+
 func Test_GetConsensusMapMedianTimestamp(t *testing.T) {
 	lggr := logger.Test(t)
 	f := 3
@@ -151,6 +173,24 @@ func TestMedianBigInt(t *testing.T) {
 	}
 }
 
+func TestTokenPriceAggregator(t *testing.T) {
+	prices := []cciptypes.TokenPrice{
+		{TokenID: "a", Price: BI(300)},
+		{TokenID: "a", Price: BI(100)},
+		{TokenID: "a", Price: BI(200)},
+	}
+	assert.Equal(t, cciptypes.TokenPrice{TokenID: "a", Price: BI(200)}, TokenPriceAggregator(prices))
+
+	// The outlier and the token price without a price are dropped, the plain median would be 220.
+	prices = append(prices,
+		cciptypes.TokenPrice{TokenID: "a", Price: BI(210)},
+		cciptypes.TokenPrice{TokenID: "a", Price: BI(220)},
+		cciptypes.TokenPrice{TokenID: "a", Price: BI(100000)},
+		cciptypes.TokenPrice{TokenID: "a", Price: cciptypes.BigInt{}},
+	)
+	assert.Equal(t, cciptypes.TokenPrice{TokenID: "a", Price: BI(210)}, TokenPriceAggregator(prices))
+}
+
 func TestMakeConstantThreshold(t *testing.T) {
 	{
 		f := 3
@@ -267,3 +307,18 @@ func TestMakeMultiThreshold_GenericKey(t *testing.T) {
 	_, ok = threshold.Get(4)
 	assert.False(t, ok)
 }
+
+func Test_GetConsensusMapMode(t *testing.T) {
+	items := map[cciptypes.ChainSelector][]cciptypes.SeqNum{
+		1: {10, 10, 10, 11},
+		2: {10, 10, 11, 11},
+		3: {10, 10, 11, 12},
+		4: {10, 10, 10},
+	}
+	minObs := MakeMultiThreshold(map[cciptypes.ChainSelector]int{1: 1, 2: 1, 3: 1}, TwoFPlus1)
+	recorder := &failureRecorder{}
+
+	result := GetConsensusMapMode(logger.Test(t), "seqNums", items, minObs, recorder)
+	assert.Equal(t, map[cciptypes.ChainSelector]cciptypes.SeqNum{1: 10}, result)
+	assert.ElementsMatch(t, []string{"seqNums:2", "seqNums:3", "seqNums:4"}, recorder.failures)
+}
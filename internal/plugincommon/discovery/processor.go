@@ -204,7 +204,7 @@ func (cdp *ContractDiscoveryProcessor) Outcome(
 
 	// fChain consensus - uses the role DON F value because all nodes can observe the home chain.
 	donThresh := consensus.MakeConstantThreshold[cciptypes.ChainSelector](consensus.TwoFPlus1(cdp.fRoleDON))
-	fChain := consensus.GetConsensusMap(cdp.lggr, "fChain", agg.fChain, donThresh)
+	fChain := consensus.GetConsensusMap(cdp.lggr, "fChain", agg.fChain, donThresh, nil)
 	fChainThresh := consensus.MakeMultiThreshold(fChain, consensus.TwoFPlus1)
 	destThresh := consensus.MakeConstantThreshold[cciptypes.ChainSelector](consensus.TwoFPlus1(fChain[cdp.dest]))
 
@@ -214,6 +214,7 @@ func (cdp *ContractDiscoveryProcessor) Outcome(
 		"onramp",
 		agg.onrampAddrs,
 		destThresh,
+		nil,
 	)
 	cdp.lggr.Infow("Determined consensus onramps",
 		"onrampConsensus", onrampConsensus,
@@ -230,6 +231,7 @@ func (cdp *ContractDiscoveryProcessor) Outcome(
 		"nonceManager",
 		agg.nonceManagerAddrs,
 		fChainThresh,
+		nil,
 	)
 	cdp.lggr.Infow("Determined consensus nonce manager",
 		"nonceManagerConsensus", nonceManagerConsensus,
@@ -247,6 +249,7 @@ func (cdp *ContractDiscoveryProcessor) Outcome(
 		"rmnRemote",
 		agg.rmnRemoteAddrs,
 		fChainThresh,
+		nil,
 	)
 	cdp.lggr.Infow("Determined consensus RMNRemote",
 		"rmnRemoteConsensus", rmnRemoteConsensus,
@@ -263,6 +266,7 @@ func (cdp *ContractDiscoveryProcessor) Outcome(
 		"fee quoter",
 		agg.feeQuoterAddrs,
 		fChainThresh,
+		nil,
 	)
 	cdp.lggr.Infow("Determined consensus fee quoter",
 		"feeQuoterConsensus", feeQuoterConsensus,
@@ -280,6 +284,7 @@ func (cdp *ContractDiscoveryProcessor) Outcome(
 		"router",
 		agg.routerAddrs,
 		fChainThresh,
+		nil,
 	)
 	cdp.lggr.Infow("Determined consensus router",
 		"routerConsensus", routerConsensus,
@@ -0,0 +1,51 @@
+package plugincommon
+
+import (
+	"errors"
+	"fmt"
+
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// ObservationFailure describes a single item which could not be observed, e.g. the sequence number of one source
+// chain or the price of one token. Observers return failures alongside whatever they managed to observe, so that a
+// failing read for one chain or token does not prevent observing all the others.
+type ObservationFailure struct {
+	// Object is the name of the observed object, e.g. "onRampSeqNum".
+	Object string
+	// ChainSel is the chain the failure relates to, zero if not chain specific.
+	ChainSel cciptypes.ChainSelector
+	// Token is the token the failure relates to, empty if not token specific.
+	Token string
+	Err   error
+}
+
+func (f ObservationFailure) Error() string {
+	switch {
+	case f.Token != "":
+		return fmt.Sprintf("observe %s for token %s: %v", f.Object, f.Token, f.Err)
+	case f.ChainSel != 0:
+		return fmt.Sprintf("observe %s for chain %d: %v", f.Object, f.ChainSel, f.Err)
+	default:
+		return fmt.Sprintf("observe %s: %v", f.Object, f.Err)
+	}
+}
+
+func (f ObservationFailure) Unwrap() error {
+	return f.Err
+}
+
+// ObservationFailures is a list of observation failures.
+type ObservationFailures []ObservationFailure
+
+// Err joins all failures into a single error, nil if there are no failures.
+func (fs ObservationFailures) Err() error {
+	if len(fs) == 0 {
+		return nil
+	}
+	errs := make([]error, len(fs))
+	for i, f := range fs {
+		errs[i] = f
+	}
+	return errors.Join(errs...)
+}
@@ -9,6 +9,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	plugincommon "github.com/goplugin/plugin-ccip/internal/plugincommon"
+
 	plugintypes "github.com/goplugin/plugin-ccip/internal/plugintypes"
 
 	types "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
@@ -75,7 +77,7 @@ func (_c *MockObserver_ObserveFChain_Call) RunAndReturn(run func() map[ccipocr3.
 }
 
 // ObserveLatestOnRampSeqNums provides a mock function with given fields: ctx, destChain
-func (_m *MockObserver) ObserveLatestOnRampSeqNums(ctx context.Context, destChain ccipocr3.ChainSelector) []plugintypes.SeqNumChain {
+func (_m *MockObserver) ObserveLatestOnRampSeqNums(ctx context.Context, destChain ccipocr3.ChainSelector) ([]plugintypes.SeqNumChain, plugincommon.ObservationFailures) {
 	ret := _m.Called(ctx, destChain)
 
 	if len(ret) == 0 {
@@ -83,6 +85,10 @@ func (_m *MockObserver) ObserveLatestOnRampSeqNums(ctx context.Context, destChai
 	}
 
 	var r0 []plugintypes.SeqNumChain
+	var r1 plugincommon.ObservationFailures
+	if rf, ok := ret.Get(0).(func(context.Context, ccipocr3.ChainSelector) ([]plugintypes.SeqNumChain, plugincommon.ObservationFailures)); ok {
+		return rf(ctx, destChain)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, ccipocr3.ChainSelector) []plugintypes.SeqNumChain); ok {
 		r0 = rf(ctx, destChain)
 	} else {
@@ -91,7 +97,15 @@ func (_m *MockObserver) ObserveLatestOnRampSeqNums(ctx context.Context, destChai
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, ccipocr3.ChainSelector) plugincommon.ObservationFailures); ok {
+		r1 = rf(ctx, destChain)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(plugincommon.ObservationFailures)
+		}
+	}
+
+	return r0, r1
 }
 
 // MockObserver_ObserveLatestOnRampSeqNums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveLatestOnRampSeqNums'
@@ -113,18 +127,18 @@ func (_c *MockObserver_ObserveLatestOnRampSeqNums_Call) Run(run func(ctx context
 	return _c
 }
 
-func (_c *MockObserver_ObserveLatestOnRampSeqNums_Call) Return(_a0 []plugintypes.SeqNumChain) *MockObserver_ObserveLatestOnRampSeqNums_Call {
-	_c.Call.Return(_a0)
+func (_c *MockObserver_ObserveLatestOnRampSeqNums_Call) Return(_a0 []plugintypes.SeqNumChain, _a1 plugincommon.ObservationFailures) *MockObserver_ObserveLatestOnRampSeqNums_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockObserver_ObserveLatestOnRampSeqNums_Call) RunAndReturn(run func(context.Context, ccipocr3.ChainSelector) []plugintypes.SeqNumChain) *MockObserver_ObserveLatestOnRampSeqNums_Call {
+func (_c *MockObserver_ObserveLatestOnRampSeqNums_Call) RunAndReturn(run func(context.Context, ccipocr3.ChainSelector) ([]plugintypes.SeqNumChain, plugincommon.ObservationFailures)) *MockObserver_ObserveLatestOnRampSeqNums_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ObserveMerkleRoots provides a mock function with given fields: ctx, ranges
-func (_m *MockObserver) ObserveMerkleRoots(ctx context.Context, ranges []plugintypes.ChainRange) []ccipocr3.MerkleRootChain {
+func (_m *MockObserver) ObserveMerkleRoots(ctx context.Context, ranges []plugintypes.ChainRange) ([]ccipocr3.MerkleRootChain, plugincommon.ObservationFailures) {
 	ret := _m.Called(ctx, ranges)
 
 	if len(ret) == 0 {
@@ -132,6 +146,10 @@ func (_m *MockObserver) ObserveMerkleRoots(ctx context.Context, ranges []plugint
 	}
 
 	var r0 []ccipocr3.MerkleRootChain
+	var r1 plugincommon.ObservationFailures
+	if rf, ok := ret.Get(0).(func(context.Context, []plugintypes.ChainRange) ([]ccipocr3.MerkleRootChain, plugincommon.ObservationFailures)); ok {
+		return rf(ctx, ranges)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, []plugintypes.ChainRange) []ccipocr3.MerkleRootChain); ok {
 		r0 = rf(ctx, ranges)
 	} else {
@@ -140,7 +158,15 @@ func (_m *MockObserver) ObserveMerkleRoots(ctx context.Context, ranges []plugint
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, []plugintypes.ChainRange) plugincommon.ObservationFailures); ok {
+		r1 = rf(ctx, ranges)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(plugincommon.ObservationFailures)
+		}
+	}
+
+	return r0, r1
 }
 
 // MockObserver_ObserveMerkleRoots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveMerkleRoots'
@@ -162,12 +188,12 @@ func (_c *MockObserver_ObserveMerkleRoots_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockObserver_ObserveMerkleRoots_Call) Return(_a0 []ccipocr3.MerkleRootChain) *MockObserver_ObserveMerkleRoots_Call {
-	_c.Call.Return(_a0)
+func (_c *MockObserver_ObserveMerkleRoots_Call) Return(_a0 []ccipocr3.MerkleRootChain, _a1 plugincommon.ObservationFailures) *MockObserver_ObserveMerkleRoots_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockObserver_ObserveMerkleRoots_Call) RunAndReturn(run func(context.Context, []plugintypes.ChainRange) []ccipocr3.MerkleRootChain) *MockObserver_ObserveMerkleRoots_Call {
+func (_c *MockObserver_ObserveMerkleRoots_Call) RunAndReturn(run func(context.Context, []plugintypes.ChainRange) ([]ccipocr3.MerkleRootChain, plugincommon.ObservationFailures)) *MockObserver_ObserveMerkleRoots_Call {
 	_c.Call.Return(run)
 	return _c
 }
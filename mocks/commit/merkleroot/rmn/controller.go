@@ -135,17 +135,64 @@ func (_c *MockController_ComputeReportSignatures_Call) RunAndReturn(run func(con
 	return _c
 }
 
-// InitConnection provides a mock function with given fields: ctx, commitConfigDigest, rmnHomeConfigDigest, peerIDs
-func (_m *MockController) InitConnection(ctx context.Context, commitConfigDigest ccipocr3.Bytes32, rmnHomeConfigDigest ccipocr3.Bytes32, peerIDs []string) error {
-	ret := _m.Called(ctx, commitConfigDigest, rmnHomeConfigDigest, peerIDs)
+// HealthReport provides a mock function with given fields:
+func (_m *MockController) HealthReport() map[string]error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HealthReport")
+	}
+
+	var r0 map[string]error
+	if rf, ok := ret.Get(0).(func() map[string]error); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]error)
+		}
+	}
+
+	return r0
+}
+
+// MockController_HealthReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HealthReport'
+type MockController_HealthReport_Call struct {
+	*mock.Call
+}
+
+// HealthReport is a helper method to define mock.On call
+func (_e *MockController_Expecter) HealthReport() *MockController_HealthReport_Call {
+	return &MockController_HealthReport_Call{Call: _e.mock.On("HealthReport")}
+}
+
+func (_c *MockController_HealthReport_Call) Run(run func()) *MockController_HealthReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockController_HealthReport_Call) Return(_a0 map[string]error) *MockController_HealthReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockController_HealthReport_Call) RunAndReturn(run func() map[string]error) *MockController_HealthReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InitConnection provides a mock function with given fields: ctx, commitConfigDigest, rmnHomeConfigDigests, peerIDs
+func (_m *MockController) InitConnection(ctx context.Context, commitConfigDigest ccipocr3.Bytes32, rmnHomeConfigDigests []ccipocr3.Bytes32, peerIDs []string) error {
+	ret := _m.Called(ctx, commitConfigDigest, rmnHomeConfigDigests, peerIDs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for InitConnection")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, ccipocr3.Bytes32, ccipocr3.Bytes32, []string) error); ok {
-		r0 = rf(ctx, commitConfigDigest, rmnHomeConfigDigest, peerIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, ccipocr3.Bytes32, []ccipocr3.Bytes32, []string) error); ok {
+		r0 = rf(ctx, commitConfigDigest, rmnHomeConfigDigests, peerIDs)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -161,15 +208,15 @@ type MockController_InitConnection_Call struct {
 // InitConnection is a helper method to define mock.On call
 //   - ctx context.Context
 //   - commitConfigDigest ccipocr3.Bytes32
-//   - rmnHomeConfigDigest ccipocr3.Bytes32
+//   - rmnHomeConfigDigests []ccipocr3.Bytes32
 //   - peerIDs []string
-func (_e *MockController_Expecter) InitConnection(ctx interface{}, commitConfigDigest interface{}, rmnHomeConfigDigest interface{}, peerIDs interface{}) *MockController_InitConnection_Call {
-	return &MockController_InitConnection_Call{Call: _e.mock.On("InitConnection", ctx, commitConfigDigest, rmnHomeConfigDigest, peerIDs)}
+func (_e *MockController_Expecter) InitConnection(ctx interface{}, commitConfigDigest interface{}, rmnHomeConfigDigests interface{}, peerIDs interface{}) *MockController_InitConnection_Call {
+	return &MockController_InitConnection_Call{Call: _e.mock.On("InitConnection", ctx, commitConfigDigest, rmnHomeConfigDigests, peerIDs)}
 }
 
-func (_c *MockController_InitConnection_Call) Run(run func(ctx context.Context, commitConfigDigest ccipocr3.Bytes32, rmnHomeConfigDigest ccipocr3.Bytes32, peerIDs []string)) *MockController_InitConnection_Call {
+func (_c *MockController_InitConnection_Call) Run(run func(ctx context.Context, commitConfigDigest ccipocr3.Bytes32, rmnHomeConfigDigests []ccipocr3.Bytes32, peerIDs []string)) *MockController_InitConnection_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(ccipocr3.Bytes32), args[2].(ccipocr3.Bytes32), args[3].([]string))
+		run(args[0].(context.Context), args[1].(ccipocr3.Bytes32), args[2].([]ccipocr3.Bytes32), args[3].([]string))
 	})
 	return _c
 }
@@ -179,7 +226,7 @@ func (_c *MockController_InitConnection_Call) Return(_a0 error) *MockController_
 	return _c
 }
 
-func (_c *MockController_InitConnection_Call) RunAndReturn(run func(context.Context, ccipocr3.Bytes32, ccipocr3.Bytes32, []string) error) *MockController_InitConnection_Call {
+func (_c *MockController_InitConnection_Call) RunAndReturn(run func(context.Context, ccipocr3.Bytes32, []ccipocr3.Bytes32, []string) error) *MockController_InitConnection_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -104,6 +104,7 @@ const (
 	// Used by the rmn remote reader.
 	MethodNameGetVersionedConfig    = "GetVersionedConfig"
 	MethodNameGetReportDigestHeader = "GetReportDigestHeader"
+	MethodNameGetCursedSubjects     = "GetCursedSubjects"
 )
 
 // Event Names
@@ -2,6 +2,7 @@ package reader
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
@@ -33,15 +34,22 @@ import (
 // can be generated.
 type ccipChainReader struct {
 	lggr            logger.Logger
+	homeChain       HomeChain
 	contractReaders map[cciptypes.ChainSelector]contractreader.Extended
 	contractWriters map[cciptypes.ChainSelector]types.ChainWriter
 	destChain       cciptypes.ChainSelector
 	offrampAddress  string
+
+	// sourceConfidenceMu guards sourceConfidence, the confidence level at which each source chain is read. It is
+	// resolved from the home chain once per Sync, so that all the reads between two syncs use the same levels.
+	sourceConfidenceMu sync.RWMutex
+	sourceConfidence   map[cciptypes.ChainSelector]primitives.ConfidenceLevel
 }
 
 func newCCIPChainReaderInternal(
 	ctx context.Context,
 	lggr logger.Logger,
+	homeChain HomeChain,
 	contractReaders map[cciptypes.ChainSelector]contractreader.ContractReaderFacade,
 	contractWriters map[cciptypes.ChainSelector]types.ChainWriter,
 	destChain cciptypes.ChainSelector,
@@ -54,6 +62,7 @@ func newCCIPChainReaderInternal(
 
 	reader := &ccipChainReader{
 		lggr:            lggr,
+		homeChain:       homeChain,
 		contractReaders: crs,
 		contractWriters: contractWriters,
 		destChain:       destChain,
@@ -302,7 +311,7 @@ func (r *ccipChainReader) MsgsBetweenSeqNums(
 		query.KeyFilter{
 			Key: consts.EventNameCCIPMessageSent,
 			Expressions: []query.Expression{
-				query.Confidence(primitives.Finalized),
+				query.Confidence(r.sourceChainConfidence(sourceChainSelector, primitives.Finalized)),
 			},
 		},
 		query.LimitAndSort{
@@ -366,7 +375,7 @@ func (r *ccipChainReader) GetExpectedNextSequenceNumber(
 		ctx,
 		consts.ContractNameOnRamp,
 		consts.MethodNameGetExpectedNextSequenceNumber,
-		primitives.Unconfirmed,
+		r.sourceChainConfidence(sourceChainSelector, primitives.Unconfirmed),
 		map[string]any{
 			"destChainSelector": destChainSelector,
 		},
@@ -379,6 +388,113 @@ func (r *ccipChainReader) GetExpectedNextSequenceNumber(
 	return cciptypes.SeqNum(expectedNextSequenceNumber), nil
 }
 
+// globalCurseSubject is the RMN curse subject which curses all chains, see RMNRemote.sol.
+var globalCurseSubject = [16]byte{0x01, 15: 0x01}
+
+// GetRmnCurseInfo implements CCIPReader.
+func (r *ccipChainReader) GetRmnCurseInfo(
+	ctx context.Context,
+	destChainSelector cciptypes.ChainSelector,
+	sourceChainSelectors []cciptypes.ChainSelector,
+) (CurseInfo, error) {
+	if destChainSelector != r.destChain {
+		return CurseInfo{}, fmt.Errorf("expected destination chain %d, got %d", r.destChain, destChainSelector)
+	}
+
+	if err := validateExtendedReaderExistence(r.contractReaders, destChainSelector); err != nil {
+		return CurseInfo{}, err
+	}
+
+	var cursedSubjects [][16]byte
+	err := r.contractReaders[destChainSelector].ExtendedGetLatestValue(
+		ctx,
+		consts.ContractNameRMNRemote,
+		consts.MethodNameGetCursedSubjects,
+		primitives.Unconfirmed,
+		map[string]any{},
+		&cursedSubjects,
+	)
+	if err != nil {
+		return CurseInfo{}, fmt.Errorf("get RMNRemote cursed subjects: %w", err)
+	}
+
+	return newCurseInfo(cursedSubjects, destChainSelector, sourceChainSelectors), nil
+}
+
+// newCurseInfo derives the curse state of the provided chains from the cursed subjects of RMNRemote. The curse subject
+// of a chain is its selector as a big endian 16 byte value.
+func newCurseInfo(
+	cursedSubjects [][16]byte,
+	destChainSelector cciptypes.ChainSelector,
+	sourceChainSelectors []cciptypes.ChainSelector,
+) CurseInfo {
+	cursed := make(map[[16]byte]struct{}, len(cursedSubjects))
+	for _, subject := range cursedSubjects {
+		cursed[subject] = struct{}{}
+	}
+	isCursed := func(chain cciptypes.ChainSelector) bool {
+		_, ok := cursed[chainSelectorToCurseSubject(chain)]
+		return ok
+	}
+
+	_, globalCurse := cursed[globalCurseSubject]
+	curseInfo := CurseInfo{
+		CursedSourceChains: make(map[cciptypes.ChainSelector]bool, len(sourceChainSelectors)),
+		CursedDestination:  isCursed(destChainSelector),
+		GlobalCurse:        globalCurse,
+	}
+	for _, chain := range sourceChainSelectors {
+		curseInfo.CursedSourceChains[chain] = isCursed(chain)
+	}
+	return curseInfo
+}
+
+func chainSelectorToCurseSubject(chain cciptypes.ChainSelector) [16]byte {
+	var subject [16]byte
+	binary.BigEndian.PutUint64(subject[8:], uint64(chain))
+	return subject
+}
+
+// sourceChainConfidence returns the confidence level at which data is read from the provided source chain, as resolved
+// by the last Sync. The provided default is used if the chain sets no OptimisticConfirmations or its configuration was
+// unavailable.
+func (r *ccipChainReader) sourceChainConfidence(
+	chain cciptypes.ChainSelector,
+	defaultConfidence primitives.ConfidenceLevel,
+) primitives.ConfidenceLevel {
+	r.sourceConfidenceMu.RLock()
+	defer r.sourceConfidenceMu.RUnlock()
+	if confidence, ok := r.sourceConfidence[chain]; ok {
+		return confidence
+	}
+	return defaultConfidence
+}
+
+// resolveSourceChainConfidence derives the confidence level of every chain which sets OptimisticConfirmations on the
+// home chain. The previously resolved levels are kept if the home chain configuration is unavailable.
+func (r *ccipChainReader) resolveSourceChainConfidence() {
+	if r.homeChain == nil {
+		return
+	}
+	chainConfigs, err := r.homeChain.GetAllChainConfigs()
+	if err != nil {
+		r.lggr.Warnw("failed to get chain configs, keeping the previous confidence levels", "err", err)
+		return
+	}
+
+	confidence := make(map[cciptypes.ChainSelector]primitives.ConfidenceLevel, len(chainConfigs))
+	for chain, chainConfig := range chainConfigs {
+		if chainConfig.Config.OptimisticConfirmations == 0 {
+			continue
+		}
+		confidence[chain] = chainConfig.Config.ConfidenceLevel()
+	}
+
+	r.sourceConfidenceMu.Lock()
+	defer r.sourceConfidenceMu.Unlock()
+	r.sourceConfidence = confidence
+}
+
 func (r *ccipChainReader) NextSeqNum(
 	ctx context.Context, chains []cciptypes.ChainSelector,
 ) ([]cciptypes.SeqNum, error) {
@@ -748,6 +864,9 @@ func (r *ccipChainReader) DiscoverContracts(ctx context.Context) (ContractAddres
 
 // Sync goes through the input contracts and binds them to the contract reader.
 func (r *ccipChainReader) Sync(ctx context.Context, contracts ContractAddresses) error {
+	// Sync runs once per round, the confidence levels are resolved here instead of on every read.
+	r.resolveSourceChainConfidence()
+
 	var errs []error
 	for contractName, chainSelToAddress := range contracts {
 		for chainSel, address := range chainSelToAddress {
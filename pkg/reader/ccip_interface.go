@@ -37,9 +37,12 @@ func (ca ContractAddresses) Append(contract string, chain cciptypes.ChainSelecto
 	return resp
 }
 
+// NewCCIPChainReader creates a CCIPReader. Messages are read from each source chain with the confidence level derived
+// from the chain's OptimisticConfirmations in the home chain configuration, resolved on every Sync.
 func NewCCIPChainReader(
 	ctx context.Context,
 	lggr logger.Logger,
+	homeChain HomeChain,
 	contractReaders map[cciptypes.ChainSelector]contractreader.ContractReaderFacade,
 	contractWriters map[cciptypes.ChainSelector]types.ChainWriter,
 	destChain cciptypes.ChainSelector,
@@ -48,6 +51,7 @@ func NewCCIPChainReader(
 	return newCCIPChainReaderInternal(
 		ctx,
 		lggr,
+		homeChain,
 		contractReaders,
 		contractWriters,
 		destChain,
@@ -59,18 +63,35 @@ func NewCCIPChainReader(
 func NewCCIPReaderWithExtendedContractReaders(
 	ctx context.Context,
 	lggr logger.Logger,
+	homeChain HomeChain,
 	contractReaders map[cciptypes.ChainSelector]contractreader.Extended,
 	contractWriters map[cciptypes.ChainSelector]types.ChainWriter,
 	destChain cciptypes.ChainSelector,
 	offrampAddress []byte,
 ) CCIPReader {
-	cr := newCCIPChainReaderInternal(ctx, lggr, nil, contractWriters, destChain, offrampAddress)
+	cr := newCCIPChainReaderInternal(ctx, lggr, homeChain, nil, contractWriters, destChain, offrampAddress)
 	for ch, extendedCr := range contractReaders {
 		cr.WithExtendedContractReader(ch, extendedCr)
 	}
 	return cr
 }
 
+// CurseInfo contains the RMN curse state relevant to the lanes of a destination chain.
+type CurseInfo struct {
+	// CursedSourceChains contains the curse state of each requested source chain.
+	CursedSourceChains map[cciptypes.ChainSelector]bool
+	// CursedDestination is true if the destination chain is cursed.
+	CursedDestination bool
+	// GlobalCurse is true if all chains are cursed.
+	GlobalCurse bool
+}
+
+// IsLaneCursed returns true if messages from the source chain can not be processed on the destination chain, either
+// because the source chain, the destination chain or all chains are cursed.
+func (ci CurseInfo) IsLaneCursed(sourceChain cciptypes.ChainSelector) bool {
+	return ci.GlobalCurse || ci.CursedDestination || ci.CursedSourceChains[sourceChain]
+}
+
 type CCIPReader interface {
 	// CommitReportsGTETimestamp reads the requested chain starting at a given timestamp
 	// and finds all ReportAccepted up to the provided limit.
@@ -100,6 +121,8 @@ type CCIPReader interface {
 
 	// GetExpectedNextSequenceNumber returns the next sequence number to be used
 	// in the onramp.
+	// The onramp is read unconfirmed, unless the source chain sets OptimisticConfirmations on the home chain: with
+	// more than one optimistic confirmation, it is read finalized like the messages of the chain.
 	GetExpectedNextSequenceNumber(
 		ctx context.Context,
 		sourceChainSelector, destChainSelector cciptypes.ChainSelector,
@@ -141,6 +164,14 @@ type CCIPReader interface {
 		destChainSelector cciptypes.ChainSelector,
 	) (rmntypes.RemoteConfig, error)
 
+	// GetRmnCurseInfo reads the cursed subjects from the RMNRemote contract of the destination chain and returns the
+	// global curse state, the curse state of the destination chain and that of each provided source chain.
+	GetRmnCurseInfo(
+		ctx context.Context,
+		destChainSelector cciptypes.ChainSelector,
+		sourceChainSelectors []cciptypes.ChainSelector,
+	) (CurseInfo, error)
+
 	// DiscoverContracts reads from all available contract readers to discover contract addresses.
 	DiscoverContracts(ctx context.Context) (ContractAddresses, error)
 
@@ -152,5 +183,6 @@ type CCIPReader interface {
 
 	// Sync can be used to perform frequent syncing operations inside the reader implementation.
 	// Returns a bool indicating whether something was updated.
+	// The confidence levels at which source chains are read are resolved from the home chain on every Sync.
 	Sync(ctx context.Context, contracts ContractAddresses) error
 }
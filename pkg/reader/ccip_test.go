@@ -19,8 +19,10 @@ import (
 	"github.com/goplugin/plugin-common/pkg/types/query/primitives"
 	"github.com/goplugin/plugin-common/pkg/utils/tests"
 
+	"github.com/goplugin/plugin-ccip/chainconfig"
 	typeconv "github.com/goplugin/plugin-ccip/internal/libs/typeconv"
 	"github.com/goplugin/plugin-ccip/internal/plugintypes"
+	readermock "github.com/goplugin/plugin-ccip/mocks/internal_/reader"
 	reader_mocks "github.com/goplugin/plugin-ccip/mocks/pkg/contractreader"
 	"github.com/goplugin/plugin-ccip/pkg/consts"
 	"github.com/goplugin/plugin-ccip/pkg/contractreader"
@@ -65,6 +67,7 @@ func TestCCIPChainReader_getSourceChainsConfig(t *testing.T) {
 	ccipReader := newCCIPChainReaderInternal(
 		tests.Context(t),
 		logger.Test(t),
+		nil,
 		map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{
 			chainA: sourceCRs[chainA],
 			chainB: sourceCRs[chainB],
@@ -859,6 +862,7 @@ func TestCCIPChainReader_getDestFeeQuoterStaticConfig(t *testing.T) {
 	ccipReader := newCCIPChainReaderInternal(
 		tests.Context(t),
 		logger.Test(t),
+		nil,
 		map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{
 			chainC: destCR,
 		}, nil, chainC, offrampAddress,
@@ -905,6 +909,7 @@ func TestCCIPChainReader_getFeeQuoterTokenPriceUSD(t *testing.T) {
 	ccipReader := newCCIPChainReaderInternal(
 		tests.Context(t),
 		logger.Test(t),
+		nil,
 		map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{
 			chainC: destCR,
 		}, nil, chainC, offrampAddress,
@@ -957,11 +962,10 @@ func TestCCIPChainReader_LinkPriceUSD(t *testing.T) {
 	contractReaders := make(map[cciptypes.ChainSelector]contractreader.Extended)
 	contractReaders[chainC] = destCR
 	ccipReader := ccipChainReader{
-		logger.Test(t),
-		contractReaders,
-		nil,
-		chainC,
-		string(offrampAddress),
+		lggr:            logger.Test(t),
+		contractReaders: contractReaders,
+		destChain:       chainC,
+		offrampAddress:  string(offrampAddress),
 	}
 
 	require.NoError(t, ccipReader.contractReaders[chainC].Bind(
@@ -973,3 +977,152 @@ func TestCCIPChainReader_LinkPriceUSD(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, cciptypes.NewBigIntFromInt64(145), price)
 }
+
+func TestCCIPChainReader_GetExpectedNextSequenceNumber_ChainConfidence(t *testing.T) {
+	testCases := []struct {
+		name          string
+		chainConfig   ChainConfig
+		chainCfgErr   error
+		expConfidence primitives.ConfidenceLevel
+	}{
+		{
+			name:          "single optimistic confirmation reads unconfirmed",
+			chainConfig:   ChainConfig{Config: chainconfig.ChainConfig{OptimisticConfirmations: 1}},
+			expConfidence: primitives.Unconfirmed,
+		},
+		{
+			name:          "multiple optimistic confirmations read finalized",
+			chainConfig:   ChainConfig{Config: chainconfig.ChainConfig{OptimisticConfirmations: 5}},
+			expConfidence: primitives.Finalized,
+		},
+		{
+			name:          "unset optimistic confirmations read unconfirmed",
+			chainConfig:   ChainConfig{Config: chainconfig.ChainConfig{}},
+			expConfidence: primitives.Unconfirmed,
+		},
+		{
+			name:          "missing chain config reads unconfirmed",
+			chainCfgErr:   errors.New("chain config not found"),
+			expConfidence: primitives.Unconfirmed,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			homeChain := readermock.NewMockHomeChain(t)
+			chainConfigs := map[cciptypes.ChainSelector]ChainConfig{chainA: tc.chainConfig}
+			if tc.chainCfgErr != nil {
+				chainConfigs = nil
+			}
+			// The chain configs are resolved once on Sync, not on every read.
+			homeChain.EXPECT().GetAllChainConfigs().Return(chainConfigs, tc.chainCfgErr).Once()
+
+			sourceCR := reader_mocks.NewMockExtended(t)
+			sourceCR.EXPECT().ExtendedGetLatestValue(
+				mock.Anything,
+				consts.ContractNameOnRamp,
+				consts.MethodNameGetExpectedNextSequenceNumber,
+				tc.expConfidence,
+				map[string]any{"destChainSelector": chainC},
+				mock.Anything,
+			).Return(nil).Run(withReturnValueOverridden(func(returnVal interface{}) {
+				*returnVal.(*uint64) = 10
+			})).Twice()
+
+			ccipReader := &ccipChainReader{
+				lggr:      logger.Test(t),
+				homeChain: homeChain,
+				contractReaders: map[cciptypes.ChainSelector]contractreader.Extended{
+					chainA: sourceCR,
+				},
+				destChain: chainC,
+			}
+
+			require.NoError(t, ccipReader.Sync(tests.Context(t), nil))
+			for i := 0; i < 2; i++ {
+				seqNum, err := ccipReader.GetExpectedNextSequenceNumber(tests.Context(t), chainA, chainC)
+				require.NoError(t, err)
+				assert.Equal(t, cciptypes.SeqNum(10), seqNum)
+			}
+		})
+	}
+}
+
+func TestCCIPChainReader_GetRmnCurseInfo(t *testing.T) {
+	testCases := []struct {
+		name           string
+		cursedSubjects [][16]byte
+		expCurseInfo   CurseInfo
+	}{
+		{
+			name: "nothing cursed",
+			expCurseInfo: CurseInfo{
+				CursedSourceChains: map[cciptypes.ChainSelector]bool{chainA: false, chainB: false},
+			},
+		},
+		{
+			name:           "source chain cursed",
+			cursedSubjects: [][16]byte{chainSelectorToCurseSubject(chainB)},
+			expCurseInfo: CurseInfo{
+				CursedSourceChains: map[cciptypes.ChainSelector]bool{chainA: false, chainB: true},
+			},
+		},
+		{
+			name:           "destination chain cursed",
+			cursedSubjects: [][16]byte{chainSelectorToCurseSubject(chainC)},
+			expCurseInfo: CurseInfo{
+				CursedSourceChains: map[cciptypes.ChainSelector]bool{chainA: false, chainB: false},
+				CursedDestination:  true,
+			},
+		},
+		{
+			name:           "global curse",
+			cursedSubjects: [][16]byte{globalCurseSubject},
+			expCurseInfo: CurseInfo{
+				CursedSourceChains: map[cciptypes.ChainSelector]bool{chainA: false, chainB: false},
+				GlobalCurse:        true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			destCR := reader_mocks.NewMockExtended(t)
+			destCR.EXPECT().ExtendedGetLatestValue(
+				mock.Anything,
+				consts.ContractNameRMNRemote,
+				consts.MethodNameGetCursedSubjects,
+				primitives.Unconfirmed,
+				map[string]any{},
+				mock.Anything,
+			).Return(nil).Run(withReturnValueOverridden(func(returnVal interface{}) {
+				*returnVal.(*[][16]byte) = tc.cursedSubjects
+			}))
+
+			ccipReader := &ccipChainReader{
+				lggr: logger.Test(t),
+				contractReaders: map[cciptypes.ChainSelector]contractreader.Extended{
+					chainC: destCR,
+				},
+				destChain: chainC,
+			}
+
+			curseInfo, err := ccipReader.GetRmnCurseInfo(
+				tests.Context(t), chainC, []cciptypes.ChainSelector{chainA, chainB})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expCurseInfo, curseInfo)
+			assert.Equal(t, tc.expCurseInfo.GlobalCurse || tc.expCurseInfo.CursedDestination ||
+				tc.expCurseInfo.CursedSourceChains[chainB], curseInfo.IsLaneCursed(chainB))
+		})
+	}
+}
+
+func Test_chainSelectorToCurseSubject(t *testing.T) {
+	subject := chainSelectorToCurseSubject(cciptypes.ChainSelector(0x0102030405060708))
+	assert.Equal(t, [16]byte{8: 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}, subject)
+	assert.Equal(t, [16]byte{0x01, 15: 0x01}, globalCurseSubject)
+}
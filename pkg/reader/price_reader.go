@@ -2,10 +2,15 @@ package reader
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
-	"golang.org/x/sync/errgroup"
+	"golang.org/x/exp/maps"
 
 	"github.com/goplugin/plugin-libocr/offchainreporting2plus/types"
 	ocr2types "github.com/goplugin/plugin-libocr/offchainreporting2plus/types"
@@ -15,6 +20,7 @@ import (
 	"github.com/goplugin/plugin-common/pkg/types/query/primitives"
 
 	typeconv "github.com/goplugin/plugin-ccip/internal/libs/typeconv"
+	"github.com/goplugin/plugin-ccip/internal/plugincommon/consensus"
 	"github.com/goplugin/plugin-ccip/internal/plugintypes"
 	"github.com/goplugin/plugin-ccip/pkg/consts"
 	"github.com/goplugin/plugin-ccip/pkg/contractreader"
@@ -22,12 +28,23 @@ import (
 	"github.com/goplugin/plugin-ccip/pluginconfig"
 )
 
+var (
+	// ErrFeedPriceInvalid is returned when a feed answer is not a valid price.
+	ErrFeedPriceInvalid = errors.New("invalid feed price")
+	// ErrFeedPriceStale is returned when a feed answer is older than allowed.
+	ErrFeedPriceStale = errors.New("stale feed price")
+	// ErrFeedPriceOutOfBounds is returned when a feed answer is out of the configured price bounds of the token.
+	ErrFeedPriceOutOfBounds = errors.New("feed price out of bounds")
+)
+
 type PriceReader interface {
 	// GetFeedPricesUSD returns the prices of the provided tokens in USD normalized to e18.
 	//	1 USDC = 1.00 USD per full token, each full token is 1e6 units -> 1 * 1e18 * 1e18 / 1e6 = 1e30
 	//	1 ETH = 2,000 USD per full token, each full token is 1e18 units -> 2000 * 1e18 * 1e18 / 1e18 = 2_000e18
 	//	1 PLI = 5.00 USD per full token, each full token is 1e18 units -> 5 * 1e18 * 1e18 / 1e18 = 5e18
 	// The order of the returned prices corresponds to the order of the provided tokens.
+	// If only some prices could be read, the prices which could not be read are nil and a TokenPriceErrors
+	// error is returned alongside the prices which were read.
 	GetFeedPricesUSD(ctx context.Context, tokens []ocr2types.Account) ([]*big.Int, error)
 
 	// GetFeeQuoterTokenUpdates returns the latest token prices from the FeeQuoter on the specified chain
@@ -38,6 +55,25 @@ type PriceReader interface {
 	) (map[ocr2types.Account]plugintypes.TimestampedBig, error)
 }
 
+// TokenPriceErrors is returned by GetFeedPricesUSD when the prices of some tokens could not be read. It maps each of
+// these tokens to the error encountered while reading its price.
+type TokenPriceErrors map[ocr2types.Account]error
+
+func (e TokenPriceErrors) Error() string {
+	tokens := maps.Keys(e)
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i] < tokens[j] })
+	errs := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		errs = append(errs, fmt.Sprintf("token price for %s: %v", token, e[token]))
+	}
+	return fmt.Sprintf("failed to get %d token prices: %s", len(e), strings.Join(errs, "; "))
+}
+
+// Unwrap returns the errors of all the tokens, so that errors.Is and errors.As can be used on the token errors.
+func (e TokenPriceErrors) Unwrap() []error {
+	return maps.Values(e)
+}
+
 type priceReader struct {
 	lggr         logger.Logger
 	chainReaders map[ccipocr3.ChainSelector]contractreader.ContractReaderFacade
@@ -148,45 +184,207 @@ func (pr *priceReader) GetFeeQuoterTokenUpdates(
 func (pr *priceReader) GetFeedPricesUSD(
 	ctx context.Context, tokens []ocr2types.Account,
 ) ([]*big.Int, error) {
-	prices := make([]*big.Int, len(tokens))
-	if pr.feedChainReader() == nil {
-		pr.lggr.Debug("node does not support feed chain")
-		return prices, nil
+	// The prices of the tokens with a USD price feed are read first so that the price of a base token is read once,
+	// whether it is requested or not and however many requested tokens are derived from it.
+	feedTokens := make(map[ocr2types.Account]struct{}, len(tokens))
+	for _, token := range tokens {
+		tokenInfo, ok := pr.tokenInfo[token]
+		switch {
+		case !ok:
+		case tokenInfo.DerivedPrice != nil:
+			if baseTokenInfo, ok := pr.tokenInfo[tokenInfo.DerivedPrice.BaseToken]; ok && baseTokenInfo.DerivedPrice == nil {
+				feedTokens[tokenInfo.DerivedPrice.BaseToken] = struct{}{}
+			}
+		default:
+			feedTokens[token] = struct{}{}
+		}
+	}
+	rawPrices, rawErrs := readTokenPricesConcurrently(maps.Keys(feedTokens),
+		func(token ocr2types.Account) (*big.Int, error) {
+			return pr.getRawTokenPriceE18(ctx, token, pr.tokenInfo[token])
+		})
+
+	pricesByToken, tokenErrs := readTokenPricesConcurrently(tokens, func(token ocr2types.Account) (*big.Int, error) {
+		tokenInfo, ok := pr.tokenInfo[token]
+		if !ok {
+			return nil, fmt.Errorf("token info not found")
+		}
+
+		rawTokenPrice, err := rawPrices[token], rawErrs[token]
+		if tokenInfo.DerivedPrice != nil {
+			rawTokenPrice, err = pr.getDerivedRawTokenPriceE18(ctx, token, tokenInfo, rawPrices, rawErrs)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return calculateUsdPer1e18TokenAmount(rawTokenPrice, tokenInfo.Decimals), nil
+	})
+
+	if len(tokenErrs) == len(tokens) && len(tokens) > 0 {
+		return nil, fmt.Errorf("failed to get any token price successfully: %w", tokenErrs)
 	}
-	eg := new(errgroup.Group)
+
+	prices := make([]*big.Int, len(tokens))
 	for idx, token := range tokens {
-		idx := idx
+		prices[idx] = pricesByToken[token]
+	}
+	if len(tokenErrs) > 0 {
+		return prices, tokenErrs
+	}
+
+	return prices, nil
+}
+
+// readTokenPricesConcurrently reads the price of each token concurrently.
+func readTokenPricesConcurrently(
+	tokens []ocr2types.Account,
+	read func(token ocr2types.Account) (*big.Int, error),
+) (map[ocr2types.Account]*big.Int, TokenPriceErrors) {
+	prices := make(map[ocr2types.Account]*big.Int, len(tokens))
+	tokenErrs := make(TokenPriceErrors)
+	mu := &sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, token := range tokens {
 		token := token
-		eg.Go(func() error {
-			boundContract := commontypes.BoundContract{
-				Address: pr.tokenInfo[token].AggregatorAddress,
-				Name:    consts.ContractNamePriceAggregator,
-			}
-			rawTokenPrice, err := pr.getRawTokenPriceE18Normalized(ctx, token, boundContract, pr.feedChainReader())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			price, err := read(token)
+
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
-				return fmt.Errorf("token price for %s: %w", token, err)
-			}
-			tokenInfo, ok := pr.tokenInfo[token]
-			if !ok {
-				return fmt.Errorf("get tokenInfo for %s: %w", token, err)
+				tokenErrs[token] = err
+				return
 			}
+			prices[token] = price
+		}()
+	}
+	wg.Wait()
 
-			prices[idx] = calculateUsdPer1e18TokenAmount(rawTokenPrice, tokenInfo.Decimals)
-			return nil
-		})
+	return prices, tokenErrs
+}
+
+// getRawTokenPriceE18 returns the USD price of a full token with 18 decimals.
+// If the token has multiple price sources, the median of the prices that could be read is returned as long as
+// at least MinPriceSources were read, sources which are unavailable are skipped.
+func (pr *priceReader) getRawTokenPriceE18(
+	ctx context.Context,
+	token ocr2types.Account,
+	tokenInfo pluginconfig.TokenInfo,
+) (*big.Int, error) {
+	sources := tokenInfo.AllPriceSources(pr.feedChain)
+	rawTokenPrices := make([]*big.Int, 0, len(sources))
+	var errs []error
+	for _, source := range sources {
+		chainReader, ok := pr.chainReaders[source.ChainSelector]
+		if !ok {
+			errs = append(errs, fmt.Errorf("feed chain %d of aggregator %s not supported",
+				source.ChainSelector, source.AggregatorAddress))
+			continue
+		}
+
+		boundContract := commontypes.BoundContract{
+			Address: source.AggregatorAddress,
+			Name:    consts.ContractNamePriceAggregator,
+		}
+		rawTokenPrice, err := pr.getRawTokenPriceE18Normalized(ctx, token, tokenInfo, boundContract, chainReader)
+		if err != nil {
+			pr.lggr.Warnw("failed to read token price source",
+				"token", token, "aggregator", source.AggregatorAddress, "chain", source.ChainSelector, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+		rawTokenPrices = append(rawTokenPrices, rawTokenPrice)
+	}
+
+	minSources := max(tokenInfo.MinPriceSources, 1)
+	if len(rawTokenPrices) < minSources {
+		return nil, fmt.Errorf("read %d price sources, at least %d required: %w",
+			len(rawTokenPrices), minSources, errors.Join(errs...))
+	}
+
+	return consensus.MedianBig(rawTokenPrices), nil
+}
+
+// getDerivedRawTokenPriceE18 returns the USD price of a full token with 18 decimals computed as
+// price(base token) * rate, where rate is the amount of base tokens a full token is worth.
+// The raw prices of the base tokens are read beforehand, the rate is read only if the base token price is available.
+func (pr *priceReader) getDerivedRawTokenPriceE18(
+	ctx context.Context,
+	token ocr2types.Account,
+	tokenInfo pluginconfig.TokenInfo,
+	baseRawTokenPrices map[ocr2types.Account]*big.Int,
+	baseErrs TokenPriceErrors,
+) (*big.Int, error) {
+	baseToken := tokenInfo.DerivedPrice.BaseToken
+	baseTokenInfo, ok := pr.tokenInfo[baseToken]
+	if !ok || baseTokenInfo.DerivedPrice != nil {
+		return nil, fmt.Errorf("base token %s of derived token %s has no USD price feed", baseToken, token)
+	}
+
+	baseRawTokenPrice, ok := baseRawTokenPrices[baseToken]
+	if !ok {
+		return nil, fmt.Errorf("base token %s price: %w", baseToken, baseErrs[baseToken])
+	}
+
+	rate, err := pr.getRateE18(ctx, token, tokenInfo)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := eg.Wait(); err != nil {
-		return nil, fmt.Errorf("failed to get all token prices successfully: %w", err)
+	rawTokenPrice := new(big.Int).Mul(baseRawTokenPrice, rate)
+	rawTokenPrice.Div(rawTokenPrice, big.NewInt(1e18))
+	if err := checkFeedPriceBounds(rawTokenPrice, tokenInfo); err != nil {
+		return nil, fmt.Errorf("derived price of token %s: %w", token, err)
+	}
+	return rawTokenPrice, nil
+}
+
+// getRateE18 reads the exchange rate of a derived token, normalized to 18 decimals.
+func (pr *priceReader) getRateE18(
+	ctx context.Context,
+	token ocr2types.Account,
+	tokenInfo pluginconfig.TokenInfo,
+) (*big.Int, error) {
+	rateSource := tokenInfo.DerivedPrice.Rate
+	chain := rateSource.ChainSelector
+	if chain == 0 {
+		chain = pr.feedChain
+	}
+	chainReader, ok := pr.chainReaders[chain]
+	if !ok {
+		return nil, fmt.Errorf("chain %d of the rate of token %s not supported", chain, token)
 	}
 
-	for _, price := range prices {
-		if price == nil {
-			return nil, fmt.Errorf("failed to get all token prices successfully, some prices are nil")
+	if !rateSource.IsContractRead() {
+		boundContract := commontypes.BoundContract{
+			Address: rateSource.Address,
+			Name:    consts.ContractNamePriceAggregator,
 		}
+		return pr.readLatestAnswerE18(ctx, token, boundContract, chainReader, tokenInfo.MaxFeedStaleness.Duration())
 	}
 
-	return prices, nil
+	boundContract := commontypes.BoundContract{
+		Address: rateSource.Address,
+		Name:    rateSource.ContractName,
+	}
+	var rate *big.Int
+	if err :=
+		chainReader.GetLatestValue(
+			ctx,
+			boundContract.ReadIdentifier(rateSource.MethodName),
+			primitives.Unconfirmed,
+			nil,
+			&rate,
+		); err != nil {
+		return nil, fmt.Errorf("%s.%s call failed for token %s: %w",
+			rateSource.ContractName, rateSource.MethodName, token, err)
+	}
+	if rate == nil || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("rate of token %s: %w: non-positive rate %s", token, ErrFeedPriceInvalid, rate)
+	}
+	return normalizeE18(rate, rateSource.Decimals), nil
 }
 
 func (pr *priceReader) getFeedDecimals(
@@ -210,11 +408,35 @@ func (pr *priceReader) getFeedDecimals(
 	return decimals, nil
 }
 
+// getRawTokenPriceE18Normalized reads the latest answer of the token aggregator, normalized to 18 decimals.
+// Answers that are not positive, stale or out of the configured bounds of the token are rejected.
 func (pr *priceReader) getRawTokenPriceE18Normalized(
 	ctx context.Context,
 	token ocr2types.Account,
+	tokenInfo pluginconfig.TokenInfo,
 	boundContract commontypes.BoundContract,
 	feedChainReader contractreader.ContractReaderFacade,
+) (*big.Int, error) {
+	answer, err := pr.readLatestAnswerE18(
+		ctx, token, boundContract, feedChainReader, tokenInfo.MaxFeedStaleness.Duration())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkFeedPriceBounds(answer, tokenInfo); err != nil {
+		return nil, fmt.Errorf("aggregator %s of token %s: %w", boundContract.Address, token, err)
+	}
+	return answer, nil
+}
+
+// readLatestAnswerE18 reads the latest answer of an aggregator, normalized to 18 decimals.
+// Answers that are not positive or stale are rejected.
+func (pr *priceReader) readLatestAnswerE18(
+	ctx context.Context,
+	token ocr2types.Account,
+	boundContract commontypes.BoundContract,
+	feedChainReader contractreader.ContractReaderFacade,
+	maxStaleness time.Duration,
 ) (*big.Int, error) {
 	var latestRoundData LatestRoundData
 	identifier := boundContract.ReadIdentifier(consts.MethodNameGetLatestRoundData)
@@ -229,21 +451,60 @@ func (pr *priceReader) getRawTokenPriceE18Normalized(
 		return nil, fmt.Errorf("latestRoundData call failed for token %s: %w", token, err)
 	}
 
+	if err := validateLatestRoundData(latestRoundData, maxStaleness); err != nil {
+		return nil, fmt.Errorf("aggregator %s of token %s: %w", boundContract.Address, token, err)
+	}
+
 	decimals, err1 := pr.getFeedDecimals(ctx, token, boundContract, feedChainReader)
 	if err1 != nil {
 		return nil, fmt.Errorf("failed to get decimals for token %s: %w", token, err1)
 	}
-	answer := latestRoundData.Answer
+	return normalizeE18(latestRoundData.Answer, decimals), nil
+}
+
+// checkFeedPriceBounds checks that the USD price of a full token with 18 decimals is within the configured bounds.
+func checkFeedPriceBounds(rawTokenPrice *big.Int, tokenInfo pluginconfig.TokenInfo) error {
+	if tokenInfo.MinFeedPrice != nil && rawTokenPrice.Cmp(tokenInfo.MinFeedPrice.Int) < 0 {
+		return fmt.Errorf("%w: %s < min %s", ErrFeedPriceOutOfBounds, rawTokenPrice, tokenInfo.MinFeedPrice)
+	}
+	if tokenInfo.MaxFeedPrice != nil && rawTokenPrice.Cmp(tokenInfo.MaxFeedPrice.Int) > 0 {
+		return fmt.Errorf("%w: %s > max %s", ErrFeedPriceOutOfBounds, rawTokenPrice, tokenInfo.MaxFeedPrice)
+	}
+	return nil
+}
+
+// normalizeE18 scales the value with the provided decimals to 18 decimals, the value is modified in place.
+func normalizeE18(value *big.Int, decimals uint8) *big.Int {
 	if decimals < 18 {
-		answer.Mul(answer, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(18-int64(decimals)), nil))
+		value.Mul(value, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(18-int64(decimals)), nil))
 	} else if decimals > 18 {
-		answer.Div(answer, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(decimals)-18), nil))
+		value.Div(value, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(decimals)-18), nil))
 	}
-	return answer, nil
+	return value
 }
 
-func (pr *priceReader) feedChainReader() contractreader.ContractReaderFacade {
-	return pr.chainReaders[pr.feedChain]
+// validateLatestRoundData checks that the answer is positive, that it was computed in the latest round and, if
+// maxStaleness is set, that it is not older than maxStaleness.
+func validateLatestRoundData(data LatestRoundData, maxStaleness time.Duration) error {
+	if data.Answer == nil || data.Answer.Sign() <= 0 {
+		return fmt.Errorf("%w: non-positive answer %s", ErrFeedPriceInvalid, data.Answer)
+	}
+
+	if data.RoundID != nil && data.AnsweredInRound != nil && data.AnsweredInRound.Cmp(data.RoundID) < 0 {
+		return fmt.Errorf("%w: answered in round %s before the latest round %s",
+			ErrFeedPriceStale, data.AnsweredInRound, data.RoundID)
+	}
+
+	if maxStaleness > 0 {
+		if data.UpdatedAt == nil {
+			return fmt.Errorf("%w: answer has no update time", ErrFeedPriceStale)
+		}
+		updatedAt := time.Unix(data.UpdatedAt.Int64(), 0)
+		if age := time.Since(updatedAt); age > maxStaleness {
+			return fmt.Errorf("%w: answer updated at %s is older than %s", ErrFeedPriceStale, updatedAt, maxStaleness)
+		}
+	}
+	return nil
 }
 
 // Input price is USD per full token, with 18 decimal precision
@@ -2,17 +2,22 @@ package reader
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	ocr2types "github.com/goplugin/plugin-libocr/offchainreporting2plus/types"
 
+	commonconfig "github.com/goplugin/plugin-common/pkg/config"
+	"github.com/goplugin/plugin-common/pkg/logger"
 	commontypes "github.com/goplugin/plugin-common/pkg/types"
 	"github.com/goplugin/plugin-common/pkg/types/query/primitives"
 
@@ -86,6 +91,18 @@ func TestOnchainTokenPricesReader_GetTokenPricesUSD(t *testing.T) {
 			inputTokens:   []ocr2types.Account{ArbAddr, EthAddr},
 			mockPrices:    map[ocr2types.Account]*big.Int{ArbAddr: ArbPrice},
 			errorAccounts: []ocr2types.Account{EthAddr},
+			want:          []*big.Int{ArbPrice, nil},
+			wantErr:       true,
+		},
+		{
+			name: "All prices missing should error without prices",
+			tokenInfo: map[ocr2types.Account]pluginconfig.TokenInfo{
+				ArbAddr: ArbInfo,
+				EthAddr: EthInfo,
+			},
+			inputTokens:   []ocr2types.Account{ArbAddr, EthAddr},
+			mockPrices:    map[ocr2types.Account]*big.Int{},
+			errorAccounts: []ocr2types.Account{ArbAddr, EthAddr},
 			want:          nil,
 			wantErr:       true,
 		},
@@ -95,6 +112,7 @@ func TestOnchainTokenPricesReader_GetTokenPricesUSD(t *testing.T) {
 		contractReader := createMockReader(t, tc.mockPrices, tc.errorAccounts, tc.tokenInfo)
 		feedChain := cciptypes.ChainSelector(1)
 		tokenPricesReader := priceReader{
+			lggr: logger.Test(t),
 			chainReaders: map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{
 				feedChain: contractReader,
 			},
@@ -107,6 +125,10 @@ func TestOnchainTokenPricesReader_GetTokenPricesUSD(t *testing.T) {
 
 			if tc.wantErr {
 				require.Error(t, err)
+				require.Equal(t, tc.want, result)
+				var tokenErrs TokenPriceErrors
+				require.True(t, errors.As(err, &tokenErrs))
+				require.ElementsMatch(t, tc.errorAccounts, maps.Keys(tokenErrs))
 				return
 			}
 			require.NoError(t, err)
@@ -115,6 +137,367 @@ func TestOnchainTokenPricesReader_GetTokenPricesUSD(t *testing.T) {
 	}
 }
 
+func TestPriceReader_GetFeedPricesUSD_MultipleSources(t *testing.T) {
+	const (
+		aggregator1 = "0xa300000000000000000000000000000000000000"
+		aggregator2 = "0xa400000000000000000000000000000000000000"
+		aggregator3 = "0xa500000000000000000000000000000000000000"
+	)
+	feedChain := cciptypes.ChainSelector(1)
+	otherFeedChain := cciptypes.ChainSelector(2)
+	unsupportedFeedChain := cciptypes.ChainSelector(3)
+
+	testCases := []struct {
+		name            string
+		priceSources    []pluginconfig.PriceSource
+		minPriceSources int
+		// aggregator address -> price, nil if the price can't be read
+		feedChainPrices      map[string]*big.Int
+		otherFeedChainPrices map[string]*big.Int
+		want                 *big.Int
+		wantErr              bool
+	}{
+		{
+			name: "median of all the sources",
+			priceSources: []pluginconfig.PriceSource{
+				{AggregatorAddress: aggregator2},
+				{AggregatorAddress: aggregator3, ChainSelector: otherFeedChain},
+			},
+			minPriceSources:      3,
+			feedChainPrices:      map[string]*big.Int{aggregator1: big.NewInt(100), aggregator2: big.NewInt(5)},
+			otherFeedChainPrices: map[string]*big.Int{aggregator3: big.NewInt(7)},
+			want:                 big.NewInt(7),
+		},
+		{
+			name: "falls back to the sources which can be read",
+			priceSources: []pluginconfig.PriceSource{
+				{AggregatorAddress: aggregator2},
+				{AggregatorAddress: aggregator3, ChainSelector: unsupportedFeedChain},
+			},
+			feedChainPrices: map[string]*big.Int{aggregator1: nil, aggregator2: big.NewInt(5)},
+			want:            big.NewInt(5),
+		},
+		{
+			name: "not enough sources",
+			priceSources: []pluginconfig.PriceSource{
+				{AggregatorAddress: aggregator2},
+				{AggregatorAddress: aggregator3, ChainSelector: otherFeedChain},
+			},
+			minPriceSources:      2,
+			feedChainPrices:      map[string]*big.Int{aggregator1: nil, aggregator2: big.NewInt(5)},
+			otherFeedChainPrices: map[string]*big.Int{aggregator3: nil},
+			wantErr:              true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tokenPricesReader := priceReader{
+				lggr: logger.Test(t),
+				chainReaders: map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{
+					feedChain:      createMockAggregatorsReader(t, tc.feedChainPrices),
+					otherFeedChain: createMockAggregatorsReader(t, tc.otherFeedChainPrices),
+				},
+				tokenInfo: map[ocr2types.Account]pluginconfig.TokenInfo{
+					ArbAddr: {
+						AggregatorAddress: aggregator1,
+						DeviationPPB:      cciptypes.NewBigInt(big.NewInt(1e5)),
+						Decimals:          Decimals18,
+						PriceSources:      tc.priceSources,
+						MinPriceSources:   tc.minPriceSources,
+					},
+				},
+				feedChain: feedChain,
+			}
+
+			result, err := tokenPricesReader.GetFeedPricesUSD(context.Background(), []ocr2types.Account{ArbAddr})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []*big.Int{tc.want}, result)
+		})
+	}
+}
+
+func TestPriceReader_GetFeedPricesUSD_FeedChecks(t *testing.T) {
+	now := time.Now()
+	minPrice := cciptypes.NewBigInt(big.NewInt(1e18))
+	maxPrice := cciptypes.NewBigInt(big.NewInt(8e18))
+
+	testCases := []struct {
+		name            string
+		latestRoundData LatestRoundData
+		tokenInfo       func(info pluginconfig.TokenInfo) pluginconfig.TokenInfo
+		expErr          error
+	}{
+		{
+			name: "valid answer",
+			latestRoundData: LatestRoundData{
+				RoundID:         big.NewInt(2),
+				Answer:          big.NewInt(5e18),
+				UpdatedAt:       big.NewInt(now.Add(-time.Minute).Unix()),
+				AnsweredInRound: big.NewInt(2),
+			},
+			tokenInfo: func(info pluginconfig.TokenInfo) pluginconfig.TokenInfo {
+				info.MaxFeedStaleness = *commonconfig.MustNewDuration(time.Hour)
+				info.MinFeedPrice = &minPrice
+				info.MaxFeedPrice = &maxPrice
+				return info
+			},
+		},
+		{
+			name:            "zero answer",
+			latestRoundData: LatestRoundData{Answer: big.NewInt(0)},
+			expErr:          ErrFeedPriceInvalid,
+		},
+		{
+			name:            "negative answer",
+			latestRoundData: LatestRoundData{Answer: big.NewInt(-1)},
+			expErr:          ErrFeedPriceInvalid,
+		},
+		{
+			name: "answered in a previous round",
+			latestRoundData: LatestRoundData{
+				RoundID:         big.NewInt(2),
+				Answer:          big.NewInt(5e18),
+				AnsweredInRound: big.NewInt(1),
+			},
+			expErr: ErrFeedPriceStale,
+		},
+		{
+			name: "stale answer",
+			latestRoundData: LatestRoundData{
+				Answer:    big.NewInt(5e18),
+				UpdatedAt: big.NewInt(now.Add(-2 * time.Hour).Unix()),
+			},
+			tokenInfo: func(info pluginconfig.TokenInfo) pluginconfig.TokenInfo {
+				info.MaxFeedStaleness = *commonconfig.MustNewDuration(time.Hour)
+				return info
+			},
+			expErr: ErrFeedPriceStale,
+		},
+		{
+			name:            "answer below the min price",
+			latestRoundData: LatestRoundData{Answer: big.NewInt(1e17)},
+			tokenInfo: func(info pluginconfig.TokenInfo) pluginconfig.TokenInfo {
+				info.MinFeedPrice = &minPrice
+				return info
+			},
+			expErr: ErrFeedPriceOutOfBounds,
+		},
+		{
+			name:            "answer above the max price",
+			latestRoundData: LatestRoundData{Answer: big.NewInt(9e18)},
+			tokenInfo: func(info pluginconfig.TokenInfo) pluginconfig.TokenInfo {
+				info.MaxFeedPrice = &maxPrice
+				return info
+			},
+			expErr: ErrFeedPriceOutOfBounds,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tokenInfo := ArbInfo
+			if tc.tokenInfo != nil {
+				tokenInfo = tc.tokenInfo(tokenInfo)
+			}
+
+			boundContract := commontypes.BoundContract{
+				Address: tokenInfo.AggregatorAddress,
+				Name:    consts.ContractNamePriceAggregator,
+			}
+			reader := readermock.NewMockContractReaderFacade(t)
+			reader.On("GetLatestValue",
+				mock.Anything,
+				boundContract.ReadIdentifier(consts.MethodNameGetLatestRoundData),
+				primitives.Unconfirmed,
+				nil,
+				mock.Anything).Run(
+				func(args mock.Arguments) {
+					*args.Get(4).(*LatestRoundData) = tc.latestRoundData
+				}).Return(nil).Once()
+			reader.On("GetLatestValue",
+				mock.Anything,
+				boundContract.ReadIdentifier(consts.MethodNameGetDecimals),
+				primitives.Unconfirmed,
+				nil,
+				mock.Anything).Run(
+				func(args mock.Arguments) {
+					*args.Get(4).(*uint8) = Decimals18
+				}).Return(nil).Maybe()
+
+			feedChain := cciptypes.ChainSelector(1)
+			tokenPricesReader := priceReader{
+				lggr:         logger.Test(t),
+				chainReaders: map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{feedChain: reader},
+				tokenInfo:    map[ocr2types.Account]pluginconfig.TokenInfo{ArbAddr: tokenInfo},
+				feedChain:    feedChain,
+			}
+
+			result, err := tokenPricesReader.GetFeedPricesUSD(context.Background(), []ocr2types.Account{ArbAddr})
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				require.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []*big.Int{tc.latestRoundData.Answer}, result)
+		})
+	}
+}
+
+func TestPriceReader_GetFeedPricesUSD_DerivedPrice(t *testing.T) {
+	const (
+		rateAggregator = "0xa600000000000000000000000000000000000000"
+		rateContract   = "0xa700000000000000000000000000000000000000"
+	)
+	feedChain := cciptypes.ChainSelector(1)
+	derivedToken := ocr2types.Account("0xa800000000000000000000000000000000000000")
+
+	testCases := []struct {
+		name       string
+		rate       pluginconfig.RateSource
+		baseFails  bool
+		mockReader func(reader *readermock.MockContractReaderFacade)
+		want       *big.Int
+		wantErr    bool
+	}{
+		{
+			name: "rate from a ratio feed",
+			rate: pluginconfig.RateSource{Address: rateAggregator},
+			mockReader: func(reader *readermock.MockContractReaderFacade) {
+				mockAggregator(reader, rateAggregator, big.NewInt(1.5e18))
+			},
+			// 5 USD * 1.5
+			want: big.NewInt(7.5e18),
+		},
+		{
+			name: "rate from a contract read",
+			rate: pluginconfig.RateSource{
+				Address:      rateContract,
+				ContractName: "StakedToken",
+				MethodName:   "getExchangeRate",
+				Decimals:     6,
+			},
+			mockReader: func(reader *readermock.MockContractReaderFacade) {
+				boundContract := commontypes.BoundContract{Address: rateContract, Name: "StakedToken"}
+				reader.On("GetLatestValue",
+					mock.Anything,
+					boundContract.ReadIdentifier("getExchangeRate"),
+					primitives.Unconfirmed,
+					nil,
+					mock.Anything).Run(
+					func(args mock.Arguments) {
+						*args.Get(4).(**big.Int) = big.NewInt(1.2e6)
+					}).Return(nil).Once()
+			},
+			// 5 USD * 1.2
+			want: big.NewInt(6e18),
+		},
+		{
+			name:      "base token price not available",
+			rate:      pluginconfig.RateSource{Address: rateAggregator},
+			baseFails: true,
+			// the rate is not read if the base token price is not available
+			mockReader: func(reader *readermock.MockContractReaderFacade) {},
+			wantErr:    true,
+		},
+		{
+			name: "rate not available",
+			rate: pluginconfig.RateSource{Address: rateAggregator},
+			mockReader: func(reader *readermock.MockContractReaderFacade) {
+				mockAggregator(reader, rateAggregator, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			basePrice := ArbPrice
+			if tc.baseFails {
+				basePrice = nil
+			}
+			reader := createMockAggregatorsReader(t, map[string]*big.Int{ArbInfo.AggregatorAddress: basePrice})
+			tc.mockReader(reader)
+
+			tokenPricesReader := priceReader{
+				lggr:         logger.Test(t),
+				chainReaders: map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{feedChain: reader},
+				tokenInfo: map[ocr2types.Account]pluginconfig.TokenInfo{
+					ArbAddr: ArbInfo,
+					derivedToken: {
+						DeviationPPB: cciptypes.NewBigInt(big.NewInt(1e5)),
+						Decimals:     Decimals18,
+						DerivedPrice: &pluginconfig.DerivedPriceConfig{BaseToken: ArbAddr, Rate: tc.rate},
+					},
+				},
+				feedChain: feedChain,
+			}
+
+			result, err := tokenPricesReader.GetFeedPricesUSD(context.Background(), []ocr2types.Account{derivedToken})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []*big.Int{tc.want}, result)
+		})
+	}
+}
+
+func TestPriceReader_GetFeedPricesUSD_BaseTokenReadOnce(t *testing.T) {
+	const (
+		rateAggregator1 = "0xa600000000000000000000000000000000000000"
+		rateAggregator2 = "0xa700000000000000000000000000000000000000"
+	)
+	feedChain := cciptypes.ChainSelector(1)
+	derivedToken1 := ocr2types.Account("0xa800000000000000000000000000000000000000")
+	derivedToken2 := ocr2types.Account("0xa900000000000000000000000000000000000000")
+
+	// the base token aggregator is mocked to be read once
+	reader := createMockAggregatorsReader(t, map[string]*big.Int{
+		ArbInfo.AggregatorAddress: ArbPrice,
+		rateAggregator1:           big.NewInt(1.5e18),
+		rateAggregator2:           big.NewInt(1.2e18),
+	})
+
+	derivedInfo := func(rateAggregator string) pluginconfig.TokenInfo {
+		return pluginconfig.TokenInfo{
+			DeviationPPB: cciptypes.NewBigInt(big.NewInt(1e5)),
+			Decimals:     Decimals18,
+			DerivedPrice: &pluginconfig.DerivedPriceConfig{
+				BaseToken: ArbAddr,
+				Rate:      pluginconfig.RateSource{Address: rateAggregator},
+			},
+		}
+	}
+	tokenPricesReader := priceReader{
+		lggr:         logger.Test(t),
+		chainReaders: map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{feedChain: reader},
+		tokenInfo: map[ocr2types.Account]pluginconfig.TokenInfo{
+			ArbAddr:       ArbInfo,
+			derivedToken1: derivedInfo(rateAggregator1),
+			derivedToken2: derivedInfo(rateAggregator2),
+		},
+		feedChain: feedChain,
+	}
+
+	result, err := tokenPricesReader.GetFeedPricesUSD(
+		context.Background(), []ocr2types.Account{derivedToken1, ArbAddr, derivedToken2})
+	require.NoError(t, err)
+	require.Equal(t, []*big.Int{big.NewInt(7.5e18), ArbPrice, big.NewInt(6e18)}, result)
+}
+
 func TestPriceService_calculateUsdPer1e18TokenAmount(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -210,3 +593,52 @@ func createMockReader(
 
 	return reader
 }
+
+// createMockAggregatorsReader mocks the 18 decimals aggregators with the provided prices,
+// reading the price of an aggregator with a nil price fails.
+func createMockAggregatorsReader(
+	t *testing.T,
+	prices map[string]*big.Int,
+) *readermock.MockContractReaderFacade {
+	reader := readermock.NewMockContractReaderFacade(t)
+	for aggregator, price := range prices {
+		mockAggregator(reader, aggregator, price)
+	}
+	return reader
+}
+
+// mockAggregator mocks the latest answer of an 18 decimals aggregator, reading a nil price fails.
+func mockAggregator(reader *readermock.MockContractReaderFacade, aggregator string, price *big.Int) {
+	boundContract := commontypes.BoundContract{
+		Address: aggregator,
+		Name:    consts.ContractNamePriceAggregator,
+	}
+
+	latestRoundData := reader.On("GetLatestValue",
+		mock.Anything,
+		boundContract.ReadIdentifier(consts.MethodNameGetLatestRoundData),
+		primitives.Unconfirmed,
+		nil,
+		mock.Anything)
+	if price == nil {
+		latestRoundData.Return(fmt.Errorf("error")).Once()
+		return
+	}
+
+	reader.On("GetLatestValue",
+		mock.Anything,
+		boundContract.ReadIdentifier(consts.MethodNameGetDecimals),
+		primitives.Unconfirmed,
+		nil,
+		mock.Anything).Run(
+		func(args mock.Arguments) {
+			arg := args.Get(4).(*uint8)
+			*arg = Decimals18
+		}).Return(nil).Maybe()
+
+	latestRoundData.Run(
+		func(args mock.Arguments) {
+			arg := args.Get(4).(*LatestRoundData)
+			arg.Answer = new(big.Int).Set(price)
+		}).Return(nil).Once()
+}
@@ -33,6 +33,8 @@ type HomeNodeInfo = rmntypes.HomeNodeInfo
 type NodeID = rmntypes.NodeID
 
 type RMNHome interface {
+	// GetAllConfigDigests gets the active and the candidate RMNHome config digests, either can be empty
+	GetAllConfigDigests() (activeConfigDigest cciptypes.Bytes32, candidateConfigDigest cciptypes.Bytes32)
 	// GetRMNNodesInfo gets the RMNHomeNodeInfo for the given configDigest
 	GetRMNNodesInfo(configDigest cciptypes.Bytes32) ([]rmntypes.HomeNodeInfo, error)
 	// IsRMNHomeConfigDigestSet checks if the configDigest is set in the RMNHome contract
@@ -173,6 +175,15 @@ func (r *rmnHomePoller) setRMNHomeState(
 	s.rmnHomeConfig = rmnHomeConfig
 }
 
+func (r *rmnHomePoller) GetAllConfigDigests() (
+	activeConfigDigest cciptypes.Bytes32,
+	candidateConfigDigest cciptypes.Bytes32,
+) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.rmnHomeState.primaryConfigDigest, r.rmnHomeState.secondaryConfigDigest
+}
+
 func (r *rmnHomePoller) GetRMNNodesInfo(configDigest cciptypes.Bytes32) ([]rmntypes.HomeNodeInfo, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
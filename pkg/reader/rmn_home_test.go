@@ -222,6 +222,10 @@ func Test_RMNHomePollingWorking(t *testing.T) {
 			}
 			require.GreaterOrEqual(t, callCount, tt.expectedCallCount)
 
+			activeConfigDigest, candidateConfigDigest := configPoller.GetAllConfigDigests()
+			require.Equal(t, primaryConfig.ConfigDigest, activeConfigDigest)
+			require.Equal(t, secondaryConfig.ConfigDigest, candidateConfigDigest)
+
 			for i, config := range []VersionedConfig{primaryConfig, secondaryConfig} {
 				isEmpty := (i == 0 && tt.primaryEmpty) || (i == 1 && tt.secondaryEmpty)
 
@@ -0,0 +1,207 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+	"github.com/goplugin/plugin-common/pkg/services"
+
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+var (
+	// ErrRMNRemoteConfigNotFetched is returned when no RMNRemote config has been fetched yet.
+	ErrRMNRemoteConfigNotFetched = errors.New("RMNRemote config not fetched yet")
+	// ErrRMNRemoteConfigStale is returned when the cached RMNRemote config was not refreshed for too long.
+	ErrRMNRemoteConfigStale = errors.New("RMNRemote config is stale")
+)
+
+type RMNRemote interface {
+	// GetRemoteConfig returns the latest RMNRemote config of the destination chain fetched by the poller.
+	// Returns ErrContractReaderNotFound if the node does not support the destination chain, and
+	// ErrRMNRemoteConfigNotFetched or ErrRMNRemoteConfigStale if no fresh config is available.
+	GetRemoteConfig() (rmntypes.RemoteConfig, error)
+	// GetMinSigners returns the minimum number of RMN signatures (F+1) required by RMNRemote.
+	GetMinSigners() (uint64, error)
+	// GetSignersInfo returns the signers configured on RMNRemote.
+	GetSignersInfo() ([]rmntypes.RemoteSignerInfo, error)
+	// GetRmnHomeConfigDigest returns the RMNHome config digest RMNRemote is configured with.
+	GetRmnHomeConfigDigest() (cciptypes.Bytes32, error)
+	services.Service
+}
+
+type rmnRemoteState struct {
+	config      rmntypes.RemoteConfig
+	lastUpdated time.Time
+	// fetchErr is set when the config could not be fetched for a reason which is not a transient failure,
+	// e.g. when the node does not support the destination chain.
+	fetchErr error
+}
+
+// rmnRemotePoller polls the RMNRemote contract of the destination chain for its latest config.
+// It is running in the background with a polling interval of pollingDuration.
+type rmnRemotePoller struct {
+	wg              sync.WaitGroup
+	stopCh          services.StopChan
+	sync            services.StateMachine
+	mutex           *sync.RWMutex
+	ccipReader      CCIPReader
+	destChain       cciptypes.ChainSelector
+	lggr            logger.Logger
+	state           rmnRemoteState
+	failedPolls     uint
+	pollingDuration time.Duration // How frequently the poller fetches the config
+	// The cached config is considered stale if it was not refreshed within staleAfter.
+	staleAfter time.Duration
+}
+
+func NewRMNRemotePoller(
+	ccipReader CCIPReader,
+	destChain cciptypes.ChainSelector,
+	lggr logger.Logger,
+	pollingInterval time.Duration,
+) RMNRemote {
+	return &rmnRemotePoller{
+		stopCh:          make(chan struct{}),
+		mutex:           &sync.RWMutex{},
+		ccipReader:      ccipReader,
+		destChain:       destChain,
+		lggr:            lggr,
+		state:           rmnRemoteState{fetchErr: ErrRMNRemoteConfigNotFetched},
+		pollingDuration: pollingInterval,
+		staleAfter:      time.Duration(MaxFailedPolls) * pollingInterval,
+	}
+}
+
+func (r *rmnRemotePoller) Start(ctx context.Context) error {
+	return r.sync.StartOnce(r.Name(), func() error {
+		r.lggr.Infow("Start Polling RMNRemote")
+		r.wg.Add(1)
+		go r.poll()
+		return nil
+	})
+}
+
+func (r *rmnRemotePoller) poll() {
+	defer r.wg.Done()
+	ctx, cancel := r.stopCh.NewCtx()
+	defer cancel()
+	// Initial fetch once poll is called before any ticks
+	if err := r.fetchAndSetRMNRemoteConfig(ctx); err != nil {
+		// Just log, don't return error as we want to keep polling
+		r.lggr.Errorw("Initial fetch of RMNRemote config failed", "err", err)
+	}
+
+	ticker := time.NewTicker(r.pollingDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			r.mutex.Lock()
+			r.failedPolls = 0
+			r.mutex.Unlock()
+			return
+		case <-ticker.C:
+			if err := r.fetchAndSetRMNRemoteConfig(ctx); err != nil {
+				r.lggr.Warnw("Fetching RMNRemote config failed", "err", err)
+			}
+		}
+	}
+}
+
+func (r *rmnRemotePoller) fetchAndSetRMNRemoteConfig(ctx context.Context) error {
+	cfg, err := r.ccipReader.GetRMNRemoteConfig(ctx, r.destChain)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if errors.Is(err, ErrContractReaderNotFound) {
+		// The destination chain is not supported by this node, there is nothing to poll.
+		r.state = rmnRemoteState{fetchErr: err}
+		r.failedPolls = 0
+		return nil
+	}
+	if err != nil {
+		r.failedPolls++
+		return fmt.Errorf("error fetching RMNRemote config: %w", err)
+	}
+
+	if r.state.config.ConfigDigest != cfg.ConfigDigest {
+		r.lggr.Infow("Fetched new RMNRemote config",
+			"configDigest", cfg.ConfigDigest, "configVersion", cfg.ConfigVersion, "minSigners", cfg.MinSigners)
+	}
+	r.state = rmnRemoteState{config: cfg, lastUpdated: time.Now()}
+	r.failedPolls = 0
+	return nil
+}
+
+func (r *rmnRemotePoller) GetRemoteConfig() (rmntypes.RemoteConfig, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.state.fetchErr != nil {
+		return rmntypes.RemoteConfig{}, r.state.fetchErr
+	}
+	if age := time.Since(r.state.lastUpdated); age > r.staleAfter {
+		return rmntypes.RemoteConfig{}, fmt.Errorf("%w: last updated %s ago", ErrRMNRemoteConfigStale, age)
+	}
+	return r.state.config, nil
+}
+
+func (r *rmnRemotePoller) GetMinSigners() (uint64, error) {
+	cfg, err := r.GetRemoteConfig()
+	if err != nil {
+		return 0, err
+	}
+	return cfg.MinSigners, nil
+}
+
+func (r *rmnRemotePoller) GetSignersInfo() ([]rmntypes.RemoteSignerInfo, error) {
+	cfg, err := r.GetRemoteConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Signers, nil
+}
+
+func (r *rmnRemotePoller) GetRmnHomeConfigDigest() (cciptypes.Bytes32, error) {
+	cfg, err := r.GetRemoteConfig()
+	if err != nil {
+		return cciptypes.Bytes32{}, err
+	}
+	return cfg.ConfigDigest, nil
+}
+
+func (r *rmnRemotePoller) Close() error {
+	return r.sync.StopOnce(r.Name(), func() error {
+		defer r.wg.Wait()
+		close(r.stopCh)
+		return nil
+	})
+}
+
+func (r *rmnRemotePoller) Ready() error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.sync.Ready()
+}
+
+func (r *rmnRemotePoller) HealthReport() map[string]error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.failedPolls >= MaxFailedPolls {
+		err := fmt.Errorf("polling failed %d times in a row (maximum allowed: %d)", r.failedPolls, MaxFailedPolls)
+		r.sync.SvcErrBuffer.Append(err)
+	}
+	if r.state.fetchErr == nil && time.Since(r.state.lastUpdated) > r.staleAfter {
+		r.sync.SvcErrBuffer.Append(fmt.Errorf("%w: last updated at %s", ErrRMNRemoteConfigStale, r.state.lastUpdated))
+	}
+	return map[string]error{r.Name(): r.sync.Healthy()}
+}
+
+func (r *rmnRemotePoller) Name() string {
+	return "rmnRemotePoller"
+}
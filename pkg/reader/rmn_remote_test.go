@@ -0,0 +1,163 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goplugin/plugin-common/pkg/logger"
+
+	rmntypes "github.com/goplugin/plugin-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/goplugin/plugin-ccip/pkg/types/ccipocr3"
+)
+
+// rmnRemoteConfigReader is a CCIPReader which only implements GetRMNRemoteConfig.
+type rmnRemoteConfigReader struct {
+	CCIPReader
+	calls atomic.Int32
+	cfg   rmntypes.RemoteConfig
+	err   error
+}
+
+func (r *rmnRemoteConfigReader) GetRMNRemoteConfig(
+	_ context.Context,
+	_ cciptypes.ChainSelector,
+) (rmntypes.RemoteConfig, error) {
+	r.calls.Add(1)
+	return r.cfg, r.err
+}
+
+func TestRMNRemotePoller_GetRemoteConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := rmntypes.RemoteConfig{
+		ConfigDigest:  cciptypes.Bytes32{1},
+		ConfigVersion: 2,
+		MinSigners:    3,
+		Signers:       []rmntypes.RemoteSignerInfo{{NodeIndex: 1}},
+	}
+
+	tests := []struct {
+		name      string
+		cfg       rmntypes.RemoteConfig
+		readerErr error
+		expErr    error
+	}{
+		{
+			name: "config fetched",
+			cfg:  cfg,
+		},
+		{
+			name:      "destination chain not supported",
+			readerErr: ErrContractReaderNotFound,
+			expErr:    ErrContractReaderNotFound,
+		},
+		{
+			name:      "fetch failed",
+			readerErr: errors.New("rpc error"),
+			expErr:    ErrRMNRemoteConfigNotFetched,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ccipReader := &rmnRemoteConfigReader{cfg: tc.cfg, err: tc.readerErr}
+			poller := NewRMNRemotePoller(ccipReader, cciptypes.ChainSelector(1), logger.Test(t), time.Hour)
+
+			require.NoError(t, poller.Start(context.Background()))
+			require.Eventually(t, func() bool {
+				return ccipReader.calls.Load() > 0
+			}, 5*time.Second, 10*time.Millisecond)
+
+			// The initial fetch result is stored right after the call.
+			var got rmntypes.RemoteConfig
+			var err error
+			require.Eventually(t, func() bool {
+				got, err = poller.GetRemoteConfig()
+				return tc.expErr != nil || err == nil
+			}, 5*time.Second, 10*time.Millisecond)
+
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.cfg, got)
+
+				minSigners, err := poller.GetMinSigners()
+				require.NoError(t, err)
+				require.Equal(t, tc.cfg.MinSigners, minSigners)
+				digest, err := poller.GetRmnHomeConfigDigest()
+				require.NoError(t, err)
+				require.Equal(t, tc.cfg.ConfigDigest, digest)
+			}
+
+			require.NoError(t, poller.Close())
+		})
+	}
+}
+
+func TestRMNRemotePoller_Staleness(t *testing.T) {
+	t.Parallel()
+
+	// Failing fetches leave the cached config untouched.
+	ccipReader := &rmnRemoteConfigReader{err: errors.New("rpc error")}
+	poller := NewRMNRemotePoller(ccipReader, cciptypes.ChainSelector(1), logger.Test(t), time.Hour).(*rmnRemotePoller)
+	require.NoError(t, poller.Start(context.Background()))
+	require.Eventually(t, func() bool {
+		return ccipReader.calls.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	poller.mutex.Lock()
+	poller.state = rmnRemoteState{
+		config:      rmntypes.RemoteConfig{ConfigDigest: cciptypes.Bytes32{1}},
+		lastUpdated: time.Now(),
+	}
+	poller.mutex.Unlock()
+	_, err := poller.GetRemoteConfig()
+	require.NoError(t, err)
+	require.NoError(t, poller.HealthReport()[poller.Name()])
+
+	poller.mutex.Lock()
+	poller.state.lastUpdated = time.Now().Add(-poller.staleAfter - time.Minute)
+	poller.mutex.Unlock()
+	_, err = poller.GetRemoteConfig()
+	require.ErrorIs(t, err, ErrRMNRemoteConfigStale)
+	require.ErrorIs(t, poller.HealthReport()[poller.Name()], ErrRMNRemoteConfigStale)
+
+	require.NoError(t, poller.Close())
+}
+
+func TestRMNRemotePoller_HealthReport(t *testing.T) {
+	t.Parallel()
+
+	ccipReader := &rmnRemoteConfigReader{err: errors.New("rpc error")}
+	poller := NewRMNRemotePoller(ccipReader, cciptypes.ChainSelector(1), logger.Test(t), time.Hour).(*rmnRemotePoller)
+
+	require.NoError(t, poller.Start(context.Background()))
+	require.Eventually(t, func() bool {
+		poller.mutex.RLock()
+		defer poller.mutex.RUnlock()
+		return poller.failedPolls == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The config was never fetched, this is reported by GetRemoteConfig but not as unhealthy until polling keeps failing.
+	_, err := poller.GetRemoteConfig()
+	require.ErrorIs(t, err, ErrRMNRemoteConfigNotFetched)
+	require.NoError(t, poller.HealthReport()[poller.Name()])
+
+	for i := uint(1); i < MaxFailedPolls; i++ {
+		require.Error(t, poller.fetchAndSetRMNRemoteConfig(context.Background()))
+	}
+
+	err = poller.HealthReport()[poller.Name()]
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "polling failed")
+
+	require.NoError(t, poller.Close())
+}
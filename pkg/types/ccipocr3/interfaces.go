@@ -14,6 +14,19 @@ type ExecutePluginCodec interface {
 	Decode(context.Context, []byte) (ExecutePluginReport, error)
 }
 
+// ExecutePluginReportSizeEstimator predicts the encoded size of an ExecutePluginReport without encoding it. It is
+// optionally implemented by an ExecutePluginCodec. Estimates are used to search for the largest report which fits the
+// size limit, so they must never be smaller than the actual encoded size. Only the selected report is encoded to
+// check the real size.
+type ExecutePluginReportSizeEstimator interface {
+	// EstimateChainReportOverhead returns the size of a single chain report without messages and proofs.
+	EstimateChainReportOverhead() int
+	// EstimateMessageSize returns the size added to a single chain report by a message and its offchain token data.
+	EstimateMessageSize(msg Message, offchainTokenData [][]byte) int
+	// EstimateProofSize returns the size added to a single chain report by one merkle proof hash.
+	EstimateProofSize() int
+}
+
 type MessageHasher interface {
 	Hash(context.Context, Message) (Bytes32, error)
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"strings"
 	"time"
 
@@ -27,15 +28,104 @@ const (
 	defaultRMNEnabled                         = false
 	defaultRemoteGasPriceBatchWriteFrequency  = 1 * time.Minute
 	defaultSignObservationPrefix              = "plugin ccip 1.6 rmn observation"
+	defaultReorgCheckLookback                 = 1 * time.Hour
+	defaultRMNInitialRequestTimer             = 2 * time.Second
+	defaultRMNAdaptiveTimersPercentile        = 90
+	defaultRMNAdaptiveTimersMinSamples        = 20
+	defaultRMNAdaptiveTimersWindowSize        = 200
+	defaultRMNAdaptiveTimersMinTimer          = 500 * time.Millisecond
+	defaultRMNPollingInterval                 = 5 * time.Second
 )
 
+// FeeInfo configures the gas price updates of a chain.
+// The deviation thresholds override the ones of the chain config on the home chain, if set.
 type FeeInfo struct {
 	ExecDeviationPPB             cciptypes.BigInt `json:"execDeviationPPB"`
 	DataAvailabilityDeviationPPB cciptypes.BigInt `json:"dataAvailabilityDeviationPPB"`
+
+	// Heartbeat is the maximum time between two gas price updates of the chain, even if the fees did not deviate.
+	// If not set, the RemoteGasPriceBatchWriteFrequency is used.
+	Heartbeat commonconfig.Duration `json:"heartbeat,omitempty"`
+
+	// FeeAggregation configures how the fee components observed by the oracles for the chain are aggregated.
+	// If not set, the median of the observations is used.
+	FeeAggregation *FeeAggregationConfig `json:"feeAggregation,omitempty"`
+}
+
+func (f FeeInfo) Validate() error {
+	if f.FeeAggregation != nil {
+		if err := f.FeeAggregation.Validate(); err != nil {
+			return fmt.Errorf("invalid feeAggregation: %w", err)
+		}
+	}
+	return nil
+}
+
+// FeeAggregationMethod is the method used to aggregate the fee components observed by the oracles.
+type FeeAggregationMethod string
+
+const (
+	// FeeAggregationMedian takes the median of the observations.
+	FeeAggregationMedian FeeAggregationMethod = "median"
+	// FeeAggregationTrimmedMean drops the TrimCount lowest and highest observations and averages the rest.
+	FeeAggregationTrimmedMean FeeAggregationMethod = "trimmedMean"
+	// FeeAggregationMedianMaxSpread drops the observations deviating from the median by more than MaxSpreadPPB and
+	// takes the median of the rest. There is no consensus on the fee if fewer than 2f+1 observations are left.
+	FeeAggregationMedianMaxSpread FeeAggregationMethod = "medianMaxSpread"
+)
+
+// FeeAggregationConfig configures the aggregation of the fee components observed for a chain, and their smoothing
+// across rounds.
+type FeeAggregationConfig struct {
+	// Method is the aggregation method, defaults to FeeAggregationMedian.
+	Method FeeAggregationMethod `json:"method,omitempty"`
+
+	// TrimCount is the number of lowest and highest observations dropped by FeeAggregationTrimmedMean.
+	// Defaults to the f of the chain.
+	TrimCount int `json:"trimCount,omitempty"`
+
+	// MaxSpreadPPB is the maximum deviation in parts per billion of an observation from the median for
+	// FeeAggregationMedianMaxSpread.
+	MaxSpreadPPB int64 `json:"maxSpreadPPB,omitempty"`
+
+	// SmoothingWeightPercent, if set, smooths the aggregated fees with an exponential moving average over the
+	// previous outcomes, giving this weight in percent to the newly aggregated fees.
+	// e.g. 25 moves the fees a quarter of the way to the newly aggregated fees every round.
+	SmoothingWeightPercent uint8 `json:"smoothingWeightPercent,omitempty"`
+}
+
+func (a FeeAggregationConfig) Validate() error {
+	switch a.Method {
+	case "", FeeAggregationMedian, FeeAggregationTrimmedMean:
+		if a.MaxSpreadPPB != 0 {
+			return fmt.Errorf("maxSpreadPPB can only be set for the %s method", FeeAggregationMedianMaxSpread)
+		}
+	case FeeAggregationMedianMaxSpread:
+		if a.MaxSpreadPPB <= 0 {
+			return errors.New("maxSpreadPPB not set or negative, must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown method %q", a.Method)
+	}
+
+	if a.TrimCount < 0 {
+		return fmt.Errorf("trimCount must not be negative, got %d", a.TrimCount)
+	}
+
+	if a.TrimCount > 0 && a.Method != FeeAggregationTrimmedMean {
+		return fmt.Errorf("trimCount can only be set for the %s method", FeeAggregationTrimmedMean)
+	}
+
+	if a.SmoothingWeightPercent > 100 {
+		return fmt.Errorf("smoothingWeightPercent must be at most 100, got %d", a.SmoothingWeightPercent)
+	}
+
+	return nil
 }
 
 type TokenInfo struct {
 	// AggregatorAddress is the address of the price feed TOKEN/USD aggregator on the feed chain.
+	// It must not be set for tokens with a DerivedPrice.
 	AggregatorAddress string `json:"aggregatorAddress"`
 
 	// DeviationPPB is the deviation in parts per billion that the price feed is allowed to deviate
@@ -44,26 +134,243 @@ type TokenInfo struct {
 
 	// Decimals is the number of decimals for the token (NOT the feed).
 	Decimals uint8 `json:"decimals"`
+
+	// Heartbeat is the maximum time between two price updates of the token, even if the price did not deviate.
+	// If not set, the TokenPriceBatchWriteFrequency is used.
+	Heartbeat commonconfig.Duration `json:"heartbeat,omitempty"`
+
+	// PriceSources are additional TOKEN/USD aggregators of the token, possibly on other chains than the feed chain.
+	// If set, the token price is the median of the prices read from AggregatorAddress and PriceSources,
+	// sources that cannot be read are skipped.
+	PriceSources []PriceSource `json:"priceSources,omitempty"`
+
+	// MinPriceSources is the minimum number of price sources that must be read for the token price to be observed.
+	// If not set, a single source is enough.
+	MinPriceSources int `json:"minPriceSources,omitempty"`
+
+	// MaxFeedStaleness is the maximum age of a feed answer, older answers are not observed.
+	// If not set, the age of the answers is not checked.
+	MaxFeedStaleness commonconfig.Duration `json:"maxFeedStaleness,omitempty"`
+
+	// MinFeedPrice and MaxFeedPrice bound the feed answers, in USD per full token with 18 decimals (e.g. 2000e18 for
+	// 2,000 USD). Answers out of the bounds are not observed. If not set, the answers are not bounded.
+	MinFeedPrice *cciptypes.BigInt `json:"minFeedPrice,omitempty"`
+	MaxFeedPrice *cciptypes.BigInt `json:"maxFeedPrice,omitempty"`
+
+	// DerivedPrice, if set, derives the token price from the price of another token and an exchange rate
+	// instead of reading it from a TOKEN/USD aggregator.
+	DerivedPrice *DerivedPriceConfig `json:"derivedPrice,omitempty"`
+}
+
+// DerivedPriceConfig configures a token without a USD price feed whose price is computed as
+// price(BaseToken) * rate, e.g. for liquid staking tokens or wrapped assets.
+type DerivedPriceConfig struct {
+	// BaseToken is the token the price is derived from, it must be configured in the TokenInfo with a USD price feed.
+	BaseToken types.Account `json:"baseToken"`
+
+	// Rate is the source of the amount of BaseToken a full token is worth.
+	Rate RateSource `json:"rate"`
+}
+
+// RateSource is an on-chain exchange rate.
+// By default, the rate is read from a TOKEN/BASE ratio feed aggregator and normalized with the feed decimals.
+// If ContractName and MethodName are set, the rate is read from that contract method instead, which must be
+// configured in the contract reader of the chain, and normalized with Decimals.
+type RateSource struct {
+	// Address is the address of the ratio feed aggregator or of the contract providing the rate.
+	Address string `json:"address"`
+
+	// ChainSelector is the chain of the rate contract, defaults to the PriceFeedChainSelector.
+	ChainSelector cciptypes.ChainSelector `json:"chainSelector,omitempty"`
+
+	// ContractName and MethodName identify the contract read returning the rate as an integer.
+	ContractName string `json:"contractName,omitempty"`
+	MethodName   string `json:"methodName,omitempty"`
+
+	// Decimals is the number of decimals of the rate returned by the contract read.
+	Decimals uint8 `json:"decimals,omitempty"`
+}
+
+// IsContractRead returns true if the rate is read from a contract method instead of a ratio feed aggregator.
+func (r RateSource) IsContractRead() bool {
+	return r.ContractName != ""
+}
+
+func (r RateSource) Validate() error {
+	if err := validateAggregatorAddress(r.Address); err != nil {
+		return fmt.Errorf("invalid rate address: %w", err)
+	}
+
+	if r.IsContractRead() != (r.MethodName != "") {
+		return errors.New("contractName and methodName must be set together")
+	}
+
+	if !r.IsContractRead() && r.Decimals != 0 {
+		return errors.New("decimals can only be set for contract reads, ratio feed decimals are read from the feed")
+	}
+
+	return nil
+}
+
+// PriceSource is a TOKEN/USD price feed aggregator.
+type PriceSource struct {
+	// AggregatorAddress is the address of the price feed TOKEN/USD aggregator.
+	AggregatorAddress string `json:"aggregatorAddress"`
+
+	// ChainSelector is the chain of the aggregator, defaults to the PriceFeedChainSelector.
+	ChainSelector cciptypes.ChainSelector `json:"chainSelector,omitempty"`
+}
+
+// AllPriceSources returns the aggregator of the token followed by its additional price sources.
+// Sources without a chain selector are assigned to the provided feed chain.
+// Tokens with a DerivedPrice have no price sources.
+func (a TokenInfo) AllPriceSources(feedChain cciptypes.ChainSelector) []PriceSource {
+	if a.DerivedPrice != nil {
+		return nil
+	}
+	sources := make([]PriceSource, 0, len(a.PriceSources)+1)
+	sources = append(sources, PriceSource{AggregatorAddress: a.AggregatorAddress, ChainSelector: feedChain})
+	for _, source := range a.PriceSources {
+		if source.ChainSelector == 0 {
+			source.ChainSelector = feedChain
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+// TokenPriceChains returns the chains the price of the token is read from in ascending order: the chains of its
+// price sources or, for a derived token, the chain of its rate and the chains of the price sources of its base token.
+func (c CommitOffchainConfig) TokenPriceChains(token types.Account) []cciptypes.ChainSelector {
+	tokenInfo, ok := c.TokenInfo[token]
+	if !ok {
+		return nil
+	}
+
+	var chains []cciptypes.ChainSelector
+	if tokenInfo.DerivedPrice != nil {
+		rateChain := tokenInfo.DerivedPrice.Rate.ChainSelector
+		if rateChain == 0 {
+			rateChain = c.PriceFeedChainSelector
+		}
+		chains = append(chains, rateChain)
+
+		tokenInfo, ok = c.TokenInfo[tokenInfo.DerivedPrice.BaseToken]
+		if !ok {
+			return chains
+		}
+	}
+
+	for _, source := range tokenInfo.AllPriceSources(c.PriceFeedChainSelector) {
+		chains = append(chains, source.ChainSelector)
+	}
+	slices.Sort(chains)
+	return slices.Compact(chains)
+}
+
+// RMNAdaptiveTimersConfig configures how the RMN initial request timers are derived from the observed
+// RMN node response times.
+type RMNAdaptiveTimersConfig struct {
+	// Percentile of the recent response times that is used as the initial request timer, e.g. 90 means that
+	// the timer is set such that 90% of the recent responses would have been received before it expires.
+	Percentile uint8 `json:"percentile"`
+
+	// MinSamples is the number of response times that must be observed before the adaptive timer is used.
+	// Until then the configured static timer is used.
+	MinSamples int `json:"minSamples"`
+
+	// WindowSize is the number of most recent response times the percentile is computed from.
+	WindowSize int `json:"windowSize"`
+
+	// MinTimer and MaxTimer bound the adaptive timer.
+	// MaxTimer defaults to half of the RMN signatures timeout.
+	MinTimer commonconfig.Duration `json:"minTimer"`
+	MaxTimer commonconfig.Duration `json:"maxTimer"`
+}
+
+func (a *RMNAdaptiveTimersConfig) applyDefaults(rmnSignaturesTimeout time.Duration) {
+	if a.Percentile == 0 {
+		a.Percentile = defaultRMNAdaptiveTimersPercentile
+	}
+
+	if a.MinSamples == 0 {
+		a.MinSamples = defaultRMNAdaptiveTimersMinSamples
+	}
+
+	if a.WindowSize == 0 {
+		a.WindowSize = defaultRMNAdaptiveTimersWindowSize
+	}
+
+	if a.MinTimer.Duration() == 0 {
+		a.MinTimer = *commonconfig.MustNewDuration(defaultRMNAdaptiveTimersMinTimer)
+	}
+
+	if a.MaxTimer.Duration() == 0 {
+		a.MaxTimer = *commonconfig.MustNewDuration(rmnSignaturesTimeout / 2)
+	}
+}
+
+func (a RMNAdaptiveTimersConfig) Validate() error {
+	if a.Percentile == 0 || a.Percentile > 100 {
+		return fmt.Errorf("percentile must be in [1, 100], got %d", a.Percentile)
+	}
+
+	if a.MinSamples <= 0 || a.WindowSize < a.MinSamples {
+		return fmt.Errorf("minSamples (%d) must be positive and not greater than windowSize (%d)",
+			a.MinSamples, a.WindowSize)
+	}
+
+	if a.MinTimer.Duration() == 0 || a.MinTimer.Duration() > a.MaxTimer.Duration() {
+		return fmt.Errorf("minTimer (%s) must be positive and not greater than maxTimer (%s)", a.MinTimer, a.MaxTimer)
+	}
+
+	return nil
 }
 
 func (a TokenInfo) Validate() error {
-	if a.AggregatorAddress == "" {
-		return errors.New("aggregatorAddress not set")
+	if a.DerivedPrice != nil {
+		if a.AggregatorAddress != "" || len(a.PriceSources) > 0 {
+			return errors.New("aggregatorAddress and priceSources must not be set for derived prices")
+		}
+		if a.DerivedPrice.BaseToken == "" {
+			return errors.New("derived price base token not set")
+		}
+		if err := a.DerivedPrice.Rate.Validate(); err != nil {
+			return fmt.Errorf("invalid derived price: %w", err)
+		}
+	} else if err := validateAggregatorAddress(a.AggregatorAddress); err != nil {
+		return err
 	}
 
-	// aggregator must be an ethereum address
-	decoded, err := hex.DecodeString(strings.ToLower(strings.TrimPrefix(a.AggregatorAddress, "0x")))
-	if err != nil {
-		return fmt.Errorf("aggregatorAddress must be a valid ethereum address (i.e hex encoded 20 bytes): %w", err)
+	for i, source := range a.PriceSources {
+		if err := validateAggregatorAddress(source.AggregatorAddress); err != nil {
+			return fmt.Errorf("invalid price source %d: %w", i, err)
+		}
 	}
-	if len(decoded) != 20 {
-		return fmt.Errorf("aggregatorAddress must be a valid ethereum address, got %d bytes expected 20", len(decoded))
+
+	if a.MinPriceSources < 0 || a.MinPriceSources > len(a.PriceSources)+1 {
+		return fmt.Errorf("minPriceSources (%d) must be between 0 and the number of price sources (%d)",
+			a.MinPriceSources, len(a.PriceSources)+1)
 	}
 
 	if a.DeviationPPB.Int.Cmp(big.NewInt(0)) <= 0 {
 		return errors.New("deviationPPB not set or negative, must be positive")
 	}
 
+	if a.MinFeedPrice != nil && (a.MinFeedPrice.Int == nil || a.MinFeedPrice.Sign() < 0) {
+		return errors.New("minFeedPrice must not be negative")
+	}
+
+	if a.MaxFeedPrice != nil {
+		if a.MaxFeedPrice.Int == nil || a.MaxFeedPrice.Sign() <= 0 {
+			return errors.New("maxFeedPrice must be positive")
+		}
+		if a.MinFeedPrice != nil && a.MinFeedPrice.Cmp(a.MaxFeedPrice.Int) > 0 {
+			return fmt.Errorf("minFeedPrice (%s) must not be greater than maxFeedPrice (%s)",
+				a.MinFeedPrice, a.MaxFeedPrice)
+		}
+	}
+
 	if a.Decimals == 0 {
 		return fmt.Errorf("tokenDecimals can't be zero")
 	}
@@ -71,6 +378,22 @@ func (a TokenInfo) Validate() error {
 	return nil
 }
 
+func validateAggregatorAddress(aggregatorAddress string) error {
+	if aggregatorAddress == "" {
+		return errors.New("aggregatorAddress not set")
+	}
+
+	// aggregator must be an ethereum address
+	decoded, err := hex.DecodeString(strings.ToLower(strings.TrimPrefix(aggregatorAddress, "0x")))
+	if err != nil {
+		return fmt.Errorf("aggregatorAddress must be a valid ethereum address (i.e hex encoded 20 bytes): %w", err)
+	}
+	if len(decoded) != 20 {
+		return fmt.Errorf("aggregatorAddress must be a valid ethereum address, got %d bytes expected 20", len(decoded))
+	}
+	return nil
+}
+
 // CommitOffchainConfig is the OCR offchainConfig for the commit plugin.
 // This is posted onchain as part of the OCR configuration process of the commit plugin.
 // Every plugin is provided this configuration in its encoded form in the NewReportingPlugin
@@ -81,13 +404,26 @@ type CommitOffchainConfig struct {
 	//TODO: Rename to something with ChainFee
 	RemoteGasPriceBatchWriteFrequency commonconfig.Duration `json:"remoteGasPriceBatchWriteFrequency"`
 
+	// FeeInfo optionally overrides, per chain, the gas price deviation thresholds of the home chain ChainConfig.
 	FeeInfo map[cciptypes.ChainSelector]FeeInfo `json:"feeInfo"`
 
+	// MaxGasPriceUpdatesPerReport is the maximum number of gas price updates in a single report.
+	// If more chains need an update, the chains missing from the fee quoter and then the ones whose fees deviate the
+	// most are selected first, the rest are updated in the next rounds.
+	// If set to zero, the number of gas price updates is not capped.
+	MaxGasPriceUpdatesPerReport int `json:"maxGasPriceUpdatesPerReport,omitempty"`
+
 	// TokenPriceBatchWriteFrequency is the frequency at which the commit plugin should
 	// write token prices to the remote chain.
 	// If set to zero, no prices will be written (i.e keystone feeds would be active).
+	// It can be overridden per token with TokenInfo.Heartbeat.
 	TokenPriceBatchWriteFrequency commonconfig.Duration `json:"tokenPriceBatchWriteFrequency"`
 
+	// MaxTokenPriceUpdatesPerReport is the maximum number of token price updates in a single report.
+	// If more tokens need an update, the ones deviating or overdue the most are selected first and the rest are
+	// updated in the next rounds. If set to zero, the number of token price updates is not capped.
+	MaxTokenPriceUpdatesPerReport int `json:"maxTokenPriceUpdatesPerReport,omitempty"`
+
 	// TokenInfo is a map of Arbitrum price sources for each token.
 	// Note that the token address is that on the remote chain.
 	TokenInfo map[types.Account]TokenInfo `json:"tokenInfo"`
@@ -111,6 +447,22 @@ type CommitOffchainConfig struct {
 	// RMNEnabled is a flag to enable/disable RMN signature verification.
 	RMNEnabled bool `json:"rmnEnabled"`
 
+	// RMNPollingInterval is the interval at which the RMNHome and RMNRemote configs are polled when RMN is enabled.
+	// A polled config is considered stale after reader.MaxFailedPolls consecutive failed polls.
+	RMNPollingInterval commonconfig.Duration `json:"rmnPollingInterval"`
+
+	// RMNObservationsInitialRequestTimer is how long to wait for the observations of the initially requested
+	// RMN nodes before requesting observations from the rest of the RMN nodes.
+	RMNObservationsInitialRequestTimer commonconfig.Duration `json:"rmnObservationsInitialRequestTimer"`
+
+	// RMNReportsInitialRequestTimer is how long to wait for the report signatures of the initially requested
+	// RMN nodes before requesting report signatures from the rest of the RMN nodes.
+	RMNReportsInitialRequestTimer commonconfig.Duration `json:"rmnReportsInitialRequestTimer"`
+
+	// RMNAdaptiveTimers, if set, derives the RMN initial request timers from the observed RMN node response times
+	// instead of using RMNObservationsInitialRequestTimer and RMNReportsInitialRequestTimer.
+	RMNAdaptiveTimers *RMNAdaptiveTimersConfig `json:"rmnAdaptiveTimers,omitempty"`
+
 	// MaxMerkleTreeSize is the maximum size of a merkle tree to create prior to calculating the merkle root.
 	// If for example in the next round we have 1000 pending messages and a max tree size of 256, only 256 seq nums
 	// will be in the report. If a value is not set we fallback to EvmDefaultMaxMerkleTreeSize.
@@ -118,6 +470,21 @@ type CommitOffchainConfig struct {
 
 	// SignObservationPrefix is the prefix used by the RMN node to sign observations.
 	SignObservationPrefix string `json:"signObservationPrefix"`
+
+	// ReorgCheckInterval is the interval at which the merkle roots committed within ReorgCheckLookback are
+	// recomputed from the source chain messages and compared with the committed roots, in order to detect source
+	// chain reorgs. If set to zero, committed roots are not verified.
+	ReorgCheckInterval commonconfig.Duration `json:"reorgCheckInterval"`
+
+	// ReorgCheckLookback is how far back in time committed reports are verified.
+	// Defaults to one hour if ReorgCheckInterval is set.
+	ReorgCheckLookback commonconfig.Duration `json:"reorgCheckLookback"`
+
+	// PauseLaneOnReorg stops committing messages of a source chain once one of its committed merkle roots no longer
+	// matches the messages on the source chain. Disabled by default, detected reorgs are then only reported. The
+	// mismatching roots are verified again on every reorg check and the lane resumes once they all match the source
+	// chain again.
+	PauseLaneOnReorg bool `json:"pauseLaneOnReorg"`
 }
 
 func (c *CommitOffchainConfig) applyDefaults() {
@@ -125,6 +492,22 @@ func (c *CommitOffchainConfig) applyDefaults() {
 		c.RMNSignaturesTimeout = defaultRMNSignaturesTimeout
 	}
 
+	if c.RMNEnabled && c.RMNPollingInterval.Duration() == 0 {
+		c.RMNPollingInterval = *commonconfig.MustNewDuration(defaultRMNPollingInterval)
+	}
+
+	if c.RMNEnabled && c.RMNObservationsInitialRequestTimer.Duration() == 0 {
+		c.RMNObservationsInitialRequestTimer = *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer)
+	}
+
+	if c.RMNEnabled && c.RMNReportsInitialRequestTimer.Duration() == 0 {
+		c.RMNReportsInitialRequestTimer = *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer)
+	}
+
+	if c.RMNAdaptiveTimers != nil {
+		c.RMNAdaptiveTimers.applyDefaults(c.RMNSignaturesTimeout)
+	}
+
 	if c.NewMsgScanBatchSize == 0 {
 		c.NewMsgScanBatchSize = defaultNewMsgScanBatchSize
 	}
@@ -144,6 +527,10 @@ func (c *CommitOffchainConfig) applyDefaults() {
 	if c.SignObservationPrefix == "" {
 		c.SignObservationPrefix = defaultSignObservationPrefix
 	}
+
+	if c.ReorgCheckInterval.Duration() > 0 && c.ReorgCheckLookback.Duration() == 0 {
+		c.ReorgCheckLookback = *commonconfig.MustNewDuration(defaultReorgCheckLookback)
+	}
 }
 
 func (c *CommitOffchainConfig) Validate() error {
@@ -165,6 +552,28 @@ func (c *CommitOffchainConfig) Validate() error {
 		if err := tokenInfo.Validate(); err != nil {
 			return fmt.Errorf("invalid token info for token %s: %w", token, err)
 		}
+		if tokenInfo.DerivedPrice == nil {
+			continue
+		}
+		baseTokenInfo, ok := c.TokenInfo[tokenInfo.DerivedPrice.BaseToken]
+		if !ok || baseTokenInfo.DerivedPrice != nil {
+			return fmt.Errorf("base token %s of derived token %s must have a token info with a USD price feed",
+				tokenInfo.DerivedPrice.BaseToken, token)
+		}
+	}
+
+	for chain, feeInfo := range c.FeeInfo {
+		if err := feeInfo.Validate(); err != nil {
+			return fmt.Errorf("invalid fee info for chain %d: %w", chain, err)
+		}
+	}
+
+	if c.MaxGasPriceUpdatesPerReport < 0 {
+		return fmt.Errorf("maxGasPriceUpdatesPerReport must not be negative, got %d", c.MaxGasPriceUpdatesPerReport)
+	}
+
+	if c.MaxTokenPriceUpdatesPerReport < 0 {
+		return fmt.Errorf("maxTokenPriceUpdatesPerReport must not be negative, got %d", c.MaxTokenPriceUpdatesPerReport)
 	}
 
 	if c.NewMsgScanBatchSize == 0 {
@@ -175,6 +584,24 @@ func (c *CommitOffchainConfig) Validate() error {
 		return fmt.Errorf("rmnSignaturesTimeout not set")
 	}
 
+	if c.RMNEnabled && c.RMNPollingInterval.Duration() <= 0 {
+		return fmt.Errorf("rmnPollingInterval not set")
+	}
+
+	if c.RMNEnabled && c.RMNObservationsInitialRequestTimer.Duration() == 0 {
+		return fmt.Errorf("rmnObservationsInitialRequestTimer not set")
+	}
+
+	if c.RMNEnabled && c.RMNReportsInitialRequestTimer.Duration() == 0 {
+		return fmt.Errorf("rmnReportsInitialRequestTimer not set")
+	}
+
+	if c.RMNAdaptiveTimers != nil {
+		if err := c.RMNAdaptiveTimers.Validate(); err != nil {
+			return fmt.Errorf("invalid rmnAdaptiveTimers: %w", err)
+		}
+	}
+
 	if c.MaxReportTransmissionCheckAttempts == 0 {
 		return fmt.Errorf("maxReportTransmissionCheckAttempts not set")
 	}
@@ -187,6 +614,14 @@ func (c *CommitOffchainConfig) Validate() error {
 		return fmt.Errorf("signObservationPrefix not set")
 	}
 
+	if c.ReorgCheckInterval.Duration() > 0 && c.ReorgCheckLookback.Duration() == 0 {
+		return fmt.Errorf("reorgCheckLookback not set")
+	}
+
+	if c.PauseLaneOnReorg && c.ReorgCheckInterval.Duration() == 0 {
+		return fmt.Errorf("pauseLaneOnReorg requires reorgCheckInterval to be set")
+	}
+
 	return nil
 }
 
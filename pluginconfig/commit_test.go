@@ -21,6 +21,10 @@ func TestArbitrumPriceSource_Validate(t *testing.T) {
 		AggregatorAddress string
 		DeviationPPB      cciptypes.BigInt
 		Decimals          uint8
+		PriceSources      []PriceSource
+		MinPriceSources   int
+		MinFeedPrice      *cciptypes.BigInt
+		MaxFeedPrice      *cciptypes.BigInt
 	}
 	tests := []struct {
 		name    string
@@ -99,6 +103,73 @@ func TestArbitrumPriceSource_Validate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"valid, multiple price sources",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:          18,
+				PriceSources: []PriceSource{
+					{AggregatorAddress: "0x2e03388d351bf87cf2409eff18c45df59775fbb3"},
+					{AggregatorAddress: "0x2e03388d351bf87cf2409eff18c45df59775fbb4", ChainSelector: 1},
+				},
+				MinPriceSources: 2,
+			},
+			false,
+		},
+		{
+			"invalid, price source address",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:          18,
+				PriceSources:      []PriceSource{{AggregatorAddress: "0x2e03"}},
+			},
+			true,
+		},
+		{
+			"invalid, more min price sources than sources",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:          18,
+				PriceSources:      []PriceSource{{AggregatorAddress: "0x2e03388d351bf87cf2409eff18c45df59775fbb3"}},
+				MinPriceSources:   3,
+			},
+			true,
+		},
+		{
+			"valid, feed price bounds",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:          18,
+				MinFeedPrice:      &cciptypes.BigInt{Int: big.NewInt(1)},
+				MaxFeedPrice:      &cciptypes.BigInt{Int: big.NewInt(2)},
+			},
+			false,
+		},
+		{
+			"invalid, min feed price greater than max feed price",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:          18,
+				MinFeedPrice:      &cciptypes.BigInt{Int: big.NewInt(3)},
+				MaxFeedPrice:      &cciptypes.BigInt{Int: big.NewInt(2)},
+			},
+			true,
+		},
+		{
+			"invalid, zero max feed price",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:          18,
+				MaxFeedPrice:      &cciptypes.BigInt{Int: big.NewInt(0)},
+			},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -106,6 +177,10 @@ func TestArbitrumPriceSource_Validate(t *testing.T) {
 				AggregatorAddress: tt.fields.AggregatorAddress,
 				DeviationPPB:      tt.fields.DeviationPPB,
 				Decimals:          tt.fields.Decimals,
+				PriceSources:      tt.fields.PriceSources,
+				MinPriceSources:   tt.fields.MinPriceSources,
+				MinFeedPrice:      tt.fields.MinFeedPrice,
+				MaxFeedPrice:      tt.fields.MaxFeedPrice,
 			}
 			if err := a.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("TokenInfo.Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -125,9 +200,13 @@ func TestCommitOffchainConfig_Validate(t *testing.T) {
 		MaxReportTransmissionCheckAttempts uint32
 		MaxMerkleTreeSize                  uint32
 		SignObservationPrefix              string
+		MaxTokenPriceUpdatesPerReport      int
+		MaxGasPriceUpdatesPerReport        int
 	}
 	remoteTokenAddress := rand.RandomAddress()
 	aggregatorAddress := string(rand.RandomAddress())
+	derivedTokenAddress := rand.RandomAddress()
+	rateAddress := string(rand.RandomAddress())
 	tests := []struct {
 		name    string
 		fields  fields
@@ -204,6 +283,81 @@ func TestCommitOffchainConfig_Validate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"valid, derived token price",
+			fields{
+				RemoteGasPriceBatchWriteFrequency: *commonconfig.MustNewDuration(1),
+				TokenPriceBatchWriteFrequency:     *commonconfig.MustNewDuration(1),
+				TokenInfo: map[types.Account]TokenInfo{
+					remoteTokenAddress: {
+						AggregatorAddress: aggregatorAddress,
+						DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+						Decimals:          18,
+					},
+					derivedTokenAddress: {
+						DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+						Decimals:     18,
+						DerivedPrice: &DerivedPriceConfig{
+							BaseToken: remoteTokenAddress,
+							Rate:      RateSource{Address: rateAddress},
+						},
+					},
+				},
+				TokenPriceChainSelector:            10,
+				NewMsgScanBatchSize:                256,
+				MaxReportTransmissionCheckAttempts: 10,
+				MaxMerkleTreeSize:                  1000,
+				SignObservationPrefix:              defaultSignObservationPrefix,
+			},
+			false,
+		},
+		{
+			"invalid, negative max token price updates per report",
+			fields{
+				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(1),
+				NewMsgScanBatchSize:                256,
+				MaxReportTransmissionCheckAttempts: 10,
+				MaxMerkleTreeSize:                  1000,
+				SignObservationPrefix:              defaultSignObservationPrefix,
+				MaxTokenPriceUpdatesPerReport:      -1,
+			},
+			true,
+		},
+		{
+			"invalid, negative max gas price updates per report",
+			fields{
+				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(1),
+				NewMsgScanBatchSize:                256,
+				MaxReportTransmissionCheckAttempts: 10,
+				MaxMerkleTreeSize:                  1000,
+				SignObservationPrefix:              defaultSignObservationPrefix,
+				MaxGasPriceUpdatesPerReport:        -1,
+			},
+			true,
+		},
+		{
+			"invalid, derived token price without base token info",
+			fields{
+				RemoteGasPriceBatchWriteFrequency: *commonconfig.MustNewDuration(1),
+				TokenPriceBatchWriteFrequency:     *commonconfig.MustNewDuration(1),
+				TokenInfo: map[types.Account]TokenInfo{
+					derivedTokenAddress: {
+						DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+						Decimals:     18,
+						DerivedPrice: &DerivedPriceConfig{
+							BaseToken: remoteTokenAddress,
+							Rate:      RateSource{Address: rateAddress},
+						},
+					},
+				},
+				TokenPriceChainSelector:            10,
+				NewMsgScanBatchSize:                256,
+				MaxReportTransmissionCheckAttempts: 10,
+				MaxMerkleTreeSize:                  1000,
+				SignObservationPrefix:              defaultSignObservationPrefix,
+			},
+			true,
+		},
 		{
 			"invalid, no new msg scan batch size",
 			fields{
@@ -247,6 +401,8 @@ func TestCommitOffchainConfig_Validate(t *testing.T) {
 				MaxReportTransmissionCheckAttempts: uint(tt.fields.MaxReportTransmissionCheckAttempts),
 				MaxMerkleTreeSize:                  uint64(tt.fields.MaxMerkleTreeSize),
 				SignObservationPrefix:              tt.fields.SignObservationPrefix,
+				MaxTokenPriceUpdatesPerReport:      tt.fields.MaxTokenPriceUpdatesPerReport,
+				MaxGasPriceUpdatesPerReport:        tt.fields.MaxGasPriceUpdatesPerReport,
 			}
 			err := c.Validate()
 			if tt.wantErr {
@@ -343,6 +499,9 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 			expected: CommitOffchainConfig{
 				RMNEnabled:                         true,
 				RMNSignaturesTimeout:               defaultRMNSignaturesTimeout,
+				RMNObservationsInitialRequestTimer: *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer),
+				RMNReportsInitialRequestTimer:      *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer),
+				RMNPollingInterval:                 *commonconfig.MustNewDuration(defaultRMNPollingInterval),
 				NewMsgScanBatchSize:                defaultNewMsgScanBatchSize,
 				MaxReportTransmissionCheckAttempts: defaultMaxReportTransmissionCheckAttempts,
 				MaxMerkleTreeSize:                  defaultEvmDefaultMaxMerkleTreeSize,
@@ -362,6 +521,9 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 			expected: CommitOffchainConfig{
 				RMNEnabled:                         true,
 				RMNSignaturesTimeout:               5 * time.Minute,
+				RMNObservationsInitialRequestTimer: *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer),
+				RMNReportsInitialRequestTimer:      *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer),
+				RMNPollingInterval:                 *commonconfig.MustNewDuration(defaultRMNPollingInterval),
 				NewMsgScanBatchSize:                500,
 				MaxReportTransmissionCheckAttempts: 10,
 				MaxMerkleTreeSize:                  1000,
@@ -379,6 +541,9 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 			expected: CommitOffchainConfig{
 				RMNEnabled:                         true,
 				RMNSignaturesTimeout:               defaultRMNSignaturesTimeout,
+				RMNObservationsInitialRequestTimer: *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer),
+				RMNReportsInitialRequestTimer:      *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer),
+				RMNPollingInterval:                 *commonconfig.MustNewDuration(defaultRMNPollingInterval),
 				NewMsgScanBatchSize:                300,
 				MaxReportTransmissionCheckAttempts: defaultMaxReportTransmissionCheckAttempts,
 				MaxMerkleTreeSize:                  500,
@@ -386,6 +551,47 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 				SignObservationPrefix:              defaultSignObservationPrefix,
 			},
 		},
+		{
+			name: "Reorg checks enabled without lookback",
+			input: CommitOffchainConfig{
+				ReorgCheckInterval: *commonconfig.MustNewDuration(time.Minute),
+			},
+			expected: CommitOffchainConfig{
+				NewMsgScanBatchSize:                defaultNewMsgScanBatchSize,
+				MaxReportTransmissionCheckAttempts: defaultMaxReportTransmissionCheckAttempts,
+				MaxMerkleTreeSize:                  defaultEvmDefaultMaxMerkleTreeSize,
+				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(defaultRemoteGasPriceBatchWriteFrequency),
+				SignObservationPrefix:              defaultSignObservationPrefix,
+				ReorgCheckInterval:                 *commonconfig.MustNewDuration(time.Minute),
+				ReorgCheckLookback:                 *commonconfig.MustNewDuration(defaultReorgCheckLookback),
+			},
+		},
+		{
+			name: "RMN adaptive timers with partial values",
+			input: CommitOffchainConfig{
+				RMNEnabled:        true,
+				RMNAdaptiveTimers: &RMNAdaptiveTimersConfig{Percentile: 95},
+			},
+			expected: CommitOffchainConfig{
+				RMNEnabled:                         true,
+				RMNSignaturesTimeout:               defaultRMNSignaturesTimeout,
+				RMNObservationsInitialRequestTimer: *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer),
+				RMNReportsInitialRequestTimer:      *commonconfig.MustNewDuration(defaultRMNInitialRequestTimer),
+				RMNPollingInterval:                 *commonconfig.MustNewDuration(defaultRMNPollingInterval),
+				RMNAdaptiveTimers: &RMNAdaptiveTimersConfig{
+					Percentile: 95,
+					MinSamples: defaultRMNAdaptiveTimersMinSamples,
+					WindowSize: defaultRMNAdaptiveTimersWindowSize,
+					MinTimer:   *commonconfig.MustNewDuration(defaultRMNAdaptiveTimersMinTimer),
+					MaxTimer:   *commonconfig.MustNewDuration(defaultRMNSignaturesTimeout / 2),
+				},
+				NewMsgScanBatchSize:                defaultNewMsgScanBatchSize,
+				MaxReportTransmissionCheckAttempts: defaultMaxReportTransmissionCheckAttempts,
+				MaxMerkleTreeSize:                  defaultEvmDefaultMaxMerkleTreeSize,
+				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(defaultRemoteGasPriceBatchWriteFrequency),
+				SignObservationPrefix:              defaultSignObservationPrefix,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -423,9 +629,51 @@ func TestCommitOffchainConfig_ApplyDefaultsAndValidate(t *testing.T) {
 				MaxReportTransmissionCheckAttempts: 10,
 				MaxMerkleTreeSize:                  1000,
 				RMNEnabled:                         true,
+				RMNPollingInterval:                 *commonconfig.MustNewDuration(30 * time.Second),
 				SignObservationPrefix:              defaultSignObservationPrefix,
 			},
 		},
+		{
+			name: "Pausing lanes on reorg requires reorg checks",
+			input: CommitOffchainConfig{
+				PauseLaneOnReorg: true,
+			},
+			expectedError: "pauseLaneOnReorg requires reorgCheckInterval",
+		},
+		{
+			name: "RMN adaptive timers with defaults",
+			input: CommitOffchainConfig{
+				RMNEnabled:        true,
+				RMNAdaptiveTimers: &RMNAdaptiveTimersConfig{},
+			},
+		},
+		{
+			name: "RMN adaptive timers with invalid percentile",
+			input: CommitOffchainConfig{
+				RMNEnabled:        true,
+				RMNAdaptiveTimers: &RMNAdaptiveTimersConfig{Percentile: 101},
+			},
+			expectedError: "percentile must be in [1, 100]",
+		},
+		{
+			name: "RMN adaptive timers with min timer above max timer",
+			input: CommitOffchainConfig{
+				RMNEnabled: true,
+				RMNAdaptiveTimers: &RMNAdaptiveTimersConfig{
+					MinTimer: *commonconfig.MustNewDuration(3 * time.Second),
+					MaxTimer: *commonconfig.MustNewDuration(time.Second),
+				},
+			},
+			expectedError: "must be positive and not greater than maxTimer",
+		},
+		{
+			name: "RMN adaptive timers with window smaller than min samples",
+			input: CommitOffchainConfig{
+				RMNEnabled:        true,
+				RMNAdaptiveTimers: &RMNAdaptiveTimersConfig{MinSamples: 10, WindowSize: 5},
+			},
+			expectedError: "must be positive and not greater than windowSize",
+		},
 	}
 
 	for _, tt := range tests {
@@ -456,3 +704,135 @@ func TestCommitOffchainConfig_ApplyDefaultsAndValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitOffchainConfig_TokenPriceChains(t *testing.T) {
+	const (
+		tokenA  = types.Account("0xa")
+		tokenB  = types.Account("0xb")
+		derived = types.Account("0xd")
+	)
+	cfg := CommitOffchainConfig{
+		PriceFeedChainSelector: 1,
+		TokenInfo: map[types.Account]TokenInfo{
+			tokenA: {PriceSources: []PriceSource{{ChainSelector: 3}, {}, {ChainSelector: 2}}},
+			tokenB: {},
+			derived: {DerivedPrice: &DerivedPriceConfig{
+				BaseToken: tokenA,
+				Rate:      RateSource{ChainSelector: 4},
+			}},
+		},
+	}
+
+	assert.Equal(t, []cciptypes.ChainSelector{1, 2, 3}, cfg.TokenPriceChains(tokenA))
+	assert.Equal(t, []cciptypes.ChainSelector{1}, cfg.TokenPriceChains(tokenB))
+	assert.Equal(t, []cciptypes.ChainSelector{1, 2, 3, 4}, cfg.TokenPriceChains(derived))
+	assert.Empty(t, cfg.TokenPriceChains("0xunknown"))
+}
+
+func TestRateSource_Validate(t *testing.T) {
+	const address = "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2"
+	tests := []struct {
+		name    string
+		rate    RateSource
+		wantErr bool
+	}{
+		{
+			name: "valid, ratio feed",
+			rate: RateSource{Address: address},
+		},
+		{
+			name: "valid, contract read",
+			rate: RateSource{Address: address, ContractName: "StakedToken", MethodName: "getExchangeRate", Decimals: 18},
+		},
+		{
+			name:    "invalid, address",
+			rate:    RateSource{Address: "0x2e03"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid, contract name without method name",
+			rate:    RateSource{Address: address, ContractName: "StakedToken"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid, decimals of a ratio feed",
+			rate:    RateSource{Address: address, Decimals: 18},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rate.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFeeAggregationConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     FeeAggregationConfig
+		wantErr bool
+	}{
+		{
+			name: "valid, default",
+			cfg:  FeeAggregationConfig{},
+		},
+		{
+			name: "valid, median with smoothing",
+			cfg:  FeeAggregationConfig{Method: FeeAggregationMedian, SmoothingWeightPercent: 20},
+		},
+		{
+			name: "valid, trimmed mean",
+			cfg:  FeeAggregationConfig{Method: FeeAggregationTrimmedMean, TrimCount: 2},
+		},
+		{
+			name: "valid, median max spread",
+			cfg:  FeeAggregationConfig{Method: FeeAggregationMedianMaxSpread, MaxSpreadPPB: 1e8},
+		},
+		{
+			name:    "invalid, unknown method",
+			cfg:     FeeAggregationConfig{Method: "mode"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid, median max spread without spread",
+			cfg:     FeeAggregationConfig{Method: FeeAggregationMedianMaxSpread},
+			wantErr: true,
+		},
+		{
+			name:    "invalid, spread for median",
+			cfg:     FeeAggregationConfig{MaxSpreadPPB: 1e8},
+			wantErr: true,
+		},
+		{
+			name:    "invalid, trim count for median",
+			cfg:     FeeAggregationConfig{TrimCount: 1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid, negative trim count",
+			cfg:     FeeAggregationConfig{Method: FeeAggregationTrimmedMean, TrimCount: -1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid, smoothing weight",
+			cfg:     FeeAggregationConfig{SmoothingWeightPercent: 101},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
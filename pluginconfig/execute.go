@@ -3,10 +3,26 @@ package pluginconfig
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	commonconfig "github.com/goplugin/plugin-common/pkg/config"
 )
 
+// We use this default value when the config is not set.
+const (
+	defaultMaxReportSizeBytes = 250_000
+	defaultMaxMessageStatuses = 64
+	// defaultMerkleTreeCacheSize keeps the trees of 128 commit reports, e.g. a few pending roots for each of dozens of
+	// source chains.
+	defaultMerkleTreeCacheSize = 128
+
+	// maxMessageStatusesLimit bounds MaxMessageStatuses, the statuses are part of the outcome.
+	maxMessageStatusesLimit = 1024
+	// maxMerkleTreeCacheSizeLimit bounds MerkleTreeCacheSize, a full tree and its message leaf hashes take about
+	// 100KB of memory.
+	maxMerkleTreeCacheSizeLimit = 4096
+)
+
 // ExecuteOffchainConfig is the OCR offchainConfig for the exec plugin.
 // This is posted onchain as part of the OCR configuration process of the exec plugin.
 // Every plugin is provided this configuration in its encoded form in the NewReportingPlugin
@@ -36,6 +52,54 @@ type ExecuteOffchainConfig struct {
 
 	// TokenDataObservers registers different strategies for processing token data.
 	TokenDataObservers []TokenDataObserverConfig `json:"tokenDataObservers"`
+
+	// MaxReportSizeBytes is the maximum size of an encoded execution report.
+	// If not set, defaultMaxReportSizeBytes is used.
+	MaxReportSizeBytes uint64 `json:"maxReportSizeBytes"`
+
+	// MaxMessagesPerReport is the maximum number of messages in one execution report.
+	// Zero means no limit.
+	MaxMessagesPerReport uint64 `json:"maxMessagesPerReport"`
+
+	// MaxTokenTransfersPerReport is the maximum number of token transfers across all messages in one execution report.
+	// Zero means no limit.
+	MaxTokenTransfersPerReport uint64 `json:"maxTokenTransfersPerReport"`
+
+	// MaxMessageDataBytesPerReport is the maximum sum of message data sizes in one execution report.
+	// Zero means no limit.
+	MaxMessageDataBytesPerReport uint64 `json:"maxMessageDataBytesPerReport"`
+
+	// MaxSourceChainsPerReport is the maximum number of source chains in one execution report.
+	// Zero means no limit.
+	MaxSourceChainsPerReport uint64 `json:"maxSourceChainsPerReport"`
+
+	// MaxMessageStatuses is the maximum number of message statuses explaining why messages were not executed that
+	// are kept in the outcome. The statuses of the oldest messages are kept.
+	// If not set, defaultMaxMessageStatuses is used.
+	MaxMessageStatuses uint64 `json:"maxMessageStatuses"`
+
+	// MerkleTreeCacheSize is the number of verified commit report merkle trees which are kept across rounds to
+	// generate proofs without rehashing the messages. The leaf hashes of up to merklemulti.MaxNumberTreeLeaves
+	// messages are kept for each tree, it should be at least the number of commit reports pending execution.
+	// If not set, defaultMerkleTreeCacheSize is used.
+	MerkleTreeCacheSize uint64 `json:"merkleTreeCacheSize"`
+}
+
+func (e *ExecuteOffchainConfig) applyDefaults() {
+	if e.MaxReportSizeBytes == 0 {
+		e.MaxReportSizeBytes = defaultMaxReportSizeBytes
+	}
+	if e.MaxMessageStatuses == 0 {
+		e.MaxMessageStatuses = defaultMaxMessageStatuses
+	}
+	if e.MerkleTreeCacheSize == 0 {
+		e.MerkleTreeCacheSize = defaultMerkleTreeCacheSize
+	}
+}
+
+func (e *ExecuteOffchainConfig) ApplyDefaultsAndValidate() error {
+	e.applyDefaults()
+	return e.Validate()
 }
 
 func (e ExecuteOffchainConfig) Validate() error {
@@ -62,6 +126,15 @@ func (e ExecuteOffchainConfig) Validate() error {
 		return errors.New("MessageVisibilityInterval not set")
 	}
 
+	if e.MaxMessageStatuses > maxMessageStatusesLimit {
+		return fmt.Errorf("MaxMessageStatuses %d exceeds the limit of %d", e.MaxMessageStatuses, maxMessageStatusesLimit)
+	}
+
+	if e.MerkleTreeCacheSize > maxMerkleTreeCacheSizeLimit {
+		return fmt.Errorf("MerkleTreeCacheSize %d exceeds the limit of %d",
+			e.MerkleTreeCacheSize, maxMerkleTreeCacheSizeLimit)
+	}
+
 	set := make(map[string]struct{})
 	for _, ob := range e.TokenDataObservers {
 		if err := ob.Validate(); err != nil {
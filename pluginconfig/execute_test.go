@@ -181,3 +181,32 @@ func TestExecuteOffchainConfig_EncodeDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteOffchainConfig_ApplyDefaultsAndValidate(t *testing.T) {
+	cfg := ExecuteOffchainConfig{
+		BatchGasLimit:             1,
+		RelativeBoostPerWaitHour:  1,
+		InflightCacheExpiry:       *commonconfig.MustNewDuration(1),
+		RootSnoozeTime:            *commonconfig.MustNewDuration(1),
+		MessageVisibilityInterval: *commonconfig.MustNewDuration(1),
+	}
+	require.NoError(t, cfg.ApplyDefaultsAndValidate())
+	require.Equal(t, uint64(defaultMaxReportSizeBytes), cfg.MaxReportSizeBytes)
+	require.Equal(t, uint64(defaultMaxMessageStatuses), cfg.MaxMessageStatuses)
+	require.Equal(t, uint64(defaultMerkleTreeCacheSize), cfg.MerkleTreeCacheSize)
+
+	cfg.MaxReportSizeBytes = 100_000
+	cfg.MaxMessageStatuses = 256
+	cfg.MerkleTreeCacheSize = 16
+	require.NoError(t, cfg.ApplyDefaultsAndValidate())
+	require.Equal(t, uint64(100_000), cfg.MaxReportSizeBytes)
+	require.Equal(t, uint64(256), cfg.MaxMessageStatuses)
+	require.Equal(t, uint64(16), cfg.MerkleTreeCacheSize)
+
+	cfg.MaxMessageStatuses = maxMessageStatusesLimit + 1
+	require.Error(t, cfg.ApplyDefaultsAndValidate())
+
+	cfg.MaxMessageStatuses = 256
+	cfg.MerkleTreeCacheSize = maxMerkleTreeCacheSizeLimit + 1
+	require.ErrorContains(t, cfg.ApplyDefaultsAndValidate(), "MerkleTreeCacheSize")
+}